@@ -0,0 +1,63 @@
+// Command kbindex rebuilds the policy knowledge-base index from the
+// documents in KNOWLEDGE_DIR and writes it to KNOWLEDGE_INDEX_PATH.
+//
+//	go run ./cmd/kbindex
+//	go run ./cmd/kbindex -query "คืนสินค้าได้กี่วัน"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+	"github.com/kelseyhightower/envconfig"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/knowledge"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+func main() {
+	var envCfg model.KnowledgeConfig
+	_ = godotenv.Load(".env")
+	if err := envconfig.Process("", &envCfg); err != nil {
+		log.Fatalf("Failed to process environment config: %v", err)
+	}
+
+	dir := flag.String("dir", envCfg.Dir, "documents directory")
+	out := flag.String("out", envCfg.IndexPath, "index file to write")
+	query := flag.String("query", "", "optional query to try against the new index")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := knowledge.Config{
+		Dir:       *dir,
+		IndexPath: *out,
+		Chunks:    knowledge.ChunkOptions{Size: envCfg.ChunkSize, Overlap: envCfg.ChunkOverlap},
+		Embedder:  knowledge.NewHashEmbedder(envCfg.EmbeddingDim),
+	}
+	ix, err := knowledge.Rebuild(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to rebuild knowledge index: %v", err)
+	}
+	fmt.Printf("Indexed %d documents into %d chunks (%s) -> %s\n", ix.Documents, len(ix.Entries), ix.Embedder, *out)
+
+	if *query == "" {
+		return
+	}
+	results, err := knowledge.NewBase(ix, cfg.Embedder).Search(ctx, *query, 3)
+	if err != nil {
+		log.Fatalf("Search failed: %v", err)
+	}
+	for i, r := range results {
+		where := r.Source
+		if r.Section != "" {
+			where += " > " + r.Section
+		}
+		if r.Page > 0 {
+			where += fmt.Sprintf(" (p. %d)", r.Page)
+		}
+		fmt.Printf("[%d] %.3f %s\n    %s\n", i+1, r.Score, where, r.Text)
+	}
+}
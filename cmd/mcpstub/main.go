@@ -0,0 +1,209 @@
+// Command mcpstub is a minimal MCP server for trying and testing the MCP
+// client. It serves over stdio by default, or over streamable HTTP with -http.
+//
+//	go run ./cmd/mcpstub                 # stdio
+//	go run ./cmd/mcpstub -http :8765     # POST http://localhost:8765/mcp
+//
+// Tools: get_store_hours (read-only), echo, and fail (always reports a tool
+// error). Set -delay to slow every call down, e.g. to exercise timeouts.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+var storeHours = map[string]string{
+	"bkk-siam":    "10:00-22:00",
+	"bkk-ladprao": "10:00-21:00",
+	"cnx-maya":    "11:00-21:00",
+}
+
+var tools = []map[string]any{
+	{
+		"name":        "get_store_hours",
+		"description": "Opening hours of a TechHub branch. Use this tool when customer asks when a store opens or closes.",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"branch": map[string]any{"type": "string", "description": "Branch ID: bkk-siam, bkk-ladprao or cnx-maya"},
+			},
+			"required": []string{"branch"},
+		},
+		"annotations": map[string]any{"readOnlyHint": true},
+	},
+	{
+		"name":        "echo",
+		"description": "Return the given text.",
+		"inputSchema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"text": map[string]any{"type": "string"}},
+		},
+	},
+	{
+		"name":        "fail",
+		"description": "Always fails; for testing error handling.",
+		"inputSchema": map[string]any{"type": "object"},
+	},
+}
+
+var delay time.Duration
+
+func main() {
+	addr := flag.String("http", "", "serve streamable HTTP on this address instead of stdio")
+	flag.DurationVar(&delay, "delay", 0, "added to every tools/call")
+	flag.Parse()
+
+	if *addr != "" {
+		log.Printf("mcpstub listening on %s/mcp", *addr)
+		log.Fatal(http.ListenAndServe(*addr, httpHandler()))
+	}
+	serveStdio()
+}
+
+// handle answers one message; notifications get no answer (nil).
+func handle(req *message) *message {
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &message{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		resp.Result = map[string]any{
+			"protocolVersion": p.ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "mcpstub", "version": "0.1.0"},
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": tools}
+	case "tools/call":
+		resp.Result = callTool(req.Params)
+	default:
+		resp.Error = &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+	}
+	return resp
+}
+
+func callTool(params json.RawMessage) map[string]any {
+	time.Sleep(delay)
+	var p struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return toolResult(true, "invalid params: "+err.Error())
+	}
+	switch p.Name {
+	case "get_store_hours":
+		hours, ok := storeHours[p.Arguments["branch"]]
+		if !ok {
+			return toolResult(true, fmt.Sprintf("unknown branch %q", p.Arguments["branch"]))
+		}
+		res := toolResult(false, hours)
+		res["structuredContent"] = map[string]any{"branch": p.Arguments["branch"], "hours": hours}
+		return res
+	case "echo":
+		return toolResult(false, p.Arguments["text"])
+	case "fail":
+		return toolResult(true, "this tool always fails")
+	}
+	return toolResult(true, "unknown tool "+p.Name)
+}
+
+func toolResult(isError bool, text string) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func serveStdio() {
+	sc := bufio.NewScanner(os.Stdin)
+	sc.Buffer(make([]byte, 64*1024), 8<<20)
+	var mu sync.Mutex
+	out := json.NewEncoder(os.Stdout)
+	for sc.Scan() {
+		var req message
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			log.Printf("bad message: %v", err)
+			continue
+		}
+		go func() {
+			if resp := handle(&req); resp != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				_ = out.Encode(resp)
+			}
+		}()
+	}
+}
+
+// httpHandler serves /mcp. Requests are answered as SSE streams when the
+// client accepts them, otherwise as JSON.
+func httpHandler() http.Handler {
+	const session = "stub-session"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPost:
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var req message
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != session {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Mcp-Session-Id", session)
+		resp := handle(&req)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		b, _ := json.Marshal(resp)
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") && req.Method == "tools/call" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", b)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+	return mux
+}
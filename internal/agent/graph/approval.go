@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/classifiers"
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/conversations"
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/nodes"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+const defaultConfirmTTL = 10 * time.Minute
+
+// Fixed replies of the confirmation flow; they bypass the response model so
+// the wording cannot drift.
+const (
+	confirmPromptHeader = "ขอยืนยันก่อนดำเนินการ:"
+	confirmPromptFooter = "พิมพ์ ยืนยัน เพื่อดำเนินการต่อ หรือ ยกเลิก หากไม่ต้องการ"
+	confirmDeclined     = "ยกเลิกรายการนี้แล้ว มีอะไรให้ช่วยเพิ่มเติมไหม"
+)
+
+func init() {
+	// Checkpoints serialize values held as interfaces by registered name:
+	// the graph state, node inputs and entity metadata.
+	mustRegister(compose.RegisterSerializableType[model.AppState]("chative_app_state"))
+	mustRegister(compose.RegisterSerializableType[model.QueryInput]("chative_query_input"))
+	mustRegister(compose.RegisterSerializableType[model.NLUResponse]("chative_nlu_response"))
+	mustRegister(compose.RegisterSerializableType[model.NormalizedValue]("chative_normalized_value"))
+}
+
+func mustRegister(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// toolConfirmation pauses runs at the ToolApproval node until the customer
+// confirms the side-effecting tool calls. A paused run is checkpointed under
+// the conversation ID and its calls are kept as a model.PendingAction; the
+// next message either resumes it (yes), cancels it (no) or replaces it (any
+// other message, which is then handled as a new query).
+type toolConfirmation struct {
+	pending     model.PendingActionRepository
+	checkPoints model.CheckPointStore
+	messages    *conversations.MessagesManager
+	ttl         time.Duration
+	now         func() time.Time
+}
+
+func newToolConfirmation(pending model.PendingActionRepository, checkPoints model.CheckPointStore, mm *conversations.MessagesManager, ttl time.Duration) *toolConfirmation {
+	if ttl <= 0 {
+		ttl = defaultConfirmTTL
+	}
+	return &toolConfirmation{pending: pending, checkPoints: checkPoints, messages: mm, ttl: ttl, now: time.Now}
+}
+
+// confirmationTurn is how the runner handles a message.
+type confirmationTurn struct {
+	opts    []compose.Option // checkpoint options for the graph run
+	resumed bool             // the run continues a paused one
+	reply   string           // answer without running the graph (declined), when set
+}
+
+// prepare decides how to run the graph for the message in.
+func (c *toolConfirmation) prepare(ctx context.Context, in model.QueryInput) (*confirmationTurn, error) {
+	turn := &confirmationTurn{opts: []compose.Option{compose.WithCheckPointID(in.ConversationID)}}
+
+	action, err := c.pending.Load(ctx, in.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("load pending action: %w", err)
+	}
+	if action == nil || action.Expired(c.now()) {
+		if action != nil {
+			c.discard(ctx, in.ConversationID)
+		}
+		turn.opts = append(turn.opts, compose.WithForceNewRun())
+		return turn, nil
+	}
+
+	answer := classifiers.ClassifyConfirmation(in.Query)
+	logx.Info().
+		Str("conversation_id", in.ConversationID).
+		Int("pending_calls", len(action.ToolCalls)).
+		Bool("confirmed", answer == classifiers.ConfirmYes).
+		Msg("Answer to tool confirmation")
+
+	switch answer {
+	case classifiers.ConfirmYes:
+		if err := c.messages.SaveUserMessage(ctx, in.ConversationID, in.Query); err != nil {
+			return nil, fmt.Errorf("save confirmation: %w", err)
+		}
+		if err := c.pending.Clear(ctx, in.ConversationID); err != nil {
+			return nil, fmt.Errorf("clear pending action: %w", err)
+		}
+		keys := action.Keys()
+		turn.resumed = true
+		turn.opts = append(turn.opts, compose.WithStateModifier(func(_ context.Context, _ compose.NodePath, state any) error {
+			s, ok := state.(*model.AppState)
+			if !ok {
+				return fmt.Errorf("unexpected checkpoint state %T", state)
+			}
+			s.ApprovedToolCalls = append(s.ApprovedToolCalls, keys...)
+			// time spent waiting for the customer does not count against the turn budget
+			s.ToolLoopStartedAt = time.Time{}
+			return nil
+		}))
+		return turn, nil
+	case classifiers.ConfirmNo:
+		c.discard(ctx, in.ConversationID)
+		if err := c.messages.SaveUserMessage(ctx, in.ConversationID, in.Query); err != nil {
+			return nil, fmt.Errorf("save confirmation: %w", err)
+		}
+		if err := c.messages.SaveResponse(ctx, in.ConversationID, confirmDeclined); err != nil {
+			return nil, fmt.Errorf("save response: %w", err)
+		}
+		turn.reply = confirmDeclined
+		return turn, nil
+	default:
+		// a new request supersedes the paused one
+		c.discard(ctx, in.ConversationID)
+		turn.opts = append(turn.opts, compose.WithForceNewRun())
+		return turn, nil
+	}
+}
+
+// interrupted turns the interrupt of a run into a pending action and returns
+// the confirmation question. It reports false when the interrupt did not come
+// from the ToolApproval node.
+func (c *toolConfirmation) interrupted(ctx context.Context, conversationID string, info *compose.InterruptInfo) (string, bool, error) {
+	calls, ok := info.RerunNodesExtra[nodes.NodeToolApproval].([]model.PendingToolCall)
+	if !ok || len(calls) == 0 {
+		return "", false, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(confirmPromptHeader)
+	for _, call := range calls {
+		summary := call.Summary
+		if summary == "" {
+			summary = call.Name + " " + call.Arguments
+		}
+		b.WriteString("\n- " + summary)
+	}
+	b.WriteString("\n" + confirmPromptFooter)
+
+	now := c.now()
+	action := &model.PendingAction{
+		ConversationID: conversationID,
+		ToolCalls:      calls,
+		Prompt:         b.String(),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(c.ttl),
+	}
+	if err := c.pending.Save(ctx, action); err != nil {
+		c.discard(ctx, conversationID)
+		return "", true, fmt.Errorf("save pending action: %w", err)
+	}
+	if err := c.messages.SaveResponse(ctx, conversationID, action.Prompt); err != nil {
+		return "", true, fmt.Errorf("save confirmation prompt: %w", err)
+	}
+	return action.Prompt, true, nil
+}
+
+// finish drops the checkpoint of a resumed run once it completed.
+func (c *toolConfirmation) finish(ctx context.Context, conversationID string) {
+	if err := c.checkPoints.Delete(ctx, conversationID); err != nil {
+		logx.Warn().Err(err).Str("conversation_id", conversationID).Msg("Failed to delete checkpoint")
+	}
+}
+
+// discard drops a paused run: its pending action and its checkpoint.
+func (c *toolConfirmation) discard(ctx context.Context, conversationID string) {
+	if err := c.pending.Clear(ctx, conversationID); err != nil {
+		logx.Warn().Err(err).Str("conversation_id", conversationID).Msg("Failed to clear pending action")
+	}
+	c.finish(ctx, conversationID)
+}
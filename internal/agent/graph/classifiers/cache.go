@@ -0,0 +1,160 @@
+package classifiers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// nluPromptRevision is part of the catalog version; bump it when the NLU
+// prompt templates change in a way that alters results.
+const nluPromptRevision = "1"
+
+// NLUResultCache sits in front of the NLU model. Keys hash the normalized
+// current message, the last few context messages and the catalog version, so
+// a changed intent/entity catalog never serves stale results.
+type NLUResultCache struct {
+	store           model.NLUCache
+	ttl             time.Duration
+	version         string
+	contextMessages int
+}
+
+// NewNLUResultCache wraps store using the NLU_CACHE_* settings of cfg.
+func NewNLUResultCache(store model.NLUCache, cfg *model.NLUModelConfig) (*NLUResultCache, error) {
+	if store == nil {
+		return nil, fmt.Errorf("nlu cache store is nil")
+	}
+	ttl, err := time.ParseDuration(cfg.Cache.TTL)
+	if err != nil || ttl <= 0 {
+		return nil, fmt.Errorf("invalid NLU_CACHE_TTL %q", cfg.Cache.TTL)
+	}
+	ctxMsgs := cfg.Cache.ContextMessages
+	if ctxMsgs < 0 {
+		ctxMsgs = 0
+	}
+	return &NLUResultCache{store: store, ttl: ttl, version: CatalogVersion(cfg), contextMessages: ctxMsgs}, nil
+}
+
+// CatalogVersion fingerprints everything that shapes an NLU result besides the
+// message itself: intent/entity catalogs, output mode, model and prompt revision.
+func CatalogVersion(cfg *model.NLUModelConfig) string {
+	h := sha256.New()
+	for _, part := range []string{
+		nluPromptRevision,
+		cfg.Model,
+		cfg.Mode,
+		canonicalCatalog(cfg.DefaultIntent),
+		canonicalCatalog(cfg.AdditionalIntent),
+		canonicalCatalog(cfg.DefaultEntity),
+		canonicalCatalog(cfg.AdditionalEntity),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Version returns the catalog version baked into every key.
+func (c *NLUResultCache) Version() string {
+	return c.version
+}
+
+// SyncCatalogVersion records the current catalog version in the store,
+// purging entries written under a previous catalog.
+func (c *NLUResultCache) SyncCatalogVersion(ctx context.Context) error {
+	changed, err := c.store.SetCatalogVersion(ctx, c.version)
+	if err != nil {
+		return fmt.Errorf("sync nlu catalog version: %w", err)
+	}
+	if changed {
+		logx.Info().Str("catalog_version", c.version).Msg("NLU catalog version changed; cache invalidated")
+	}
+	return nil
+}
+
+// Key builds the cache key for query given the conversation messages that
+// precede it (oldest first; the current message must not be included).
+func (c *NLUResultCache) Key(query string, recent []*schema.Message) string {
+	h := sha256.New()
+	h.Write([]byte(c.version))
+	h.Write([]byte{0})
+	h.Write([]byte(NormalizeMessage(query)))
+	var contextMsgs []*schema.Message
+	for i := len(recent) - 1; i >= 0 && len(contextMsgs) < c.contextMessages; i-- {
+		if m := recent[i]; m != nil && m.Content != "" && (m.Role == schema.User || m.Role == schema.Assistant) {
+			contextMsgs = append(contextMsgs, m)
+		}
+	}
+	for _, m := range contextMsgs {
+		h.Write([]byte{0})
+		h.Write([]byte(m.Role))
+		h.Write([]byte{':'})
+		h.Write([]byte(NormalizeMessage(m.Content)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Lookup returns a cached result annotated with cache-hit metadata, or nil.
+// Store errors are logged and treated as misses.
+func (c *NLUResultCache) Lookup(ctx context.Context, key string) *model.NLUResponse {
+	resp, err := c.store.Get(ctx, key)
+	if err != nil {
+		logx.Warn().Err(err).Msg("NLU cache lookup failed; treating as miss")
+		return nil
+	}
+	if resp == nil {
+		return nil
+	}
+	if resp.ParsingMetadata == nil {
+		resp.ParsingMetadata = map[string]any{}
+	}
+	resp.ParsingMetadata["cache_hit"] = true
+	resp.ParsingMetadata["cache_key"] = key[:16]
+	resp.ParsingMetadata["cached_at"] = resp.Timestamp.Format(time.RFC3339)
+	resp.ParsingMetadata["catalog_version"] = c.version
+	resp.ParsingMetadata["source"] = "cache"
+	resp.Timestamp = time.Now().UTC()
+	return resp
+}
+
+// Store caches a model-produced result. Results with parsing errors, without
+// intents or from the degraded rule fallback are skipped so a bad generation is not replayed for the whole TTL.
+func (c *NLUResultCache) Store(ctx context.Context, key string, resp model.NLUResponse) {
+	if len(resp.Intents) == 0 {
+		return
+	}
+	if errs, _ := resp.ParsingMetadata["parsing_errors"].([]string); len(errs) > 0 {
+		return
+	}
+	if _, ok := resp.ParsingMetadata["json_error"]; ok {
+		return
+	}
+	if degraded, _ := resp.ParsingMetadata["degraded"].(bool); degraded {
+		return
+	}
+	if err := c.store.Set(ctx, key, &resp, c.ttl); err != nil {
+		logx.Warn().Err(err).Msg("NLU cache store failed")
+	}
+}
+
+// canonicalCatalog strips whitespace from each comma-separated entry so
+// formatting-only edits do not change the version.
+func canonicalCatalog(s string) string {
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.Join(strings.Fields(p), ""); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ",")
+}
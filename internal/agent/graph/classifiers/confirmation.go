@@ -0,0 +1,33 @@
+package classifiers
+
+import "regexp"
+
+// Confirmation is the customer's answer to a confirmation question.
+type Confirmation int
+
+const (
+	ConfirmUnclear Confirmation = iota // anything else; treated as a new request
+	ConfirmYes
+	ConfirmNo
+)
+
+// Patterns are anchored to the whole normalized message so that a longer
+// reply ("ใช่ แต่เปลี่ยนเป็นสีดำ") is never taken as a plain yes.
+var (
+	confirmYesPattern = regexp.MustCompile(`^((ใช่|ยืนยัน|ตกลง|โอเค|ได้|เอา|จัดไป|จัด|สั่ง|ถูกต้อง|yes|y|yep|yeah|ok|okay|sure|confirm|confirmed|go ahead)(เลย|ครับ|คับ|ค่ะ|คะ|จ้า)* ?)+$`)
+	confirmNoPattern  = regexp.MustCompile(`^((ไม่|ไม่ใช่|ไม่เอา|ไม่ยืนยัน|ไม่ต้อง|ยกเลิก|ยังไม่|เดี๋ยวก่อน|no|n|nope|cancel|stop|don t|dont)(แล้ว|ดีกว่า|ครับ|คับ|ค่ะ|คะ|จ้า)* ?)+$`)
+)
+
+// ClassifyConfirmation reads a reply to a confirmation question.
+func ClassifyConfirmation(reply string) Confirmation {
+	norm := NormalizeMessage(reply)
+	switch {
+	case norm == "":
+		return ConfirmUnclear
+	case confirmNoPattern.MatchString(norm):
+		return ConfirmNo
+	case confirmYesPattern.MatchString(norm):
+		return ConfirmYes
+	}
+	return ConfirmUnclear
+}
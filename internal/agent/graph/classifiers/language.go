@@ -0,0 +1,96 @@
+package classifiers
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// Script-based ISO 639-3 codes produced by DetectLanguages.
+const (
+	LangThai    = "tha"
+	LangEnglish = "eng"
+)
+
+// DetectLanguages is a lightweight local detector that tells Thai from English
+// by counting letters in the Thai (U+0E00–U+0E7F) and Latin scripts. It returns
+// languages ordered primary first, in the same shape the NLU model produces.
+// Text without letters of either script yields nil.
+func DetectLanguages(text string) []model.Language {
+	var thai, latin int
+	for _, r := range text {
+		switch {
+		case r >= 0x0E00 && r <= 0x0E7F:
+			if unicode.IsLetter(r) {
+				thai++
+			}
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			latin++
+		}
+	}
+	total := thai + latin
+	if total == 0 {
+		return nil
+	}
+
+	thaiTokens, latinTokens := scriptTokens(text)
+	thaiLang := model.Language{
+		Code:       LangThai,
+		Confidence: round2(float64(thai) / float64(total)),
+		Metadata:   map[string]any{"script": "thai", "detected_tokens": float64(thaiTokens), "detector": "script"},
+	}
+	engLang := model.Language{
+		Code:       LangEnglish,
+		Confidence: round2(float64(latin) / float64(total)),
+		Metadata:   map[string]any{"script": "latin", "detected_tokens": float64(latinTokens), "detector": "script"},
+	}
+
+	// Thai customers mix in English brand names, so Thai wins ties
+	if thai >= latin {
+		thaiLang.IsPrimary = true
+		if latin == 0 {
+			return []model.Language{thaiLang}
+		}
+		return []model.Language{thaiLang, engLang}
+	}
+	engLang.IsPrimary = true
+	if thai == 0 {
+		return []model.Language{engLang}
+	}
+	return []model.Language{engLang, thaiLang}
+}
+
+// PrimaryLanguage returns the ISO 639-3 code of the dominant script, or "".
+func PrimaryLanguage(text string) string {
+	langs := DetectLanguages(text)
+	if len(langs) == 0 {
+		return ""
+	}
+	return langs[0].Code
+}
+
+// scriptTokens counts whitespace-separated runs containing Thai or Latin letters.
+func scriptTokens(text string) (thai, latin int) {
+	for _, f := range strings.Fields(text) {
+		hasThai, hasLatin := false, false
+		for _, r := range f {
+			if r >= 0x0E00 && r <= 0x0E7F {
+				hasThai = true
+			} else if r < unicode.MaxASCII && unicode.IsLetter(r) {
+				hasLatin = true
+			}
+		}
+		if hasThai {
+			thai++
+		}
+		if hasLatin {
+			latin++
+		}
+	}
+	return thai, latin
+}
+
+func round2(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
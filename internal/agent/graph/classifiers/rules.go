@@ -0,0 +1,244 @@
+package classifiers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// fallbackIntent receives rule matches whose own intent is not in the
+// configured catalog, mirroring the NLU prompt's "closest intent" rule.
+const fallbackIntent = "greet"
+
+// rule matches a whole (normalized) message to a single intent.
+type rule struct {
+	intent     string
+	pattern    *regexp.Regexp
+	confidence float64
+	sentiment  string
+	polarity   float64
+}
+
+// defaultRules cover trivial messages only. Patterns are anchored so that
+// "สวัสดีครับ ผมสนใจซื้อคอม" is NOT treated as a plain greeting.
+var defaultRules = []rule{
+	{
+		intent:     "greet",
+		pattern:    regexp.MustCompile(`^(สวัสดี|หวัดดี|hello|hi|hey|hiya|good (morning|afternoon|evening))$`),
+		confidence: 0.97,
+		sentiment:  "neutral",
+		polarity:   0.2,
+	},
+	{
+		intent:     "thanks",
+		pattern:    regexp.MustCompile(`^(ขอบคุณ|ขอบใจ|แต้งกิ้ว|thank you|thanks|thx|ty)( ?(มาก|มากๆ|มาก ๆ|so much|very much|a lot))?$`),
+		confidence: 0.95,
+		sentiment:  "positive",
+		polarity:   0.6,
+	},
+	{
+		intent:     "goodbye",
+		pattern:    regexp.MustCompile(`^(บาย|บ๊ายบาย|ลาก่อน|bye|bye bye|goodbye|see you)$`),
+		confidence: 0.95,
+		sentiment:  "neutral",
+		polarity:   0.1,
+	},
+}
+
+// politeParticles are stripped before matching ("สวัสดีครับ" -> "สวัสดี").
+var politeParticles = []string{"ครับผม", "ครับ", "คับ", "ค้าบ", "ค่ะ", "คะ", "ค่า", "จ้า", "จ้ะ", "จ๊ะ", "นะ", "ฮะ", "krub", "kub", "kha", "ka"}
+
+// RuleClassifier is a deterministic pre-classifier that answers trivial
+// messages (greetings, thanks, goodbyes) without calling the NLU model.
+type RuleClassifier struct {
+	rules         []rule
+	priorities    map[string]float64
+	minConfidence float64
+	metrics       FastPathMetrics
+}
+
+// NewRuleClassifier builds a classifier for the configured intent catalog.
+// Matches below minConfidence are reported as not confident.
+func NewRuleClassifier(cfg *model.NLUModelConfig, minConfidence float64) *RuleClassifier {
+	priorities := ParseIntentCatalog(cfg.DefaultIntent)
+	for k, v := range ParseIntentCatalog(cfg.AdditionalIntent) {
+		if _, ok := priorities[k]; !ok {
+			priorities[k] = v
+		}
+	}
+	return &RuleClassifier{rules: defaultRules, priorities: priorities, minConfidence: minConfidence}
+}
+
+// Classify returns an NLUResponse for query and whether it is confident
+// enough to skip the NLU model. A nil response means no rule matched.
+func (c *RuleClassifier) Classify(query string) (*model.NLUResponse, bool) {
+	norm := NormalizeMessage(query)
+	if norm == "" {
+		return nil, false
+	}
+	for _, r := range c.rules {
+		if !r.pattern.MatchString(norm) {
+			continue
+		}
+		return c.buildResponse(query, r), r.confidence >= c.minConfidence
+	}
+	return nil, false
+}
+
+// Fallback always returns a result for query, for use when the NLU model
+// output could not be parsed. A matching rule is used regardless of its
+// confidence; otherwise the least specific configured intent is returned with
+// low confidence. Results are marked degraded in Metadata and ParsingMetadata.
+func (c *RuleClassifier) Fallback(query string, reason string) *model.NLUResponse {
+	var resp *model.NLUResponse
+	if norm := NormalizeMessage(query); norm != "" {
+		for _, r := range c.rules {
+			if r.pattern.MatchString(norm) {
+				resp = c.buildResponse(query, r)
+				break
+			}
+		}
+	}
+	if resp == nil {
+		resp = c.buildResponse(query, rule{intent: c.genericIntent(), confidence: 0.3, sentiment: "neutral"})
+		resp.ParsingMetadata["rule_intent"] = ""
+	}
+	resp.Metadata["parser"] = "rules_fallback"
+	resp.Metadata["degraded"] = true
+	resp.ParsingMetadata["source"] = "rules_fallback"
+	resp.ParsingMetadata["degraded"] = true
+	resp.ParsingMetadata["degraded_reason"] = reason
+	return resp
+}
+
+// genericIntentPreference lists catch-all intents in order of preference.
+var genericIntentPreference = []string{"inquiry_intent", "ask_product", "support_intent"}
+
+// genericIntent picks the intent used when no rule matches: a known catch-all
+// intent if configured, else the lowest-priority configured intent.
+func (c *RuleClassifier) genericIntent() string {
+	for _, name := range genericIntentPreference {
+		if _, ok := c.priorities[name]; ok {
+			return name
+		}
+	}
+	best, bestPrio := fallbackIntent, 2.0
+	for name, prio := range c.priorities {
+		if prio < bestPrio || (prio == bestPrio && name < best) {
+			best, bestPrio = name, prio
+		}
+	}
+	return best
+}
+
+// Metrics returns the fast path hit counters.
+func (c *RuleClassifier) Metrics() *FastPathMetrics {
+	return &c.metrics
+}
+
+func (c *RuleClassifier) buildResponse(query string, r rule) *model.NLUResponse {
+	intentName := r.intent
+	meta := map[string]any{"extracted_from": "rules"}
+	prio, ok := c.priorities[intentName]
+	if !ok {
+		intentName = fallbackIntent
+		prio = c.priorities[fallbackIntent]
+		meta["closest_match"] = true
+		meta["rule_intent"] = r.intent
+	}
+
+	if r.sentiment == "" {
+		r.sentiment = "neutral"
+	}
+	langs := DetectLanguages(query)
+	primary := ""
+	if len(langs) > 0 {
+		primary = langs[0].Code
+	}
+	return &model.NLUResponse{
+		Intents:   []model.Intent{{Name: intentName, Confidence: r.confidence, Priority: prio, Metadata: meta}},
+		Entities:  []model.Entity{},
+		Languages: langs,
+		Sentiment: model.Sentiment{
+			Label:      r.sentiment,
+			Confidence: 0.8,
+			Metadata:   map[string]any{"polarity": r.polarity},
+		},
+		ImportanceScore: r.confidence*0.6 + prio*0.4,
+		PrimaryIntent:   intentName,
+		PrimaryLanguage: primary,
+		Metadata:        map[string]any{"parser": "rules"},
+		ParsingMetadata: map[string]any{"source": "rules", "rule_intent": r.intent},
+		Timestamp:       time.Now().UTC(),
+	}
+}
+
+// NormalizeMessage lowercases, removes punctuation/emoji and trailing polite
+// particles, and collapses whitespace.
+func NormalizeMessage(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || unicode.Is(unicode.Mn, r) || r == 'ๆ' {
+			return r
+		}
+		return ' '
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+	for changed := true; changed; {
+		changed = false
+		for _, p := range politeParticles {
+			if strings.HasSuffix(s, p) && len(s) > len(p) {
+				s = strings.TrimSpace(strings.TrimSuffix(s, p))
+				changed = true
+			}
+		}
+	}
+	return s
+}
+
+// ParseIntentCatalog parses "name:priority, name:priority" (NLU_*_INTENT) into a map.
+// Entries without a valid priority get 0.
+func ParseIntentCatalog(s string) map[string]float64 {
+	out := map[string]float64{}
+	for _, part := range strings.Split(s, ",") {
+		name, prio, _ := strings.Cut(strings.TrimSpace(part), ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(prio), 64)
+		if err != nil {
+			v = 0
+		}
+		out[name] = v
+	}
+	return out
+}
+
+// FastPathMetrics counts how often the fast path answered instead of the NLU model.
+type FastPathMetrics struct {
+	hits  atomic.Int64
+	total atomic.Int64
+}
+
+// Record counts one routing decision.
+func (m *FastPathMetrics) Record(hit bool) {
+	m.total.Add(1)
+	if hit {
+		m.hits.Add(1)
+	}
+}
+
+// Snapshot returns hits, total decisions and the hit rate (0 when no decisions).
+func (m *FastPathMetrics) Snapshot() (hits, total int64, rate float64) {
+	hits, total = m.hits.Load(), m.total.Load()
+	if total > 0 {
+		rate = float64(hits) / float64(total)
+	}
+	return hits, total, rate
+}
@@ -0,0 +1,134 @@
+package conversations
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// DialogueStateTracker merges each turn's normalized entities into the
+// persisted per-conversation slot state.
+//
+// Merge rules per slot:
+//   - empty slot: filled when confidence >= FillConfidence
+//   - same value again: marked confirmed (and any pending change dropped)
+//   - different value: overwrites when confidence >= OverwriteConfidence,
+//     otherwise kept as pending until the customer repeats it
+//   - a value is confirmed when extracted with confidence >= ConfirmConfidence
+type DialogueStateTracker struct {
+	repo                model.DialogueStateRepository
+	fillConfidence      float64
+	overwriteConfidence float64
+	confirmConfidence   float64
+}
+
+func NewDialogueStateTracker(repo model.DialogueStateRepository, config model.ConversationConfig) *DialogueStateTracker {
+	return &DialogueStateTracker{
+		repo:                repo,
+		fillConfidence:      config.Dialogue.FillConfidence,
+		overwriteConfidence: config.Dialogue.OverwriteConfidence,
+		confirmConfidence:   config.Dialogue.ConfirmConfidence,
+	}
+}
+
+// Update loads the conversation's dialogue state, merges the turn's NLU result and saves it.
+func (t *DialogueStateTracker) Update(ctx context.Context, conversationID string, nlu model.NLUResponse) (*model.DialogueState, error) {
+	state, err := t.repo.Load(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	changed := t.Merge(state, nlu)
+	if err := t.repo.Save(ctx, state); err != nil {
+		return nil, err
+	}
+	if len(changed) > 0 {
+		logx.Debug().
+			Str("conversation_id", conversationID).
+			Strs("slots", changed).
+			Msg("Dialogue state updated")
+	}
+	return state, nil
+}
+
+// Merge applies the turn's candidates to state and returns the slots that changed.
+func (t *DialogueStateTracker) Merge(state *model.DialogueState, nlu model.NLUResponse) []string {
+	if state.Slots == nil {
+		state.Slots = map[string]model.SlotValue{}
+	}
+	state.Turn++
+	state.UpdatedAt = time.Now().UTC()
+
+	var changed []string
+	for slot, cand := range slotCandidates(nlu) {
+		cand.UpdatedTurn = state.Turn
+		cand.Confirmed = cand.Confidence >= t.confirmConfidence
+
+		current, filled := state.Slots[slot]
+		pending, hasPending := state.Pending[slot]
+		switch {
+		case !filled:
+			if cand.Confidence < t.fillConfidence {
+				continue
+			}
+			state.Slots[slot] = cand
+		case current.Value == cand.Value:
+			// repeating the value confirms it
+			cand.Confirmed = true
+			if cand.Confidence < current.Confidence {
+				cand.Confidence = current.Confidence
+			}
+			state.Slots[slot] = cand
+			delete(state.Pending, slot)
+		case cand.Confidence >= t.overwriteConfidence || (hasPending && pending.Value == cand.Value):
+			cand.Confirmed = cand.Confirmed || hasPending
+			state.Slots[slot] = cand
+			delete(state.Pending, slot)
+		default:
+			if state.Pending == nil {
+				state.Pending = map[string]model.SlotValue{}
+			}
+			state.Pending[slot] = cand
+		}
+		changed = append(changed, slot)
+	}
+	return changed
+}
+
+// slotCandidates maps normalized entities to slots, keeping the most confident
+// candidate per slot.
+func slotCandidates(nlu model.NLUResponse) map[string]model.SlotValue {
+	out := map[string]model.SlotValue{}
+	for _, e := range nlu.Entities {
+		nv, ok := e.Normalized()
+		if !ok {
+			continue
+		}
+		slot := ""
+		switch nv.Kind {
+		case "product_type":
+			slot = model.SlotProductType
+		case "money":
+			// an exact price is usually a quoted product price, not the customer's budget
+			if e.Type == "budget" || nv.Bound != "exact" {
+				slot = model.SlotBudget
+			}
+		case "brand":
+			slot = model.SlotBrand
+		case "use_case":
+			slot = model.SlotUseCase
+		case "quantity":
+			slot = model.SlotQuantity
+		}
+		if slot == "" {
+			continue
+		}
+		if prev, ok := out[slot]; ok && prev.Confidence >= e.Confidence {
+			continue
+		}
+		v := nv
+		out[slot] = model.SlotValue{Value: nv.String(), Normalized: &v, Confidence: e.Confidence}
+	}
+	return out
+}
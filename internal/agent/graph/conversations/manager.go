@@ -23,6 +23,13 @@ func NewMessagesManager(conversationRepo model.ConversationRepository, config mo
 
 // =========== Function for NLU ===========
 func (cm *MessagesManager) ProcessNLUMessage(ctx context.Context, conversationID string, query string) (string, error) {
+	nluContext, _, err := cm.PrepareNLUMessage(ctx, conversationID, query)
+	return nluContext, err
+}
+
+// PrepareNLUMessage saves the user message and returns the NLU context together
+// with the recent messages preceding the current one (oldest first).
+func (cm *MessagesManager) PrepareNLUMessage(ctx context.Context, conversationID string, query string) (string, []*schema.Message, error) {
 	// TODO: Add input validation for conversationID and query parameters
 	// - Validate conversationID is not empty and follows expected format
 	// - Validate query length (max 10000 chars) and sanitize input
@@ -31,13 +38,13 @@ func (cm *MessagesManager) ProcessNLUMessage(ctx context.Context, conversationID
 	// Save user message
 	userMsg := schema.UserMessage(query)
 	if err := cm.conversationRepo.AddMessage(ctx, conversationID, userMsg); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	// Load history and build context
 	history, err := cm.conversationRepo.LoadHistory(ctx, conversationID)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	conversationContext := cm.buildNLUContext(history.Messages)
@@ -49,7 +56,13 @@ func (cm *MessagesManager) ProcessNLUMessage(ctx context.Context, conversationID
 	fullContext.WriteString("UserMessage(" + query + ")\n")
 	fullContext.WriteString("</current_message_to_analyze>")
 
-	return fullContext.String(), nil
+	// history ends with the message just saved
+	var recent []*schema.Message
+	if n := len(history.Messages); n > 0 {
+		recent = trimTail(history.Messages[:n-1], cm.nluMaxTurns)
+	}
+
+	return fullContext.String(), recent, nil
 }
 
 func (cm *MessagesManager) buildNLUContext(messages []*schema.Message) string {
@@ -94,6 +107,12 @@ func (cm *MessagesManager) BuildResponseContext(ctx context.Context, conversatio
 	return messages, nil
 }
 
+// SaveUserMessage appends a user message that does not go through NLU, such
+// as the answer to a tool confirmation.
+func (cm *MessagesManager) SaveUserMessage(ctx context.Context, conversationID string, content string) error {
+	return cm.conversationRepo.AddMessage(ctx, conversationID, schema.UserMessage(content))
+}
+
 func (cm *MessagesManager) SaveResponse(ctx context.Context, conversationID string, content string) error {
 	assistantMsg := schema.AssistantMessage(content, nil)
 	return cm.conversationRepo.AddMessage(ctx, conversationID, assistantMsg)
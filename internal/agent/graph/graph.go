@@ -4,23 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
+	"time"
 
 	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/classifiers"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/conversations"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/nodes"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/observers"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	"github.com/Chative-core-poc-v1/server/internal/agent/knowledge"
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
 )
 
 // Runner is a thin wrapper to execute the compiled graph with the public QueryInput.
 type Runner interface {
 	Invoke(ctx context.Context, in model.QueryInput) (string, error)
+	// ToolHealth reports the circuit breaker of every bound tool.
+	ToolHealth() []tools.BreakerStatus
+	// InvalidateToolCache drops cached results of the named tools, or of all
+	// tools when none are named.
+	InvalidateToolCache(ctx context.Context, names ...string) error
 }
 
 // Config holds everything needed to compose the full response graph end-to-end.
@@ -33,25 +40,86 @@ type Config struct {
 	ResponsePrompt   model.ResponsePromptConfig
 	Conversation     model.ConversationConfig
 	ConversationRepo model.ConversationRepository
+	// DialogueStateRepo persists slot state across turns; nil disables tracking.
+	DialogueStateRepo model.DialogueStateRepository
+	// NLUCache stores NLU results across conversations; nil disables caching.
+	NLUCache model.NLUCache
+	// ToolCache stores results of read-only tools; nil disables caching.
+	ToolCache model.ToolResultCache
+	// Catalog backs the product tools; nil uses the built-in demo catalog.
+	Catalog tools.ProductCatalog
+	// Promotions prices products in the tools; nil uses the demo promotions.
+	Promotions model.PromotionStore
+	// Inventory reports per-branch stock; nil uses the demo stock.
+	Inventory tools.Inventory
+	// Orders persists carts and orders; nil keeps them in memory.
+	Orders model.OrderStore
+	// OrderReservationTTL is how long stock stays held for unpaid orders (0 = 30m).
+	OrderReservationTTL time.Duration
+	// Carrier tracks shipped orders; nil uses the demo parcels.
+	Carrier tools.CarrierTracker
+	// Knowledge answers policy questions; nil is an empty knowledge base.
+	Knowledge *knowledge.Base
+	// Tools selects the registered tools to bind; empty enables all.
+	Tools model.ToolsConfig
+	// PendingActions and CheckPoints keep runs paused for tool confirmation
+	// (Conversation.Tools.Confirm); nil keeps them in memory.
+	PendingActions model.PendingActionRepository
+	CheckPoints    model.CheckPointStore
+	// ToolConfirmTTL is how long a confirmation question stays answerable (0 = 10m).
+	ToolConfirmTTL time.Duration
 }
 
 // GraphConfig holds all configuration needed to build the graph
 type GraphConfig struct {
 	ChatModels           *nodes.ChatModels
 	MessagesManager      *conversations.MessagesManager
+	DialogueTracker      *conversations.DialogueStateTracker // optional
+	FastPath             *classifiers.RuleClassifier         // optional rule-based NLU pre-classifier
+	RuleFallback         *classifiers.RuleClassifier         // optional classifier for unparseable NLU output
+	NLUCache             *classifiers.NLUResultCache         // optional NLU result cache
+	Catalog              tools.ProductCatalog                // product source for tools; nil uses the demo catalog
+	Promotions           model.PromotionStore                // promotion source for pricing tools; nil uses the demo promotions
+	Inventory            tools.Inventory                     // branch stock for availability tools; nil uses the demo stock
+	Orders               model.OrderStore                    // cart/order persistence for action tools; nil keeps them in memory
+	OrderReservationTTL  time.Duration                       // stock hold for unpaid orders; 0 uses the default
+	Carrier              tools.CarrierTracker                // shipment tracking for support tools; nil uses the demo parcels
+	Knowledge            *knowledge.Base                     // policy documents for the knowledge-base tool; nil finds nothing
+	Tools                model.ToolsConfig                   // registered tools to bind; empty enables all
+	Resilience           *tools.Resilience                   // tool timeouts, retries and breakers; nil uses the defaults
+	ToolCache            *tools.ToolCache                    // optional tool result cache
+	ConfirmToolCalls     bool                                // pause side-effecting tool calls for the customer's confirmation
+	CheckPoints          model.CheckPointStore               // stores paused runs; required when ConfirmToolCalls is set
 	NLUConfig            *model.NLUModelConfig
 	ResponsePromptConfig *model.ResponsePromptConfig
-	ToolMaxCalls         int
+	ToolBudget           nodes.ToolBudget // tool rounds, per-tool caps and turn time of one query
 }
 
 // GraphBuilder handles the construction of the agent conversation graph
 type GraphBuilder struct {
 	config *GraphConfig
 	graph  *compose.Graph[model.QueryInput, *schema.Message]
+	tools  []tools.Registration // enabled tools, set by setupTools
 }
 
 type graphRunner struct {
-	runnable compose.Runnable[model.QueryInput, *schema.Message]
+	runnable   compose.Runnable[model.QueryInput, *schema.Message]
+	confirm    *toolConfirmation // nil when tool confirmation is disabled
+	resilience *tools.Resilience
+	toolCache  *tools.ToolCache // nil when tool caching is disabled
+	// maxParallel bounds the concurrent tool calls of one query (0 = unbounded)
+	maxParallel int
+}
+
+func (r *graphRunner) ToolHealth() []tools.BreakerStatus {
+	return r.resilience.Health()
+}
+
+func (r *graphRunner) InvalidateToolCache(ctx context.Context, names ...string) error {
+	if r.toolCache == nil {
+		return nil
+	}
+	return r.toolCache.Invalidate(ctx, names...)
 }
 
 func (r *graphRunner) Invoke(ctx context.Context, in model.QueryInput) (string, error) {
@@ -61,13 +129,37 @@ func (r *graphRunner) Invoke(ctx context.Context, in model.QueryInput) (string,
 	// - Include detailed error context and correlation IDs for debugging
 	// - Add timeout handling with configurable deadlines
 
+	ctx = tools.WithConcurrencyLimit(ctx, r.maxParallel)
+	opts := []compose.Option{compose.WithCallbacks(observers.NewAllCallbacks())}
+	var turn *confirmationTurn
+	if r.confirm != nil {
+		var err error
+		if turn, err = r.confirm.prepare(ctx, in); err != nil {
+			return "", err
+		}
+		if turn.reply != "" {
+			return turn.reply, nil
+		}
+		opts = append(opts, turn.opts...)
+	}
+
 	out, err := r.runnable.Invoke(ctx, model.QueryInput{
 		ConversationID: in.ConversationID,
+		CustomerID:     in.CustomerID,
 		Query:          in.Query,
-	}, compose.WithCallbacks(observers.NewAllCallbacks()))
+	}, opts...)
 	if err != nil {
+		if info, ok := compose.ExtractInterruptInfo(err); ok && r.confirm != nil {
+			prompt, handled, cerr := r.confirm.interrupted(ctx, in.ConversationID, info)
+			if handled {
+				return prompt, cerr
+			}
+		}
 		return "", err
 	}
+	if turn != nil && turn.resumed {
+		r.confirm.finish(ctx, in.ConversationID)
+	}
 	if out == nil {
 		return "", nil
 	}
@@ -100,20 +192,167 @@ func BuildResponseGraph(ctx context.Context, cfg Config) (Runner, error) {
 	// Create messages manager
 	mm := conversations.NewMessagesManager(cfg.ConversationRepo, cfg.Conversation)
 
+	// Create dialogue state tracker (optional)
+	var tracker *conversations.DialogueStateTracker
+	if cfg.DialogueStateRepo != nil {
+		tracker = conversations.NewDialogueStateTracker(cfg.DialogueStateRepo, cfg.Conversation)
+	}
+
+	// Rule classifier backs the NLU fallback; the fast path is optional
+	rules := classifiers.NewRuleClassifier(&cfg.NLUModel, cfg.NLUModel.FastPathConfidence)
+	var fastPath *classifiers.RuleClassifier
+	if cfg.NLUModel.FastPath {
+		fastPath = rules
+	}
+
+	// Create NLU result cache (optional); a changed catalog invalidates old entries
+	var nluCache *classifiers.NLUResultCache
+	if cfg.NLUCache != nil {
+		nluCache, err = classifiers.NewNLUResultCache(cfg.NLUCache, &cfg.NLUModel)
+		if err != nil {
+			return nil, err
+		}
+		if err := nluCache.SyncCatalogVersion(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tool calls run with timeouts, retries and circuit breakers
+	rcfg, err := resilienceConfig(cfg.Tools)
+	if err != nil {
+		return nil, err
+	}
+	resilience := tools.NewResilience(rcfg)
+
+	// Tool loops end at the call limit, the turn budget or when they stall
+	budget, err := toolBudget(cfg.Conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	// Results of read-only tools are reused across conversations (optional)
+	var toolCache *tools.ToolCache
+	if cfg.ToolCache != nil {
+		toolCache = tools.NewToolCache(cfg.ToolCache)
+	}
+
+	// Side-effecting tool calls wait for the customer's confirmation (optional)
+	var confirm *toolConfirmation
+	if cfg.Conversation.Tools.Confirm {
+		pending := cfg.PendingActions
+		if pending == nil {
+			pending = repo.NewMemoryPendingActionRepository()
+		}
+		checkPoints := cfg.CheckPoints
+		if checkPoints == nil {
+			checkPoints = repo.NewMemoryCheckPointStore()
+		}
+		confirm = newToolConfirmation(pending, checkPoints, mm, cfg.ToolConfirmTTL)
+	}
+
 	// Build runnable graph
-	runnable, err := BuildGraph(ctx, &GraphConfig{
+	gcfg := &GraphConfig{
 		ChatModels:           cms,
 		MessagesManager:      mm,
+		DialogueTracker:      tracker,
+		FastPath:             fastPath,
+		RuleFallback:         rules,
+		NLUCache:             nluCache,
+		Catalog:              cfg.Catalog,
+		Promotions:           cfg.Promotions,
+		Inventory:            cfg.Inventory,
+		Orders:               cfg.Orders,
+		OrderReservationTTL:  cfg.OrderReservationTTL,
+		Carrier:              cfg.Carrier,
+		Knowledge:            cfg.Knowledge,
+		Tools:                cfg.Tools,
+		Resilience:           resilience,
+		ToolCache:            toolCache,
 		NLUConfig:            &cfg.NLUModel,
 		ResponsePromptConfig: &cfg.ResponsePrompt,
-		ToolMaxCalls:         cfg.Conversation.Tools.MaxCalls,
-	})
+		ToolBudget:           budget,
+	}
+	if confirm != nil {
+		gcfg.ConfirmToolCalls = true
+		gcfg.CheckPoints = confirm.checkPoints
+	}
+	runnable, err := BuildGraph(ctx, gcfg)
 	if err != nil {
 		return nil, err
 	}
 
 	logx.Debug().Msg("Response graph built successfully")
-	return &graphRunner{runnable: runnable}, nil
+	return &graphRunner{
+		runnable:    runnable,
+		confirm:     confirm,
+		resilience:  resilience,
+		toolCache:   toolCache,
+		maxParallel: cfg.Tools.MaxParallel,
+	}, nil
+}
+
+// resilienceConfig parses the tool execution limits; empty values take the
+// tools package defaults.
+func resilienceConfig(c model.ToolsConfig) (tools.ResilienceConfig, error) {
+	out := tools.ResilienceConfig{
+		Retries:          c.Retries,
+		BreakerThreshold: c.BreakerThreshold,
+		Timeouts:         make(map[string]time.Duration, len(c.Timeouts)),
+	}
+	durations := []struct {
+		env string
+		val string
+		dst *time.Duration
+	}{
+		{"TOOLS_TIMEOUT", c.Timeout, &out.Timeout},
+		{"TOOLS_RETRY_BACKOFF", c.RetryBackoff, &out.RetryBackoff},
+		{"TOOLS_RETRY_MAX_BACKOFF", c.RetryMaxBackoff, &out.RetryMaxBackoff},
+		{"TOOLS_BREAKER_COOLDOWN", c.BreakerCooldown, &out.BreakerCooldown},
+	}
+	for _, d := range durations {
+		if d.val == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.val)
+		if err != nil {
+			return out, fmt.Errorf("invalid %s '%s': %w", d.env, d.val, err)
+		}
+		*d.dst = v
+	}
+	for name, val := range c.Timeouts {
+		if _, ok := tools.Lookup(name); !ok {
+			return out, fmt.Errorf("invalid TOOLS_TIMEOUTS entry %s: unknown tool", name)
+		}
+		v, err := time.ParseDuration(val)
+		if err != nil {
+			return out, fmt.Errorf("invalid TOOLS_TIMEOUTS entry %s:%s: %w", name, val, err)
+		}
+		out.Timeouts[name] = v
+	}
+	return out, nil
+}
+
+// toolBudget parses the tool loop limits of a query.
+func toolBudget(c model.ConversationConfig) (nodes.ToolBudget, error) {
+	out := nodes.ToolBudget{
+		MaxCalls:        c.Tools.MaxCalls,
+		MaxCallsPerTool: c.Tools.MaxCallsPerTool,
+		MaxCallsByTool:  make(map[string]int, len(c.Tools.MaxCallsByTool)),
+	}
+	for name, n := range c.Tools.MaxCallsByTool {
+		if _, ok := tools.Lookup(name); !ok {
+			return out, fmt.Errorf("invalid CONVERSATION_TOOL_MAX_CALLS_BY_TOOL entry %s: unknown tool", name)
+		}
+		out.MaxCallsByTool[name] = n
+	}
+	if c.Tools.TurnBudget != "" {
+		d, err := time.ParseDuration(c.Tools.TurnBudget)
+		if err != nil {
+			return out, fmt.Errorf("invalid CONVERSATION_TOOL_TURN_BUDGET '%s': %w", c.Tools.TurnBudget, err)
+		}
+		out.TurnBudget = d
+	}
+	return out, nil
 }
 
 // BuildGraph constructs and returns the compiled agent graph
@@ -131,6 +370,9 @@ func BuildGraph(ctx context.Context, config *GraphConfig) (compose.Runnable[mode
 	if config.NLUConfig == nil || config.ResponsePromptConfig == nil {
 		return nil, fmt.Errorf("model prompt/config is nil")
 	}
+	if config.ConfirmToolCalls && config.CheckPoints == nil {
+		return nil, fmt.Errorf("checkpoint store is required to confirm tool calls")
+	}
 
 	builder := &GraphBuilder{
 		config: config,
@@ -157,7 +399,114 @@ func BuildGraph(ctx context.Context, config *GraphConfig) (compose.Runnable[mode
 
 // setupTools configures business tools and binds them to the response model
 func (b *GraphBuilder) setupTools(ctx context.Context) error {
-	businessTools := tools.GetQueryTools()
+	catalog := b.config.Catalog
+	if catalog == nil {
+		catalog = tools.NewMemoryCatalog(tools.DefaultProducts())
+	}
+	promotions := b.config.Promotions
+	if promotions == nil {
+		promotions = repo.NewMemoryPromotionStore(tools.DefaultPromotions(time.Now()))
+	}
+	inventory := b.config.Inventory
+	if inventory == nil {
+		inventory = tools.NewMemoryInventory(tools.DefaultBranches(), tools.DefaultStock(time.Now()))
+	}
+	orderStore := b.config.Orders
+	if orderStore == nil {
+		orderStore = repo.NewMemoryOrderStore(tools.DemoOrders(time.Now())...)
+	}
+	carrier := b.config.Carrier
+	if carrier == nil {
+		carrier = tools.NewFakeCarrier(tools.DefaultShipments(time.Now()))
+	}
+	kb := b.config.Knowledge
+	if kb == nil {
+		kb = knowledge.NewBase(nil, knowledge.NewHashEmbedder(knowledge.DefaultEmbeddingDim))
+	}
+	pricer := tools.NewPricer(promotions)
+	orders := tools.NewOrderService(orderStore, catalog, pricer, inventory, b.config.OrderReservationTTL)
+
+	// Bind only the registered tools enabled for this deployment
+	enabled, err := tools.Select(b.config.Tools.Enabled, b.config.Tools.Disabled)
+	if err != nil {
+		return fmt.Errorf("select tools: %w", err)
+	}
+	businessTools, err := tools.BuildTools(ctx, enabled, tools.Dependencies{
+		Catalog:    catalog,
+		Promotions: promotions,
+		Pricer:     pricer,
+		Inventory:  inventory,
+		Orders:     orders,
+		Carrier:    carrier,
+		Knowledge:  kb,
+	})
+	if err != nil {
+		logx.Error().Err(err).Msg("Failed to build tools")
+		return fmt.Errorf("failed to build tools: %w", err)
+	}
+	b.tools = enabled
+	names := make([]string, len(enabled))
+	for i, r := range enabled {
+		names[i] = r.Name
+	}
+	logx.Info().Strs("tools", names).Msg("Enabled tools")
+
+	// Arguments are checked against each tool's parameter schema; invalid
+	// calls come back to the model as an invalid_arguments result.
+	validator, err := tools.NewArgumentValidator(ctx, businessTools)
+	if err != nil {
+		logx.Error().Err(err).Msg("Failed to build tool argument validator")
+		return fmt.Errorf("failed to build tool argument validator: %w", err)
+	}
+	// Timeouts, retries and breakers wrap the calls themselves; invalid
+	// arguments never reach them, so they do not count as failures.
+	resilience := b.config.Resilience
+	if resilience == nil {
+		resilience = tools.NewResilience(tools.ResilienceConfig{})
+	}
+	if businessTools, err = resilience.Wrap(ctx, businessTools); err != nil {
+		logx.Error().Err(err).Msg("Failed to wrap tools")
+		return fmt.Errorf("failed to wrap tools: %w", err)
+	}
+	// Cached results are keyed by the validated, canonical arguments; a
+	// changed catalog drops them, and reserved or released stock drops those
+	// of the tools reporting live availability
+	if cache := b.config.ToolCache; cache != nil {
+		if businessTools, err = cache.Wrap(ctx, businessTools); err != nil {
+			logx.Error().Err(err).Msg("Failed to wrap tools with the cache")
+			return fmt.Errorf("failed to wrap tools with the cache: %w", err)
+		}
+		if n, ok := catalog.(tools.ChangeNotifier); ok {
+			n.OnChange(func() {
+				if err := cache.Invalidate(context.Background()); err != nil {
+					logx.Warn().Err(err).Msg("Failed to invalidate tool cache after catalog change")
+				}
+			})
+		}
+		if n, ok := inventory.(tools.ChangeNotifier); ok {
+			if live := tools.LiveStockTools(); len(live) > 0 {
+				n.OnChange(func() {
+					if err := cache.Invalidate(context.Background(), live...); err != nil {
+						logx.Warn().Err(err).Msg("Failed to invalidate tool cache after stock change")
+					}
+				})
+			}
+		}
+	}
+	// Projection, array caps and the token cap apply to fresh and cached
+	// results alike; the full output stays in the callbacks
+	if businessTools, err = tools.ShapeOutputs(ctx, businessTools, b.config.Tools.ResultMaxTokens); err != nil {
+		logx.Error().Err(err).Msg("Failed to wrap tools with output shaping")
+		return fmt.Errorf("failed to wrap tools with output shaping: %w", err)
+	}
+	businessTools = tools.ValidateArguments(businessTools, validator)
+	// Calls the ToolExecutor pre-handler refused (repeats, over a tool's
+	// cap, out of time) return their stand-in result without running
+	businessTools = tools.GuardCalls(businessTools)
+	// A failed call is returned to the model as a tool_failed result, so the
+	// other calls of the message still count
+	businessTools = tools.IsolateFailures(businessTools)
+
 	toolInfos, err := tools.GetToolInfos(ctx, businessTools)
 	if err != nil {
 		logx.Error().Err(err).Msg("Failed to get tool infos")
@@ -171,7 +520,7 @@ func (b *GraphBuilder) setupTools(ctx context.Context) error {
 
 	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{
 		Tools:               businessTools,
-		ExecuteSequentially: true,
+		ExecuteSequentially: !b.config.Tools.Parallel,
 		UnknownToolsHandler: func(ctx context.Context, name, input string) (string, error) {
 			// Gracefully handle hallucinated or malformed tool calls (e.g., empty name)
 			logx.Warn().
@@ -181,78 +530,24 @@ func (b *GraphBuilder) setupTools(ctx context.Context) error {
 			// Return a compact, structured message the model can use to proceed
 			return fmt.Sprintf("{\"error\":\"unknown_tool\",\"name\":%q,\"note\":\"ignored\"}", name), nil
 		},
-		ToolArgumentsHandler: func(ctx context.Context, name, arguments string) (string, error) {
-			// Best-effort sanitize; never fail hard here
-			var m map[string]any
-			if err := json.Unmarshal([]byte(arguments), &m); err != nil {
-				// keep original if not JSON
-				return arguments, nil
-			}
-
-			switch name {
-			case tools.ToolSearchProduct:
-				// query: string (required)
-				if v, ok := m["query"]; ok {
-					switch vv := v.(type) {
-					case string:
-						m["query"] = strings.TrimSpace(vv)
-					default:
-						// coerce non-string to string
-						m["query"] = strings.TrimSpace(fmt.Sprint(v))
-					}
-				}
-				// category: string (optional)
-				if v, ok := m["category"]; ok {
-					switch vv := v.(type) {
-					case string:
-						m["category"] = strings.TrimSpace(vv)
-					default:
-						delete(m, "category")
-					}
-				}
-				// max_results: number (optional, default 10, max 20)
-				if v, ok := m["max_results"]; ok {
-					switch vv := v.(type) {
-					case float64:
-						// JSON numbers decode as float64
-						m["max_results"] = clampInt(int(vv), 1, 20)
-					case string:
-						if n, err := strconv.Atoi(strings.TrimSpace(vv)); err == nil {
-							m["max_results"] = clampInt(n, 1, 20)
-						} else {
-							delete(m, "max_results")
-						}
-					default:
-						delete(m, "max_results")
-					}
-				}
-			case tools.ToolGetProductDetails:
-				// product_id: string (required)
-				if v, ok := m["product_id"]; ok {
-					switch vv := v.(type) {
-					case string:
-						m["product_id"] = strings.TrimSpace(vv)
-					default:
-						m["product_id"] = strings.TrimSpace(fmt.Sprint(v))
-					}
-				}
-			}
-
-			b, err := json.Marshal(m)
-			if err != nil {
-				// fallback to original
-				return arguments, nil
-			}
-			return string(b), nil
-		},
 	})
 	if err != nil {
 		logx.Error().Err(err).Msg("Failed to create tools node")
 		return fmt.Errorf("failed to create tools node: %w", err)
 	}
 
+	// Side-effecting calls stop at ToolApproval until the customer confirms them
+	var approval nodes.ToolApprovalConfig
+	if b.config.ConfirmToolCalls {
+		approval = nodes.ToolApprovalConfig{
+			NeedsConfirmation: tools.NeedsConfirmation,
+			Describe:          orders.DescribeAction,
+		}
+	}
+	b.graph.AddLambdaNode(nodes.NodeToolApproval, nodes.NewToolApprovalNode(approval))
+
 	b.graph.AddToolsNode(nodes.NodeToolExecutor, toolsNode,
-		compose.WithStatePreHandler(nodes.NewToolExecutorPreHandler(b.config.ToolMaxCalls)),
+		compose.WithStatePreHandler(nodes.NewToolExecutorPreHandler(b.config.ToolBudget)),
 	)
 
 	return nil
@@ -261,22 +556,34 @@ func (b *GraphBuilder) setupTools(ctx context.Context) error {
 // addNodes adds all processing nodes to the graph
 func (b *GraphBuilder) addNodes() {
 	b.graph.AddLambdaNode(nodes.NodeInputConverter,
-		nodes.NewInputConverterNode(b.config.MessagesManager, b.config.NLUConfig),
-		compose.WithStatePreHandler(nodes.NewInputConverterPreHandler()),
+		nodes.NewInputConverterNode(b.config.MessagesManager, b.config.NLUConfig, b.config.NLUCache),
+		compose.WithStatePreHandler(nodes.NewInputConverterPreHandler(b.config.FastPath)),
+	)
+
+	b.graph.AddLambdaNode(nodes.NodeNLUShortcut,
+		nodes.NewNLUShortcutNode(),
+		compose.WithStatePostHandler(nodes.NewParserPostHandler(b.config.DialogueTracker, nil)),
 	)
 
 	b.graph.AddChatModelNode(nodes.NodeNLUChatModel,
 		nodes.NewNLUChatModelNode(b.config.ChatModels.NLU),
+		compose.WithStatePreHandler(nodes.NewNLUChatModelPreHandler()),
 		compose.WithStatePostHandler(nodes.NewNLUChatModelPostHandler(b.config.ChatModels.NLUModelName)),
 	)
 
 	b.graph.AddLambdaNode(nodes.NodeParser,
-		nodes.NewParserNode(),
-		compose.WithStatePostHandler(nodes.NewParserPostHandler()),
+		nodes.NewParserNode(nodes.ParserNodeConfig{
+			Mode:           b.config.NLUConfig.Mode,
+			RepairAttempts: b.config.NLUConfig.RepairAttempts,
+			RepairModel:    b.config.ChatModels.NLU,
+			ModelName:      b.config.ChatModels.NLUModelName,
+			Fallback:       b.config.RuleFallback,
+		}),
+		compose.WithStatePostHandler(nodes.NewParserPostHandler(b.config.DialogueTracker, b.config.NLUCache)),
 	)
 
 	b.graph.AddLambdaNode(nodes.NodeResponseAssembler,
-		nodes.NewResponseAssemblerNode(b.config.MessagesManager, b.config.ResponsePromptConfig),
+		nodes.NewResponseAssemblerNode(b.config.MessagesManager, b.config.ResponsePromptConfig, b.tools, b.config.ConfirmToolCalls),
 	)
 
 	b.graph.AddLambdaNode(nodes.NodeHumanHandoff,
@@ -285,7 +592,7 @@ func (b *GraphBuilder) addNodes() {
 
 	b.graph.AddChatModelNode(nodes.NodeResponseChatModel,
 		nodes.NewResponseChatModelNode(b.config.ChatModels.Response),
-		compose.WithStatePreHandler(nodes.NewResponseChatModelPreHandler(b.config.ToolMaxCalls)),
+		compose.WithStatePreHandler(nodes.NewResponseChatModelPreHandler(b.config.ToolBudget, b.config.Tools.ResultTokenBudget)),
 		compose.WithStatePostHandler(nodes.NewResponseChatModelPostHandler(b.config.MessagesManager, b.config.ChatModels.ResponseModelName)),
 	)
 }
@@ -294,10 +601,10 @@ func (b *GraphBuilder) addNodes() {
 func (b *GraphBuilder) addEdges() {
 	edges := [][2]string{
 		{compose.START, nodes.NodeInputConverter},
-		{nodes.NodeInputConverter, nodes.NodeNLUChatModel},
 		{nodes.NodeNLUChatModel, nodes.NodeParser},
 		{nodes.NodeHumanHandoff, compose.END},
 		{nodes.NodeResponseAssembler, nodes.NodeResponseChatModel},
+		{nodes.NodeToolApproval, nodes.NodeToolExecutor},
 		{nodes.NodeToolExecutor, nodes.NodeResponseChatModel},
 	}
 
@@ -308,22 +615,41 @@ func (b *GraphBuilder) addEdges() {
 
 // addBranches creates conditional routing branches
 func (b *GraphBuilder) addBranches() error {
-	handoffBranch := compose.NewGraphBranch(
-		nodes.NewHumanHandoffCondition(),
+	var fastPathMetrics *classifiers.FastPathMetrics
+	if b.config.FastPath != nil {
+		fastPathMetrics = b.config.FastPath.Metrics()
+	}
+	nluBranch := compose.NewGraphBranch(
+		nodes.NewNLURouteCondition(fastPathMetrics),
 		map[string]bool{
-			nodes.NodeHumanHandoff:      true,
-			nodes.NodeResponseAssembler: true,
+			nodes.NodeNLUChatModel: true,
+			nodes.NodeNLUShortcut:  true,
 		},
 	)
-	if err := b.graph.AddBranch(nodes.NodeParser, handoffBranch); err != nil {
-		logx.Error().Err(err).Msg("Error adding human handoff branch")
-		return fmt.Errorf("error adding human handoff branch: %w", err)
+	if err := b.graph.AddBranch(nodes.NodeInputConverter, nluBranch); err != nil {
+		logx.Error().Err(err).Msg("Error adding NLU route branch")
+		return fmt.Errorf("error adding NLU route branch: %w", err)
+	}
+
+	// Both NLU sources (model + parser, or shortcut) share the handoff routing
+	for _, from := range []string{nodes.NodeParser, nodes.NodeNLUShortcut} {
+		handoffBranch := compose.NewGraphBranch(
+			nodes.NewHumanHandoffCondition(),
+			map[string]bool{
+				nodes.NodeHumanHandoff:      true,
+				nodes.NodeResponseAssembler: true,
+			},
+		)
+		if err := b.graph.AddBranch(from, handoffBranch); err != nil {
+			logx.Error().Err(err).Str("from", from).Msg("Error adding human handoff branch")
+			return fmt.Errorf("error adding human handoff branch: %w", err)
+		}
 	}
 
 	decisionBranch := compose.NewGraphBranch(
 		nodes.NewToolExecutorCondition(),
 		map[string]bool{
-			nodes.NodeToolExecutor: true,
+			nodes.NodeToolApproval: true,
 			compose.END:            true,
 		},
 	)
@@ -337,13 +663,19 @@ func (b *GraphBuilder) addBranches() error {
 
 // compile finalizes and compiles the graph
 func (b *GraphBuilder) compile(ctx context.Context) (compose.Runnable[model.QueryInput, *schema.Message], error) {
-	// Limit total run steps to avoid infinite loops in branching or tool retries
-	maxSteps := 10 + b.config.ToolMaxCalls*2
+	// Limit total run steps to avoid infinite loops in branching or tool retries;
+	// each tool round takes three steps (model, approval, tools)
+	maxSteps := 10 + b.config.ToolBudget.MaxCalls*3
 	if maxSteps < 20 {
 		maxSteps = 20
 	}
 
-	runnable, err := b.graph.Compile(ctx, compose.WithMaxRunSteps(maxSteps))
+	opts := []compose.GraphCompileOption{compose.WithMaxRunSteps(maxSteps)}
+	if b.config.CheckPoints != nil {
+		opts = append(opts, compose.WithCheckPointStore(b.config.CheckPoints))
+	}
+
+	runnable, err := b.graph.Compile(ctx, opts...)
 	if err != nil {
 		logx.Error().Err(err).Msg("Error compiling graph")
 		return nil, fmt.Errorf("error compiling graph: %w", err)
@@ -352,14 +684,3 @@ func (b *GraphBuilder) compile(ctx context.Context) (compose.Runnable[model.Quer
 	logx.Debug().Msg("Graph compiled successfully")
 	return runnable, nil
 }
-
-// clampInt returns v limited to [min, max].
-func clampInt(v, min, max int) int {
-	if v < min {
-		return min
-	}
-	if v > max {
-		return max
-	}
-	return v
-}
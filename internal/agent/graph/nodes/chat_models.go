@@ -9,6 +9,7 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"google.golang.org/genai"
 
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/parsers"
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
 )
 
@@ -46,7 +47,7 @@ func NewChatModels(ctx context.Context, config ChatModelConfig) (*ChatModels, er
 	}
 
 	// Create NLU Chat Model
-	chatModelNLU, err := gemini.NewChatModel(ctx, &gemini.Config{
+	nluCfg := &gemini.Config{
 		Client:      client,
 		Model:       config.NLUConfig.Model,
 		Temperature: &config.NLUConfig.Temperature,
@@ -55,7 +56,16 @@ func NewChatModels(ctx context.Context, config ChatModelConfig) (*ChatModels, er
 			IncludeThoughts: true,
 			ThinkingBudget:  genai.Ptr(int32(2000)),
 		},
-	})
+	}
+	switch config.NLUConfig.Mode {
+	case model.NLUModeJSON:
+		// Constrain output to the NLUResponse schema (sets application/json MIME type)
+		nluCfg.ResponseSchema = parsers.NLUResponseSchema()
+	case model.NLUModeTuple, "":
+	default:
+		return nil, fmt.Errorf("unknown NLU mode %q (expected %q or %q)", config.NLUConfig.Mode, model.NLUModeTuple, model.NLUModeJSON)
+	}
+	chatModelNLU, err := gemini.NewChatModel(ctx, nluCfg)
 	if err != nil {
 		logx.Error().Err(err).Msg("Error creating NLU model")
 		return nil, fmt.Errorf("error creating NLU model: %w", err)
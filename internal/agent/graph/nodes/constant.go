@@ -3,9 +3,11 @@ package nodes
 const (
 	NodeInputConverter    = "InputConverter"
 	NodeNLUChatModel      = "NLUChatModel"
+	NodeNLUShortcut       = "NLUShortcut"
 	NodeParser            = "Parser"
 	NodeHumanHandoff      = "HumanHandoff"
 	NodeResponseAssembler = "ResponsePromptAssembler"
+	NodeToolApproval      = "ToolApproval"
 	NodeToolExecutor      = "ToolExecutor"
 	NodeResponseChatModel = "ResponseChatModel"
 )
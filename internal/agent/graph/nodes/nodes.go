@@ -4,26 +4,53 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	einomodel "github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/classifiers"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/conversations"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/parsers"
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph/prompts"
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
 	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
 )
 
-// NewInputConverterPreHandler creates the pre-handler for InputConverter node
-func NewInputConverterPreHandler() func(context.Context, model.QueryInput, *model.AppState) (model.QueryInput, error) {
+// NewInputConverterPreHandler creates the pre-handler for InputConverter node.
+// When fastPath is non-nil, trivial messages are classified locally and the
+// result is stored in state for the NLU route condition.
+func NewInputConverterPreHandler(fastPath *classifiers.RuleClassifier) func(context.Context, model.QueryInput, *model.AppState) (model.QueryInput, error) {
 	return func(ctx context.Context, in model.QueryInput, s *model.AppState) (model.QueryInput, error) {
 		if s.ConversationID == "" {
 			s.ConversationID = in.ConversationID
 		}
-		// Reset tool call counter and limit flag for each new query
+		if in.CustomerID != "" {
+			s.CustomerID = in.CustomerID
+		}
+		s.CurrentQuery = in.Query
+		s.PrecomputedNLU = nil
+		s.NLUCacheKey = ""
+		s.NLUInput = nil
+		s.Degraded = false
+		s.DegradedReason = ""
+		if fastPath != nil {
+			if res, confident := fastPath.Classify(in.Query); confident {
+				s.PrecomputedNLU = res
+			}
+		}
+		// Reset tool call counter, limit flag and budgets for each new query
 		s.ToolCallCount = 0
 		s.ToolCallLimitReached = false
+		s.ToolLoopCutReason = ""
+		s.ToolLoopStartedAt = time.Time{}
+		s.ToolCallsByTool = nil
+		s.ToolCallKeys = nil
+		s.ToolResultsByKey = nil
+		s.ToolCallOverrides = nil
+		s.StalledToolRounds = 0
 		s.ToolCallIDSeq = 0
 		// Reset accumulated total cost for each new query
 		s.TotalCostUSD = 0
@@ -32,17 +59,26 @@ func NewInputConverterPreHandler() func(context.Context, model.QueryInput, *mode
 }
 
 // TODO: recheck context for all models nodes
-// NewInputConverterNode creates the InputConverter node for NLU processing
+// NewInputConverterNode creates the InputConverter node for NLU processing.
+// When cache is non-nil and no fast path result exists, a cached NLU result
+// for the same normalized message and context is placed in state.
 func NewInputConverterNode(
 	mm *conversations.MessagesManager,
 	nluCfg *model.NLUModelConfig,
+	cache *classifiers.NLUResultCache,
 ) *compose.Lambda {
 	return compose.InvokableLambda(func(ctx context.Context, input model.QueryInput) ([]*schema.Message, error) {
-		conversationCtx, err := mm.ProcessNLUMessage(ctx, input.ConversationID, input.Query)
+		conversationCtx, recent, err := mm.PrepareNLUMessage(ctx, input.ConversationID, input.Query)
 		if err != nil {
 			return nil, fmt.Errorf("error getting conversation context: %w", err)
 		}
 
+		if cache != nil {
+			if err := lookupNLUCache(ctx, cache, input.Query, recent); err != nil {
+				return nil, err
+			}
+		}
+
 		// Generate system prompt via Eino prompt component (enables prompt callbacks)
 		systemPrompt, err := prompts.RenderNLUSystem(ctx, nluCfg)
 		if err != nil {
@@ -59,6 +95,93 @@ func NewInputConverterNode(
 	})
 }
 
+// lookupNLUCache computes the cache key for this query and, unless the fast
+// path already answered, loads a cached NLU result into state.
+func lookupNLUCache(ctx context.Context, cache *classifiers.NLUResultCache, query string, recent []*schema.Message) error {
+	var skip bool
+	err := compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+		skip = state.PrecomputedNLU != nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to access state: %w", err)
+	}
+	if skip {
+		return nil
+	}
+
+	key := cache.Key(query, recent)
+	cached := cache.Lookup(ctx, key)
+	err = compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+		state.NLUCacheKey = key
+		state.PrecomputedNLU = cached
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to access state: %w", err)
+	}
+	return nil
+}
+
+// NewNLURouteCondition routes to the NLU shortcut when a precomputed NLU result
+// (rule fast path or cache) is available in state, otherwise to the NLU chat model.
+// Each decision is recorded in metrics (may be nil).
+func NewNLURouteCondition(metrics *classifiers.FastPathMetrics) func(context.Context, []*schema.Message) (string, error) {
+	return func(ctx context.Context, _ []*schema.Message) (string, error) {
+		var hit bool
+		var conversationID, source string
+		err := compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+			hit = state.PrecomputedNLU != nil
+			conversationID = state.ConversationID
+			if hit {
+				source, _ = state.PrecomputedNLU.ParsingMetadata["source"].(string)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to access state: %w", err)
+		}
+
+		if metrics != nil {
+			metrics.Record(hit)
+			hits, total, rate := metrics.Snapshot()
+			logx.Debug().
+				Str("conversation_id", conversationID).
+				Bool("fast_path_hit", hit).
+				Str("source", source).
+				Int64("fast_path_hits", hits).
+				Int64("fast_path_total", total).
+				Float64("fast_path_hit_rate", rate).
+				Msg("NLU fast path decision")
+		}
+
+		if hit {
+			return NodeNLUShortcut, nil
+		}
+		return NodeNLUChatModel, nil
+	}
+}
+
+// NewNLUShortcutNode emits the precomputed NLU result from state in place of
+// the NLU model + parser pair. Its output feeds the same post-handler and
+// routing as the Parser node.
+func NewNLUShortcutNode() *compose.Lambda {
+	return compose.InvokableLambda(func(ctx context.Context, _ []*schema.Message) (model.NLUResponse, error) {
+		var out model.NLUResponse
+		err := compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+			if state.PrecomputedNLU == nil {
+				return fmt.Errorf("missing precomputed NLU in state")
+			}
+			out = *state.PrecomputedNLU
+			return nil
+		})
+		if err != nil {
+			return model.NLUResponse{}, fmt.Errorf("failed to access state: %w", err)
+		}
+		return out, nil
+	})
+}
+
 // NewNLUChatModelPostHandler computes and logs usage cost for the NLU model.
 func NewNLUChatModelPostHandler(modelName string) func(context.Context, *schema.Message, *model.AppState) (*schema.Message, error) {
 	return func(ctx context.Context, out *schema.Message, state *model.AppState) (*schema.Message, error) {
@@ -100,27 +223,180 @@ func NewNLUChatModelPostHandler(modelName string) func(context.Context, *schema.
 	}
 }
 
-// NewParserNode creates the Parser node for NLU response parsing
-func NewParserNode() *compose.Lambda {
+// NewNLUChatModelPreHandler keeps the NLU model input in state so the parser
+// can re-prompt with the same context when the output is unparseable.
+func NewNLUChatModelPreHandler() func(context.Context, []*schema.Message, *model.AppState) ([]*schema.Message, error) {
+	return func(ctx context.Context, in []*schema.Message, state *model.AppState) ([]*schema.Message, error) {
+		state.NLUInput = in
+		return in, nil
+	}
+}
+
+// ParserNodeConfig configures parsing, repair and fallback of NLU model output.
+type ParserNodeConfig struct {
+	Mode           string                      // tuple or JSON parser (see model.NLUModeTuple / model.NLUModeJSON)
+	RepairAttempts int                         // re-prompts of RepairModel on unparseable output; 0 disables
+	RepairModel    einomodel.BaseChatModel     // usually the NLU chat model; nil disables repair
+	ModelName      string                      // for usage cost of repair calls
+	Fallback       *classifiers.RuleClassifier // local classifier used when repair fails; nil keeps the empty result
+}
+
+// NewParserNode creates the Parser node for NLU response parsing.
+// Output without any valid intent is repaired by re-prompting the NLU model
+// with the parse errors, then replaced by the rule-based fallback (flagged
+// degraded). A missing primary language is filled from the message script.
+func NewParserNode(cfg ParserNodeConfig) *compose.Lambda {
 	return compose.InvokableLambda(func(ctx context.Context, resp *schema.Message) (model.NLUResponse, error) {
-		result, err := parsers.ParseNLUResponse(resp.Content)
+		var content string
+		if resp != nil {
+			content = resp.Content
+		}
+		result, problems := parseNLUOutput(content, cfg.Mode)
+
+		var query string
+		var nluInput []*schema.Message
+		err := compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+			query = state.CurrentQuery
+			nluInput = state.NLUInput
+			return nil
+		})
 		if err != nil {
-			logx.Error().Err(err).Msg("Error parsing NLU response")
-			return model.NLUResponse{}, err
+			return model.NLUResponse{}, fmt.Errorf("failed to access state: %w", err)
 		}
+
+		attempts := 0
+		for result == nil && attempts < cfg.RepairAttempts && cfg.RepairModel != nil && len(nluInput) > 0 {
+			attempts++
+			logx.Warn().
+				Strs("problems", problems).
+				Int("attempt", attempts).
+				Msg("NLU output unparseable; re-prompting NLU model")
+			repaired, err := repairNLU(ctx, cfg, nluInput, content, problems)
+			if err != nil {
+				logx.Error().Err(err).Msg("NLU repair call failed")
+				break
+			}
+			content = repaired.Content
+			result, problems = parseNLUOutput(content, cfg.Mode)
+			if result != nil {
+				result.ParsingMetadata["repaired"] = true
+				result.ParsingMetadata["repair_attempts"] = attempts
+			}
+		}
+
 		if result == nil {
-			logx.Error().Msg("Parsing returned nil result")
-			return model.NLUResponse{}, fmt.Errorf("parsing returned nil result")
+			if cfg.Fallback == nil {
+				logx.Error().Strs("problems", problems).Msg("NLU output unparseable and no fallback configured")
+				return model.NLUResponse{}, fmt.Errorf("nlu output unparseable: %s", strings.Join(problems, "; "))
+			}
+			logx.Warn().
+				Strs("problems", problems).
+				Int("repair_attempts", attempts).
+				Msg("NLU output unparseable; falling back to rule classifier (degraded)")
+			result = cfg.Fallback.Fallback(query, "nlu_unparseable")
+			result.ParsingMetadata["parsing_errors"] = problems
+			result.ParsingMetadata["repair_attempts"] = attempts
 		}
+
+		// Without a detected language the response prompt would default to English
+		if result.PrimaryLanguage == "" {
+			if langs := classifiers.DetectLanguages(query); len(langs) > 0 {
+				result.Languages = langs
+				result.PrimaryLanguage = langs[0].Code
+				result.ParsingMetadata["language_source"] = "script"
+			}
+		}
+
+		// Attach typed canonical values (money, quantity, color, brand) to entities
+		parsers.NormalizeEntities(result)
 		return *result, nil
 	})
 }
 
-// NewParserPostHandler creates the post-handler for Parser node
-func NewParserPostHandler() func(context.Context, model.NLUResponse, *model.AppState) (model.NLUResponse, error) {
+// parseNLUOutput parses content and returns nil with the problems found when
+// the result is unusable (parser error or no valid intent).
+func parseNLUOutput(content, mode string) (*model.NLUResponse, []string) {
+	result, err := parsers.ParseNLU(content, mode)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	if result == nil {
+		return nil, []string{"parser returned no result"}
+	}
+	if len(result.Intents) > 0 {
+		return result, nil
+	}
+	problems, _ := result.ParsingMetadata["parsing_errors"].([]string)
+	problems = append(problems, "no valid intent found")
+	return nil, problems
+}
+
+// repairNLU re-prompts the NLU model with its previous answer and the parse errors.
+func repairNLU(ctx context.Context, cfg ParserNodeConfig, nluInput []*schema.Message, previous string, problems []string) (*schema.Message, error) {
+	msgs := make([]*schema.Message, 0, len(nluInput)+2)
+	msgs = append(msgs, nluInput...)
+	msgs = append(msgs,
+		schema.AssistantMessage(previous, nil),
+		schema.UserMessage(prompts.RenderNLURepair(cfg.Mode, problems)),
+	)
+	out, err := cfg.RepairModel.Generate(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, fmt.Errorf("nlu repair returned no message")
+	}
+	// account the extra call like a regular NLU model call
+	costHandler := NewNLUChatModelPostHandler(cfg.ModelName)
+	err = compose.ProcessState(ctx, func(ctx context.Context, state *model.AppState) error {
+		_, err := costHandler(ctx, out, state)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to access state: %w", err)
+	}
+	return out, nil
+}
+
+// NewParserPostHandler creates the post-handler for Parser node.
+// When tracker is non-nil, the turn's normalized entities are merged into the
+// persisted dialogue state, which is then kept in AppState for this query.
+// When cache is non-nil, the model-produced result is stored under the key
+// computed by the input converter.
+func NewParserPostHandler(tracker *conversations.DialogueStateTracker, cache *classifiers.NLUResultCache) func(context.Context, model.NLUResponse, *model.AppState) (model.NLUResponse, error) {
 	return func(ctx context.Context, out model.NLUResponse, state *model.AppState) (model.NLUResponse, error) {
+		if cache != nil && state.NLUCacheKey != "" {
+			cache.Store(ctx, state.NLUCacheKey, out)
+			if out.ParsingMetadata == nil {
+				out.ParsingMetadata = map[string]any{}
+			}
+			out.ParsingMetadata["cache_hit"] = false
+		}
+
 		// Save NLU to State
 		state.NLUAnalysis = &out
+		state.Degraded, _ = out.ParsingMetadata["degraded"].(bool)
+		state.DegradedReason, _ = out.ParsingMetadata["degraded_reason"].(string)
+		if state.Degraded {
+			logx.Warn().
+				Str("conversation_id", state.ConversationID).
+				Str("reason", state.DegradedReason).
+				Msg("Run degraded: NLU analysis from rule fallback")
+		}
+
+		state.DialogueState = nil
+		if tracker != nil {
+			ds, err := tracker.Update(ctx, state.ConversationID, out)
+			if err != nil {
+				// slot tracking is best-effort; the turn can still be answered from history
+				logx.Error().
+					Str("conversation_id", state.ConversationID).
+					Err(err).
+					Msg("Error updating dialogue state")
+			} else {
+				state.DialogueState = ds
+			}
+		}
 
 		importanceScore := out.ImportanceScore
 		conversationID := state.ConversationID
@@ -173,10 +449,13 @@ func NewHumanHandoffNode() *compose.Lambda {
 	})
 }
 
-// NewResponseAssemblerNode creates the ResponseAssembler node for building response context
+// NewResponseAssemblerNode creates the ResponseAssembler node for building response context.
+// confirmToolCalls tells the prompt whether the system confirms side-effecting calls.
 func NewResponseAssemblerNode(
 	mm *conversations.MessagesManager,
 	responsePromptConfig *model.ResponsePromptConfig,
+	enabledTools []tools.Registration,
+	confirmToolCalls bool,
 ) *compose.Lambda {
 	return compose.InvokableLambda(func(ctx context.Context, nluResult model.NLUResponse) ([]*schema.Message, error) {
 		// Get data from state
@@ -187,6 +466,8 @@ func NewResponseAssemblerNode(
 			}
 			data = model.ResponseData{
 				Analysis:       *state.NLUAnalysis,
+				Degraded:       state.Degraded,
+				Dialogue:       state.DialogueState,
 				ConversationID: state.ConversationID,
 			}
 			return nil
@@ -196,7 +477,7 @@ func NewResponseAssemblerNode(
 		}
 
 		// Generate system prompt with NLU analysis via Eino prompt component (enables prompt callbacks)
-		respSysPrompt, err := prompts.RenderResponseSystem(ctx, *responsePromptConfig, data.Analysis)
+		respSysPrompt, err := prompts.RenderResponseSystem(ctx, *responsePromptConfig, enabledTools, confirmToolCalls, data.Analysis, data.Dialogue, data.Degraded)
 		if err != nil {
 			return nil, fmt.Errorf("generate response prompt: %w", err)
 		}
@@ -211,8 +492,10 @@ func NewResponseAssemblerNode(
 	})
 }
 
-// NewResponseChatModelPreHandler creates the pre-handler for ResponseChatModel node
-func NewResponseChatModelPreHandler(maxToolCalls int) func(context.Context, []*schema.Message, *model.AppState) ([]*schema.Message, error) {
+// NewResponseChatModelPreHandler creates the pre-handler for ResponseChatModel node.
+// budget decides when the tool loop ends; resultTokenBudget bounds the tool
+// results kept in History (0 = unlimited).
+func NewResponseChatModelPreHandler(budget ToolBudget, resultTokenBudget int) func(context.Context, []*schema.Message, *model.AppState) ([]*schema.Message, error) {
 	return func(ctx context.Context, in []*schema.Message, state *model.AppState) ([]*schema.Message, error) {
 		// Heuristic fix for Gemini OpenAI-compat: ensure tool results carry tool_call_id
 		if len(in) > 0 {
@@ -235,17 +518,27 @@ func NewResponseChatModelPreHandler(maxToolCalls int) func(context.Context, []*s
 		}
 
 		state.History = append(state.History, in...)
+		recordToolResults(state, in)
+
+		// Older tool results make way for new ones once the budget is spent
+		if n := fitToolResults(state.History, resultTokenBudget, len(in)); n > 0 {
+			state.ToolResultsDropped += n
+			logx.Debug().
+				Str("conversation_id", state.ConversationID).
+				Int("dropped", n).
+				Int("budget_tokens", resultTokenBudget).
+				Msg("Dropped older tool results from the response context")
+		}
 
-		if checkAndMarkToolLimit(state, maxToolCalls) {
-			maxToolCalls = normalizeMaxToolCalls(maxToolCalls)
+		if checkAndMarkToolLimit(state, budget, time.Now()) {
+			logx.Warn().
+				Str("conversation_id", state.ConversationID).
+				Str("reason", state.ToolLoopCutReason).
+				Int("tool_call_count", state.ToolCallCount).
+				Msg("Tool loop cut; asking the model to wrap up")
 			wrapUp := &schema.Message{
-				Role: schema.System,
-				Content: fmt.Sprintf(
-					"SYSTEM NOTICE: You have reached the maximum tool call limit (%d). "+
-						"Please synthesize a helpful response using the information you've already gathered. "+
-						"Acknowledge any limitations in your response if you couldn't complete all necessary tool calls.",
-					maxToolCalls,
-				),
+				Role:    schema.System,
+				Content: wrapUpNotice(state, budget),
 			}
 			state.History = append(state.History, wrapUp)
 		}
@@ -307,6 +600,31 @@ func NewResponseChatModelPostHandler(
 			}
 		}
 
+		// Surface degraded NLU on the final answer so callers can monitor it
+		if out != nil && state.Degraded && len(out.ToolCalls) == 0 {
+			if out.Extra == nil {
+				out.Extra = map[string]any{}
+			}
+			out.Extra["degraded"] = true
+			out.Extra["degraded_reason"] = state.DegradedReason
+		}
+
+		// Surface escalations raised by tools so callers can hand off to staff
+		if out != nil && len(state.Escalations) > 0 && len(out.ToolCalls) == 0 {
+			if out.Extra == nil {
+				out.Extra = map[string]any{}
+			}
+			out.Extra["escalations"] = state.Escalations
+		}
+
+		// Say why the answer came without all the tool calls the model wanted
+		if out != nil && state.ToolLoopCutReason != "" && (len(out.ToolCalls) == 0 || state.ToolCallLimitReached) {
+			if out.Extra == nil {
+				out.Extra = map[string]any{}
+			}
+			out.Extra["tool_loop_cut"] = state.ToolLoopCutReason
+		}
+
 		state.History = append(state.History, out)
 
 		// Clean logging for tool calls and responses
@@ -352,8 +670,8 @@ func NewToolExecutorCondition() func(context.Context, *schema.Message) (string,
 		}
 
 		if len(input.ToolCalls) > 0 {
-			logx.Debug().Int("tool_count", len(input.ToolCalls)).Msg("Routing to ToolExecutor")
-			return NodeToolExecutor, nil
+			logx.Debug().Int("tool_count", len(input.ToolCalls)).Msg("Routing to ToolApproval")
+			return NodeToolApproval, nil
 		}
 
 		logx.Debug().Msg("No tool calls - continuing to end")
@@ -361,22 +679,107 @@ func NewToolExecutorCondition() func(context.Context, *schema.Message) (string,
 	}
 }
 
-// NewToolExecutorPreHandler creates the pre-handler for ToolExecutor node
-func NewToolExecutorPreHandler(maxToolCalls int) func(context.Context, *schema.Message, *model.AppState) (*schema.Message, error) {
+// ToolApprovalConfig decides which tool calls need the customer's confirmation.
+type ToolApprovalConfig struct {
+	// NeedsConfirmation reports whether calls to a tool must be confirmed; nil
+	// lets every call through.
+	NeedsConfirmation func(toolName string) bool
+	// Describe renders a tool call for the confirmation prompt (optional).
+	Describe func(ctx context.Context, toolName, arguments string) string
+}
+
+// NewToolApprovalNode creates the ToolApproval node that gates ToolExecutor.
+// When the model message holds side-effecting calls that the customer has not
+// confirmed, the node interrupts the graph (rerun on resume) with the pending
+// calls as []model.PendingToolCall; the runner checkpoints the run and asks
+// for confirmation. The keys (tool name and canonical arguments) of the
+// confirmed calls are placed in state before resuming; each approves one
+// call and is used up when that call is let through.
+func NewToolApprovalNode(cfg ToolApprovalConfig) *compose.Lambda {
+	return compose.InvokableLambda(func(ctx context.Context, in *schema.Message) (*schema.Message, error) {
+		if cfg.NeedsConfirmation == nil {
+			return in, nil
+		}
+
+		// A rerun node receives a zero input on resume, so the message it
+		// paused on is kept in state.
+		var (
+			approved       = map[string]int{}
+			conversationID string
+		)
+		_ = compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+			if in == nil {
+				in = state.PendingToolMessage
+			}
+			state.PendingToolMessage = nil
+			for _, key := range state.ApprovedToolCalls {
+				approved[key]++
+			}
+			conversationID = state.ConversationID
+			return nil
+		})
+		if in == nil {
+			return nil, fmt.Errorf("tool approval has no message to approve")
+		}
+
+		var (
+			pending []model.PendingToolCall
+			used    = map[string]int{}
+		)
+		for _, tc := range in.ToolCalls {
+			if !cfg.NeedsConfirmation(tc.Function.Name) {
+				continue
+			}
+			key := tools.CallKey(tc.Function.Name, tc.Function.Arguments)
+			if approved[key] > used[key] {
+				used[key]++
+				continue
+			}
+			call := model.PendingToolCall{ID: tc.ID, Key: key, Name: tc.Function.Name, Arguments: tc.Function.Arguments}
+			if cfg.Describe != nil {
+				call.Summary = cfg.Describe(ctx, tc.Function.Name, tc.Function.Arguments)
+			}
+			pending = append(pending, call)
+		}
+		if len(pending) == 0 {
+			_ = compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+				state.ApprovedToolCalls = consumeApprovals(state.ApprovedToolCalls, used)
+				return nil
+			})
+			return in, nil
+		}
+
+		_ = compose.ProcessState(ctx, func(_ context.Context, state *model.AppState) error {
+			state.PendingToolMessage = in
+			return nil
+		})
+		logx.Info().
+			Str("conversation_id", conversationID).
+			Int("pending_calls", len(pending)).
+			Str("tool_name", pending[0].Name).
+			Msg("Side-effecting tool call awaits confirmation")
+		return nil, compose.NewInterruptAndRerunErr(pending)
+	})
+}
+
+// NewToolExecutorPreHandler creates the pre-handler for ToolExecutor node.
+// It counts the round against budget and screens its calls: repeats, calls
+// over a tool's cap and calls after the turn budget get stand-in results.
+func NewToolExecutorPreHandler(budget ToolBudget) func(context.Context, *schema.Message, *model.AppState) (*schema.Message, error) {
 	return func(ctx context.Context, in *schema.Message, state *model.AppState) (*schema.Message, error) {
 		// TODO: Production-grade resource management (ordered by priority)
 		//
 		// CRITICAL (Security & Availability):
 		// 1. [HIGH] Implement per-conversation rate limiting to prevent abuse
 		// 2. [HIGH] Add tool input validation and sanitization for security
-		// 3. [HIGH] Implement circuit breaker pattern for external API failures
+		// [DONE] Circuit breaker per tool (tools.Resilience)
 		// 4. [MEDIUM] Add tool authentication and permission validation
 		//
 		// PERFORMANCE (Scalability):
 		// 5. [HIGH] Add exponential backoff between rapid tool calls
-		// 6. [MEDIUM] Track tool execution time with configurable timeouts
-		// 7. [MEDIUM] Monitor memory usage for large tool responses
-		// 8. [LOW] Implement response caching for frequently used tools
+		// [DONE] Per-tool execution timeouts (tools.Resilience)
+		// [DONE] Large tool responses are shaped and budgeted (tools.ShapeOutputs, fitToolResults)
+		// [DONE] Response caching for read-only tools (tools.ToolCache)
 		//
 		// OBSERVABILITY (Monitoring):
 		// 9. [HIGH] Add structured logging with correlation IDs
@@ -386,22 +789,24 @@ func NewToolExecutorPreHandler(maxToolCalls int) func(context.Context, *schema.M
 		//
 		// USER EXPERIENCE (Graceful Degradation):
 		// [DONE] Basic tool call limit with graceful fallback message
-		// 13. [MEDIUM] Implement partial success handling (some tools fail, others succeed)
-		// 14. [LOW] Add retry mechanism with intelligent backoff for transient failures
+		// [DONE] Per-tool caps, repeated-call replay, turn budget and cut reasons (screenToolCalls)
+		// [DONE] Partial success handling: failed calls become tool_failed results (tools.IsolateFailures)
+		// [DONE] Retries with jittered backoff for transient failures (tools.Resilience)
 
 		// Increment tool call counter
-		exceeded := incrementToolCallAndCheck(state, maxToolCalls)
+		exceeded := incrementToolCallAndCheck(state, budget.MaxCalls)
+		refused := screenToolCalls(state, in, budget, time.Now())
 
 		logx.Debug().
 			Int("tool_call_count", state.ToolCallCount).
+			Int("refused_calls", refused).
 			Str("conversation_id", state.ConversationID).
 			Msg("Tool execution attempt")
 
 		if exceeded {
-			maxToolCalls = normalizeMaxToolCalls(maxToolCalls)
 			logx.Warn().
 				Int("tool_call_count", state.ToolCallCount).
-				Int("max_tool_calls", maxToolCalls).
+				Int("max_tool_calls", normalizeMaxToolCalls(budget.MaxCalls)).
 				Str("conversation_id", state.ConversationID).
 				Msg("Tool call limit exceeded - flagging and continuing")
 			return in, nil
@@ -0,0 +1,83 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// runApproval runs the ToolApproval node on msg with state and returns the
+// calls it paused on, or nil when it let the message through.
+func runApproval(t *testing.T, state *model.AppState, msg *schema.Message) []model.PendingToolCall {
+	t.Helper()
+	g := compose.NewGraph[*schema.Message, *schema.Message](compose.WithGenLocalState(func(context.Context) *model.AppState {
+		return state
+	}))
+	cfg := ToolApprovalConfig{NeedsConfirmation: func(name string) bool { return name == "add_to_cart" }}
+	if err := g.AddLambdaNode(NodeToolApproval, NewToolApprovalNode(cfg)); err != nil {
+		t.Fatalf("AddLambdaNode: %v", err)
+	}
+	_ = g.AddEdge(compose.START, NodeToolApproval)
+	_ = g.AddEdge(NodeToolApproval, compose.END)
+	r, err := g.Compile(context.Background())
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	_, err = r.Invoke(context.Background(), msg)
+	if err == nil {
+		return nil
+	}
+	info, ok := compose.ExtractInterruptInfo(err)
+	if !ok {
+		t.Fatalf("Invoke: %v", err)
+	}
+	calls, _ := info.RerunNodesExtra[NodeToolApproval].([]model.PendingToolCall)
+	return calls
+}
+
+func addToCart(id, productID string) schema.ToolCall {
+	return schema.ToolCall{ID: id, Function: schema.FunctionCall{Name: "add_to_cart", Arguments: `{"product_id":"` + productID + `"}`}}
+}
+
+func TestToolApprovalIsPerCallNotPerID(t *testing.T) {
+	// Gemini sets every call ID to the tool name
+	approvedCall := addToCart("add_to_cart", "prod-001")
+	state := &model.AppState{ApprovedToolCalls: []string{
+		tools.CallKey(approvedCall.Function.Name, approvedCall.Function.Arguments),
+	}}
+
+	other := &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{addToCart("add_to_cart", "prod-002")}}
+	pending := runApproval(t, state, other)
+	if len(pending) != 1 || pending[0].Arguments != `{"product_id":"prod-002"}` {
+		t.Fatalf("call with other arguments and the same ID: pending = %+v, want it to await confirmation", pending)
+	}
+
+	state.PendingToolMessage = nil
+	approved := &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{approvedCall}}
+	if pending := runApproval(t, state, approved); pending != nil {
+		t.Fatalf("approved call: pending = %+v, want it let through", pending)
+	}
+	if len(state.ApprovedToolCalls) != 0 {
+		t.Errorf("approvals left after the call ran = %v, want none", state.ApprovedToolCalls)
+	}
+	if pending := runApproval(t, state, approved); len(pending) != 1 {
+		t.Errorf("same call again: pending = %+v, want it to await a new confirmation", pending)
+	}
+}
+
+func TestToolApprovalCountsIdenticalCalls(t *testing.T) {
+	call := addToCart("add_to_cart", "prod-001")
+	state := &model.AppState{ApprovedToolCalls: []string{tools.CallKey(call.Function.Name, call.Function.Arguments)}}
+
+	twice := &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{call, call}}
+	pending := runApproval(t, state, twice)
+	if len(pending) != 1 {
+		t.Errorf("two identical calls with one approval: pending = %d calls, want 1", len(pending))
+	}
+}
@@ -1,11 +1,43 @@
 package nodes
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
 )
 
 const DefaultMaxToolCalls = 10
 
+// maxStalledToolRounds is how many tool rounds in a row may have every call
+// refused before the loop is cut as making no progress.
+const maxStalledToolRounds = 2
+
+// ToolBudget bounds the tool loop of one query.
+type ToolBudget struct {
+	MaxCalls        int            // tool rounds (model messages with tool calls); <= 0 uses DefaultMaxToolCalls
+	MaxCallsPerTool int            // calls of one tool; 0 = unlimited
+	MaxCallsByTool  map[string]int // per-tool overrides of MaxCallsPerTool
+	TurnBudget      time.Duration  // wall-clock time from the first tool round; 0 = unlimited
+}
+
+// toolCap is how many times the named tool may run in one query (0 = unlimited).
+func (b ToolBudget) toolCap(name string) int {
+	if n, ok := b.MaxCallsByTool[name]; ok {
+		return n
+	}
+	return b.MaxCallsPerTool
+}
+
+// outOfTime reports whether the turn budget of the query is spent.
+func (b ToolBudget) outOfTime(state *model.AppState, now time.Time) bool {
+	return b.TurnBudget > 0 && !state.ToolLoopStartedAt.IsZero() && now.Sub(state.ToolLoopStartedAt) >= b.TurnBudget
+}
+
 // ===== Small helpers to keep handlers simple/readable =====
 // normalizeMaxToolCalls returns a sane default when the provided value is invalid.
 func normalizeMaxToolCalls(n int) int {
@@ -15,15 +47,26 @@ func normalizeMaxToolCalls(n int) int {
 	return n
 }
 
-// checkAndMarkToolLimit evaluates whether another tool call would exceed the
-// limit and, if so, marks the state accordingly. Returns true when marked now.
-func checkAndMarkToolLimit(state *model.AppState, max int) bool {
-	max = normalizeMaxToolCalls(max)
-	if !state.ToolCallLimitReached && state.ToolCallCount >= max {
-		state.ToolCallLimitReached = true
-		return true
+// checkAndMarkToolLimit evaluates whether the tool loop must end before the
+// next model call: another round would exceed the limit, the turn budget is
+// spent, or the last rounds made no progress. If so, it marks the state with
+// the reason. Returns true when marked now.
+func checkAndMarkToolLimit(state *model.AppState, budget ToolBudget, now time.Time) bool {
+	if state.ToolCallLimitReached {
+		return false
 	}
-	return false
+	switch {
+	case state.ToolCallCount >= normalizeMaxToolCalls(budget.MaxCalls):
+		state.ToolLoopCutReason = model.ToolLoopMaxCalls
+	case budget.outOfTime(state, now):
+		state.ToolLoopCutReason = model.ToolLoopTurnBudget
+	case state.StalledToolRounds >= maxStalledToolRounds:
+		state.ToolLoopCutReason = model.ToolLoopNoProgress
+	default:
+		return false
+	}
+	state.ToolCallLimitReached = true
+	return true
 }
 
 // incrementToolCallAndCheck increments the count and marks the state if it
@@ -33,7 +76,183 @@ func incrementToolCallAndCheck(state *model.AppState, max int) bool {
 	state.ToolCallCount++
 	if state.ToolCallCount > max {
 		state.ToolCallLimitReached = true
+		state.ToolLoopCutReason = model.ToolLoopMaxCalls
 		return true
 	}
 	return false
 }
+
+// wrapUpNotice tells the model why it must answer without further tool calls.
+func wrapUpNotice(state *model.AppState, budget ToolBudget) string {
+	const answer = "Please synthesize a helpful response using the information you've already gathered. " +
+		"Acknowledge any limitations in your response if you couldn't complete all necessary tool calls."
+	switch state.ToolLoopCutReason {
+	case model.ToolLoopTurnBudget:
+		return fmt.Sprintf("SYSTEM NOTICE: This turn has used its time for tool calls (%s). %s", budget.TurnBudget, answer)
+	case model.ToolLoopNoProgress:
+		return "SYSTEM NOTICE: Your recent tool calls repeated earlier ones or exceeded their limits and returned nothing new. " + answer
+	default:
+		return fmt.Sprintf("SYSTEM NOTICE: You have reached the maximum tool call limit (%d). %s", normalizeMaxToolCalls(budget.MaxCalls), answer)
+	}
+}
+
+// screenToolCalls decides, before the tools node runs, which calls of msg run.
+// A call identical to an earlier one of the query (same tool, same canonical
+// arguments) gets the earlier result again with a nudge to stop repeating
+// it; a call over its tool's cap, or made after the turn budget is spent,
+// gets a result saying so. Refused calls are placed in
+// state.ToolCallOverrides for tools.GuardCalls. Returns how many were refused.
+func screenToolCalls(state *model.AppState, msg *schema.Message, budget ToolBudget, now time.Time) int {
+	if msg == nil || len(msg.ToolCalls) == 0 {
+		return 0
+	}
+	if state.ToolLoopStartedAt.IsZero() {
+		state.ToolLoopStartedAt = now
+	}
+	if state.ToolCallsByTool == nil {
+		state.ToolCallsByTool = map[string]int{}
+	}
+	state.ToolCallKeys = make([]string, len(msg.ToolCalls))
+	if state.ToolCallOverrides == nil {
+		state.ToolCallOverrides = map[string][]string{}
+	}
+
+	outOfTime := budget.outOfTime(state, now)
+	pending := map[string]bool{} // keys of the calls of msg that run
+	refused := 0
+	for i, tc := range msg.ToolCalls {
+		name := tc.Function.Name
+		key := tools.CallKey(name, tc.Function.Arguments)
+		var result string
+		switch prev, seen := state.ToolResultsByKey[key]; {
+		case seen:
+			result = repeatedCallResult(name, prev)
+		case pending[key]:
+			result = refusedCallResult("duplicate_call", name, "Another call in this message has the same arguments; use its result.")
+		case outOfTime:
+			result = refusedCallResult("turn_budget_exhausted", name, "This turn has no time left for tool calls; answer with the results you have.")
+		case budget.toolCap(name) > 0 && state.ToolCallsByTool[name] >= budget.toolCap(name):
+			result = refusedCallResult("tool_call_limit", name, fmt.Sprintf(
+				"This tool has reached its limit of %d calls this turn; answer with the results you have.", budget.toolCap(name)))
+		default:
+			state.ToolCallsByTool[name]++
+			state.ToolCallKeys[i] = key
+			pending[key] = true
+			continue
+		}
+		ok := tools.OverrideKey(tc.ID, name, tc.Function.Arguments)
+		state.ToolCallOverrides[ok] = append(state.ToolCallOverrides[ok], result)
+		refused++
+	}
+	if refused == len(msg.ToolCalls) {
+		state.StalledToolRounds++
+	} else {
+		state.StalledToolRounds = 0
+	}
+	return refused
+}
+
+// recordToolResults keeps the successful results of the calls
+// screenToolCalls let run, so later identical calls of the query can be
+// answered with them. Results are matched to calls by position, as the tools
+// node returns them; call IDs may repeat within a message. Error results
+// (tool_failed, temporarily_unavailable, ...) are not kept: replaying them
+// would present a failure as an answer.
+func recordToolResults(state *model.AppState, in []*schema.Message) {
+	keys := state.ToolCallKeys
+	state.ToolCallKeys = nil
+	for i, msg := range in {
+		if i >= len(keys) || keys[i] == "" || msg == nil || msg.Role != schema.Tool {
+			continue
+		}
+		key := keys[i]
+		if tools.IsErrorResult(msg.Content) {
+			continue
+		}
+		if state.ToolResultsByKey == nil {
+			state.ToolResultsByKey = map[string]string{}
+		}
+		state.ToolResultsByKey[key] = msg.Content
+	}
+}
+
+// repeatedCallResult is the stand-in result of a call the query already made.
+func repeatedCallResult(name, prev string) string {
+	var result any = prev
+	if json.Valid([]byte(prev)) {
+		result = json.RawMessage(prev)
+	}
+	b, err := json.Marshal(map[string]any{
+		"duplicate_call": true,
+		"tool":           name,
+		"hint":           "You already called this tool with the same arguments this turn; this is that result again. Do not repeat the call: answer with it or try different arguments.",
+		"result":         result,
+	})
+	if err != nil {
+		return prev
+	}
+	return string(b)
+}
+
+// refusedCallResult is the stand-in result of a call that was not run.
+func refusedCallResult(reason, name, hint string) string {
+	b, err := json.Marshal(map[string]any{"error": reason, "tool": name, "hint": hint})
+	if err != nil {
+		return fmt.Sprintf(`{"error":%q,"tool":%q}`, reason, name)
+	}
+	return string(b)
+}
+
+// fitToolResults keeps the tool results in history within budget estimated
+// tokens (0 = unlimited) by replacing the oldest ones with a short note; the
+// newest `fresh` messages are the results the model has not seen yet and are
+// kept. Returns how many results were replaced.
+func fitToolResults(history []*schema.Message, budget, fresh int) int {
+	if budget <= 0 {
+		return 0
+	}
+	total := 0
+	for _, msg := range history {
+		if msg != nil && msg.Role == schema.Tool {
+			total += tools.EstimateTokens(msg.Content)
+		}
+	}
+	dropped := 0
+	for i := 0; i < len(history)-fresh && total > budget; i++ {
+		msg := history[i]
+		if msg == nil || msg.Role != schema.Tool {
+			continue
+		}
+		if _, done := msg.Extra[extraResultDropped]; done {
+			continue
+		}
+		name := msg.ToolName
+		if name == "" {
+			name = "tool"
+		}
+		stub := *msg // the message may be shared with callbacks; replace, don't mutate
+		stub.Content = fmt.Sprintf(`{"note":"earlier %s result dropped to save context; call the tool again if it is still needed"}`, name)
+		stub.Extra = map[string]any{extraResultDropped: true}
+		total -= tools.EstimateTokens(msg.Content) - tools.EstimateTokens(stub.Content)
+		history[i] = &stub
+		dropped++
+	}
+	return dropped
+}
+
+// extraResultDropped marks a tool message whose result fitToolResults replaced.
+const extraResultDropped = "result_dropped"
+
+// consumeApprovals removes used[key] occurrences of each key from approved,
+// so an approval lets exactly one call through.
+func consumeApprovals(approved []string, used map[string]int) []string {
+	out := approved[:0]
+	for _, key := range approved {
+		if used[key] > 0 {
+			used[key]--
+			continue
+		}
+		out = append(out, key)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package nodes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+func toolCallMessage(ids ...string) *schema.Message {
+	msg := &schema.Message{Role: schema.Assistant}
+	for _, id := range ids {
+		msg.ToolCalls = append(msg.ToolCalls, schema.ToolCall{
+			ID:       id,
+			Function: schema.FunctionCall{Name: "search_product", Arguments: `{"query":"iphone"}`},
+		})
+	}
+	return msg
+}
+
+func TestRecordToolResultsReplaysOnlySuccesses(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   string
+		replayed bool
+	}{
+		{"success", `{"products":[{"id":"p1"}],"total":1}`, true},
+		{"plain text", `no products found`, true},
+		{"failed call", `{"error":"tool_failed","tool":"search_product","detail":"status 503"}`, false},
+		{"open breaker", `{"error":"temporarily_unavailable","tool":"search_product"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &model.AppState{}
+			now := time.Now()
+			budget := ToolBudget{MaxCalls: 10}
+
+			if refused := screenToolCalls(state, toolCallMessage("c1"), budget, now); refused != 0 {
+				t.Fatalf("first call refused")
+			}
+			recordToolResults(state, []*schema.Message{schema.ToolMessage(tt.result, "c1")})
+			if len(state.ToolCallKeys) != 0 {
+				t.Errorf("ToolCallKeys = %v, want the answered call removed", state.ToolCallKeys)
+			}
+
+			refused := screenToolCalls(state, toolCallMessage("c2"), budget, now)
+			overrides, overridden := state.ToolCallOverrides[tools.OverrideKey("c2", "search_product", `{"query":"iphone"}`)]
+			override := strings.Join(overrides, "")
+			if tt.replayed {
+				if refused != 1 || !overridden || !strings.Contains(override, `"duplicate_call":true`) || !strings.Contains(override, tt.result[:5]) {
+					t.Errorf("repeat = %d refused, override %q; want the earlier result replayed", refused, override)
+				}
+				return
+			}
+			if refused != 0 || overridden {
+				t.Errorf("repeat of a failed call = %d refused, override %q; want it to run again", refused, override)
+			}
+		})
+	}
+}
+
+func TestToolCallsSharingAnID(t *testing.T) {
+	// Gemini sets every call ID to the tool name
+	call := func(args string) schema.ToolCall {
+		return schema.ToolCall{ID: "search_product", Function: schema.FunctionCall{Name: "search_product", Arguments: args}}
+	}
+	msg := &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{
+		call(`{"query":"iphone"}`),
+		call(`{"query":"ipad"}`),
+		call(`{"query": "iphone"}`), // same call as the first
+		call(`{"query":"macbook"}`), // over the cap of 2
+	}}
+	state := &model.AppState{}
+	budget := ToolBudget{MaxCalls: 10, MaxCallsPerTool: 2}
+	if refused := screenToolCalls(state, msg, budget, time.Now()); refused != 2 {
+		t.Fatalf("refused = %d, want the repeat and the call over the cap", refused)
+	}
+
+	override := func(args string) string {
+		return strings.Join(state.ToolCallOverrides[tools.OverrideKey("search_product", "search_product", args)], "")
+	}
+	if got := override(`{"query":"ipad"}`); got != "" {
+		t.Errorf("ipad call refused with %q, want it to run", got)
+	}
+	if got := override(`{"query":"iphone"}`); !strings.Contains(got, "duplicate_call") {
+		t.Errorf("iphone override = %q, want one duplicate_call stand-in for the repeat", got)
+	}
+	if got := override(`{"query":"macbook"}`); !strings.Contains(got, "tool_call_limit") {
+		t.Errorf("macbook override = %q, want a tool_call_limit stand-in", got)
+	}
+
+	recordToolResults(state, []*schema.Message{
+		schema.ToolMessage(`{"products":["iphone"]}`, "search_product"),
+		schema.ToolMessage(`{"products":["ipad"]}`, "search_product"),
+		schema.ToolMessage(override(`{"query":"iphone"}`), "search_product"),
+		schema.ToolMessage(override(`{"query":"macbook"}`), "search_product"),
+	})
+	for args, want := range map[string]string{
+		`{"query":"iphone"}`: `{"products":["iphone"]}`,
+		`{"query":"ipad"}`:   `{"products":["ipad"]}`,
+	} {
+		if got := state.ToolResultsByKey[tools.CallKey("search_product", args)]; got != want {
+			t.Errorf("recorded result for %s = %q, want %q", args, got, want)
+		}
+	}
+	if got, ok := state.ToolResultsByKey[tools.CallKey("search_product", `{"query":"macbook"}`)]; ok {
+		t.Errorf("refused macbook call recorded %q", got)
+	}
+}
@@ -21,6 +21,12 @@ func newToolHandler() *callbackHelper.ToolCallbackHandler {
 			return ctx
 		},
 		OnEnd: func(ctx context.Context, info *einocb.RunInfo, output *tool.CallbackOutput) context.Context {
+			// Extra carries call notes such as cache_hit, tool_failed and the
+			// full_output of a shaped result
+			if len(output.Extra) > 0 {
+				fmt.Printf("[TOOL END] %s extra=%v output=%+v", info.Name, output.Extra, output.Response)
+				return ctx
+			}
 			fmt.Printf("[TOOL END] %s output=%+v", info.Name, output.Response)
 			return ctx
 		},
@@ -0,0 +1,548 @@
+package parsers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// Entity types (as configured in NLU_*_ENTITY) handled by the normalizer.
+const (
+	entityPrice    = "price"
+	entityBudget   = "budget"
+	entityQuantity = "quantity"
+	entityColor    = "color"
+	entityBrand    = "brand"
+	entityProduct  = "product"
+	entityUseCase  = "use_case"
+)
+
+// defaultCurrency is assumed when a money span carries no currency marker.
+const defaultCurrency = "THB"
+
+// NormalizeEntities converts raw entity spans into typed canonical values and
+// stores them under Entity.Metadata["normalized"]. Entities that cannot be
+// normalized are left untouched; the raw Value is never modified.
+func NormalizeEntities(resp *model.NLUResponse) {
+	if resp == nil {
+		return
+	}
+	normalized := 0
+	for i := range resp.Entities {
+		e := &resp.Entities[i]
+		nv, ok := NormalizeEntity(e.Type, e.Value)
+		if !ok {
+			continue
+		}
+		if e.Metadata == nil {
+			e.Metadata = map[string]any{}
+		}
+		e.Metadata[model.NormalizedMetaKey] = nv
+		normalized++
+	}
+	if normalized > 0 {
+		if resp.ParsingMetadata == nil {
+			resp.ParsingMetadata = map[string]any{}
+		}
+		resp.ParsingMetadata["normalized_entities"] = normalized
+	}
+}
+
+// NormalizeEntity returns the canonical value for a single entity span.
+func NormalizeEntity(entityType, value string) (model.NormalizedValue, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return model.NormalizedValue{}, false
+	}
+	switch strings.ToLower(strings.TrimSpace(entityType)) {
+	case entityPrice:
+		return normalizeMoney(value, "exact")
+	case entityBudget:
+		// a budget is a ceiling unless the span says otherwise
+		return normalizeMoney(value, "max")
+	case entityQuantity:
+		return normalizeQuantity(value)
+	case entityColor:
+		return normalizeColor(value)
+	case entityBrand:
+		return normalizeBrand(value)
+	case entityProduct:
+		return normalizeProductType(value)
+	case entityUseCase:
+		return normalizeUseCase(value)
+	}
+	return model.NormalizedValue{}, false
+}
+
+// ===== money =====
+
+var currencyMarkers = []struct {
+	marker   string
+	currency string
+}{
+	{"บาท", "THB"},
+	{"฿", "THB"},
+	{"thb", "THB"},
+	{"baht", "THB"},
+	{"ดอลลาร์", "USD"},
+	{"usd", "USD"},
+	{"$", "USD"},
+}
+
+var boundMarkers = []struct {
+	marker string
+	bound  string
+}{
+	{"ไม่เกิน", "max"},
+	{"ต่ำกว่า", "max"},
+	{"น้อยกว่า", "max"},
+	{"under", "max"},
+	{"below", "max"},
+	{"up to", "max"},
+	{"less than", "max"},
+	{"max", "max"},
+	{"ขึ้นไป", "min"},
+	{"มากกว่า", "min"},
+	{"อย่างน้อย", "min"},
+	{"at least", "min"},
+	{"over", "min"},
+	{"above", "min"},
+	{"ประมาณ", "approx"},
+	{"ราวๆ", "approx"},
+	{"ราว", "approx"},
+	{"around", "approx"},
+	{"about", "approx"},
+}
+
+var rangeSeparators = []string{" - ", "-", "–", "ถึง", " to "}
+
+func normalizeMoney(value, defaultBound string) (model.NormalizedValue, bool) {
+	lower := strings.ToLower(value)
+	nv := model.NormalizedValue{Kind: "money", Currency: defaultCurrency, Bound: defaultBound}
+	for _, cm := range currencyMarkers {
+		if strings.Contains(lower, cm.marker) {
+			nv.Currency = cm.currency
+			break
+		}
+	}
+	for _, bm := range boundMarkers {
+		if strings.Contains(lower, bm.marker) {
+			nv.Bound = bm.bound
+			break
+		}
+	}
+
+	// ranges such as "30,000-40,000" or "3 ถึง 4 หมื่น"
+	for _, sep := range rangeSeparators {
+		left, right, found := strings.Cut(lower, sep)
+		if !found {
+			continue
+		}
+		hi, okHi := ParseAmount(right)
+		lo, okLo := ParseAmount(left)
+		if !okHi || !okLo {
+			continue
+		}
+		// "3-4 หมื่น": the multiplier applies to both ends
+		if lo < hi && lo*10 < hi && !hasMultiplier(left) {
+			if scale := hi / trailingNumber(right); scale > 1 {
+				lo *= scale
+			}
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		nv.Amount, nv.MinAmount, nv.Bound = hi, lo, "range"
+		return nv, hi > 0
+	}
+
+	amount, ok := ParseAmount(lower)
+	if !ok || amount <= 0 {
+		return model.NormalizedValue{}, false
+	}
+	nv.Amount = amount
+	return nv, true
+}
+
+// ===== quantity =====
+
+var quantityUnits = []string{
+	"เครื่อง", "ชิ้น", "อัน", "ตัว", "กล่อง", "ชุด", "คู่", "เรือน", "ด้าม",
+	"pieces", "piece", "pcs", "units", "unit", "items", "item", "sets", "set", "pairs", "pair",
+}
+
+func normalizeQuantity(value string) (model.NormalizedValue, bool) {
+	lower := strings.ToLower(value)
+	// "x2" / "2x"
+	trimmed := strings.Trim(strings.TrimSpace(lower), "x×")
+	n, ok := ParseAmount(trimmed)
+	if !ok {
+		n, ok = parseEnglishCount(trimmed)
+	}
+	if !ok || n <= 0 {
+		return model.NormalizedValue{}, false
+	}
+	nv := model.NormalizedValue{Kind: "quantity", Amount: n}
+	for _, u := range quantityUnits {
+		if strings.Contains(lower, u) {
+			nv.Unit = u
+			break
+		}
+	}
+	return nv, true
+}
+
+var englishCounts = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"pair": 2, "couple": 2, "dozen": 12,
+}
+
+// parseEnglishCount reads counts like "two", "a couple" or "a pair of". An
+// article counts as one only when no count word follows it ("a case").
+func parseEnglishCount(s string) (float64, bool) {
+	article := false
+	for _, w := range strings.Fields(s) {
+		if n, ok := englishCounts[w]; ok {
+			return n, true
+		}
+		if w == "a" || w == "an" {
+			article = true
+		}
+	}
+	if article {
+		return 1, true
+	}
+	return 0, false
+}
+
+// ===== color =====
+
+// colorAliases maps Thai/English color words to a canonical English name.
+// Longer aliases are matched first so "น้ำเงิน" wins over "เงิน".
+var colorAliases = map[string]string{
+	"ดำ": "black", "black": "black",
+	"ขาว": "white", "white": "white",
+	"เงิน": "silver", "silver": "silver",
+	"ทอง": "gold", "gold": "gold",
+	"เทา": "gray", "gray": "gray", "grey": "gray",
+	"น้ำเงิน": "blue", "ฟ้า": "blue", "blue": "blue", "navy": "blue",
+	"แดง": "red", "red": "red",
+	"เขียว": "green", "green": "green",
+	"ชมพู": "pink", "pink": "pink",
+	"ม่วง": "purple", "purple": "purple", "violet": "purple",
+	"เหลือง": "yellow", "yellow": "yellow",
+	"ส้ม": "orange", "orange": "orange",
+	"ไทเทเนียม": "titanium", "titanium": "titanium",
+	"midnight": "midnight", "starlight": "starlight",
+}
+
+func normalizeColor(value string) (model.NormalizedValue, bool) {
+	lower := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "สี"))
+	if canon, ok := longestAlias(lower, colorAliases); ok {
+		return model.NormalizedValue{Kind: "color", Text: canon}, true
+	}
+	return model.NormalizedValue{}, false
+}
+
+// ===== brand =====
+
+// brandAliases maps brand spellings, Thai transliterations and flagship
+// product lines to the canonical brand name used by the catalog.
+var brandAliases = map[string]string{
+	"apple": "Apple", "แอปเปิ้ล": "Apple", "แอปเปิล": "Apple",
+	"iphone": "Apple", "ไอโฟน": "Apple", "ipad": "Apple", "ไอแพด": "Apple",
+	"macbook": "Apple", "แมคบุ๊ค": "Apple", "แมคบุ๊ก": "Apple", "airpods": "Apple",
+	"samsung": "Samsung", "ซัมซุง": "Samsung", "galaxy": "Samsung", "กาแล็กซี่": "Samsung",
+	"acer": "Acer", "เอเซอร์": "Acer", "เอเซอ": "Acer",
+	"lenovo": "Lenovo", "เลอโนโว": "Lenovo", "เลโนโว": "Lenovo", "ideapad": "Lenovo",
+	"hp": "HP", "เอชพี": "HP", "hewlett-packard": "HP", "pavilion": "HP",
+	"asus": "ASUS", "อัสซุส": "ASUS", "เอซุส": "ASUS", "vivobook": "ASUS",
+	"dell": "Dell", "เดล": "Dell", "xps": "Dell",
+	"sony": "Sony", "โซนี่": "Sony", "โซนี": "Sony",
+}
+
+func normalizeBrand(value string) (model.NormalizedValue, bool) {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	if canon, ok := brandAliases[lower]; ok {
+		return model.NormalizedValue{Kind: "brand", Text: canon}, true
+	}
+	if canon, ok := longestAlias(lower, brandAliases); ok {
+		return model.NormalizedValue{Kind: "brand", Text: canon}, true
+	}
+	return model.NormalizedValue{}, false
+}
+
+// ===== product type / use case =====
+
+// productTypeAliases maps product words to catalog categories.
+var productTypeAliases = map[string]string{
+	"คอม": "laptops", "คอมพิวเตอร์": "laptops", "โน้ตบุ๊ค": "laptops", "โน้ตบุ๊ก": "laptops", "โน๊ตบุ๊ค": "laptops",
+	"แล็ปท็อป": "laptops", "แลปท็อป": "laptops", "laptop": "laptops", "laptops": "laptops", "notebook": "laptops",
+	"computer": "laptops", "macbook": "laptops", "ultrabook": "laptops",
+	"มือถือ": "smartphones", "โทรศัพท์": "smartphones", "สมาร์ทโฟน": "smartphones", "ไอโฟน": "smartphones",
+	"smartphone": "smartphones", "smartphones": "smartphones", "phone": "smartphones", "iphone": "smartphones",
+	"แท็บเล็ต": "tablets", "แทบเล็ต": "tablets", "ไอแพด": "tablets", "tablet": "tablets", "tablets": "tablets", "ipad": "tablets",
+	"หูฟัง": "audio", "ลำโพง": "audio", "headphone": "audio", "headphones": "audio", "earbuds": "audio", "airpods": "audio", "audio": "audio",
+	"นาฬิกา": "wearables", "สมาร์ทวอช": "wearables", "smartwatch": "wearables", "watch": "wearables", "wearables": "wearables",
+}
+
+func normalizeProductType(value string) (model.NormalizedValue, bool) {
+	if canon, ok := longestAlias(strings.ToLower(value), productTypeAliases); ok {
+		return model.NormalizedValue{Kind: "product_type", Text: canon}, true
+	}
+	return model.NormalizedValue{}, false
+}
+
+// useCaseAliases maps usage descriptions to a small canonical vocabulary.
+var useCaseAliases = map[string]string{
+	"เล่นเกม": "gaming", "เกม": "gaming", "เกมมิ่ง": "gaming", "gaming": "gaming", "game": "gaming", "games": "gaming",
+	"ทำงาน": "work", "งานทั่วไป": "work", "งานออฟฟิศ": "work", "office": "work", "work": "work", "business": "work",
+	"เรียน": "study", "นักเรียน": "study", "นักศึกษา": "study", "study": "study", "student": "study", "school": "study",
+	"ตัดต่อ": "creative", "กราฟิก": "creative", "ออกแบบ": "creative", "editing": "creative", "design": "creative", "creative": "creative",
+	"ถ่ายรูป": "photography", "กล้อง": "photography", "photo": "photography", "photography": "photography",
+	"ดูหนัง": "entertainment", "ฟังเพลง": "entertainment", "movies": "entertainment", "music": "entertainment",
+}
+
+func normalizeUseCase(value string) (model.NormalizedValue, bool) {
+	if canon, ok := longestAlias(strings.ToLower(value), useCaseAliases); ok {
+		return model.NormalizedValue{Kind: "use_case", Text: canon}, true
+	}
+	return model.NormalizedValue{}, false
+}
+
+// longestAlias returns the canonical value of the longest alias contained in s.
+// ASCII aliases must match on word boundaries so "hp" does not hit "iphone".
+func longestAlias(s string, aliases map[string]string) (string, bool) {
+	keys := make([]string, 0, len(aliases))
+	for k := range aliases {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	for _, k := range keys {
+		if isASCII(k) {
+			if containsWord(s, k) {
+				return aliases[k], true
+			}
+			continue
+		}
+		if strings.Contains(s, k) {
+			return aliases[k], true
+		}
+	}
+	return "", false
+}
+
+func containsWord(s, word string) bool {
+	for start := 0; ; {
+		idx := strings.Index(s[start:], word)
+		if idx < 0 {
+			return false
+		}
+		idx += start
+		end := idx + len(word)
+		beforeOK := idx == 0 || !isASCIIAlnum(s[idx-1])
+		afterOK := end == len(s) || !isASCIIAlnum(s[end])
+		if beforeOK && afterOK {
+			return true
+		}
+		start = idx + 1
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIIAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// ===== numbers =====
+
+var thaiDigitWords = []struct {
+	word  string
+	value float64
+}{
+	{"ศูนย์", 0}, {"หนึ่ง", 1}, {"เอ็ด", 1}, {"สอง", 2}, {"ยี่", 2}, {"สาม", 3},
+	{"สี่", 4}, {"ห้า", 5}, {"หก", 6}, {"เจ็ด", 7}, {"แปด", 8}, {"เก้า", 9},
+}
+
+var multiplierWords = []struct {
+	word  string
+	value float64
+}{
+	{"สิบ", 10}, {"ร้อย", 100}, {"พัน", 1_000}, {"หมื่น", 10_000}, {"แสน", 100_000}, {"ล้าน", 1_000_000},
+	{"thousand", 1_000}, {"million", 1_000_000}, {"mil", 1_000_000}, {"k", 1_000}, {"m", 1_000_000},
+}
+
+// ParseAmount extracts a number from free text mixing Arabic or Thai numerals,
+// Thai number words and multipliers, e.g. "40,000 บาท", "๔๐,๐๐๐", "4 หมื่น",
+// "สี่หมื่นห้าพัน", "1.5 แสน" and "40k". Unrecognized text is skipped.
+func ParseAmount(s string) (float64, bool) {
+	s = thaiDigitsToASCII(strings.ToLower(s))
+
+	var (
+		result     float64 // completed millions
+		section    float64 // value below the current million
+		digit      = -1.0  // pending number waiting for a multiplier
+		lastMult   float64
+		found      bool
+		afterDigit bool // multiplier ASCII words only count right after a number
+	)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == ',' || s[j] == '.') {
+				j++
+			}
+			raw := strings.TrimRight(strings.ReplaceAll(s[i:j], ",", ""), ".")
+			if v, err := strconv.ParseFloat(raw, 64); err == nil {
+				if digit >= 0 {
+					section += digit
+				}
+				digit = v
+				found = true
+				afterDigit = true
+			}
+			i = j
+			continue
+		case isASCIIAlnum(c):
+			j := i
+			for j < len(s) && isASCIIAlnum(s[j]) {
+				j++
+			}
+			word := s[i:j]
+			if afterDigit {
+				for _, m := range multiplierWords {
+					if word == m.word {
+						section, digit, result = applyMultiplier(section, digit, result, m.value)
+						lastMult = m.value
+						break
+					}
+				}
+			}
+			afterDigit = false
+			i = j
+			continue
+		}
+
+		matched := false
+		for _, d := range thaiDigitWords {
+			if strings.HasPrefix(s[i:], d.word) {
+				if digit >= 0 {
+					section += digit
+				}
+				digit = d.value
+				found = true
+				afterDigit = true
+				i += len(d.word)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		for _, m := range multiplierWords {
+			if !isASCII(m.word) && strings.HasPrefix(s[i:], m.word) {
+				section, digit, result = applyMultiplier(section, digit, result, m.value)
+				lastMult = m.value
+				found = true
+				afterDigit = true
+				i += len(m.word)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if c != ' ' {
+			afterDigit = false
+		}
+		i++
+	}
+
+	if digit >= 0 {
+		// colloquial Thai: "หมื่นห้า" = 15,000, "4 หมื่น 5" = 45,000
+		if lastMult >= 1_000 && digit < 10 {
+			section += digit * lastMult / 10
+		} else {
+			section += digit
+		}
+	}
+	if !found {
+		return 0, false
+	}
+	return result + section, true
+}
+
+func applyMultiplier(section, digit, result, mult float64) (float64, float64, float64) {
+	if digit < 0 {
+		digit = 1
+	}
+	if mult >= 1_000_000 {
+		result += (section + digit) * mult
+		return 0, -1, result
+	}
+	return section + digit*mult, -1, result
+}
+
+// hasMultiplier reports whether s contains a Thai or English multiplier word.
+func hasMultiplier(s string) bool {
+	for _, m := range multiplierWords {
+		if isASCII(m.word) {
+			if containsWord(s, m.word) || strings.Contains(s, "0"+m.word) {
+				return true
+			}
+			continue
+		}
+		if strings.Contains(s, m.word) {
+			return true
+		}
+	}
+	return false
+}
+
+// trailingNumber returns the bare numeral in s (before any multiplier), or 1.
+func trailingNumber(s string) float64 {
+	s = thaiDigitsToASCII(s)
+	start := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if start < 0 {
+		return 1
+	}
+	end := start
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	v, err := strconv.ParseFloat(s[start:end], 64)
+	if err != nil || v == 0 {
+		return 1
+	}
+	return v
+}
+
+func thaiDigitsToASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '๐' && r <= '๙' {
+			return '0' + (r - '๐')
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,35 @@
+package parsers
+
+import "testing"
+
+func TestNormalizeQuantity(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		unit  string
+	}{
+		{"2 เครื่อง", 2, "เครื่อง"},
+		{"x3", 3, ""},
+		{"two units", 2, "units"},
+		{"a couple", 2, ""},
+		{"a pair of airpods", 2, "pair"},
+		{"a dozen cables", 12, ""},
+		{"an iPad", 1, ""},
+		{"a case", 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := NormalizeEntity("quantity", tt.value)
+			if !ok {
+				t.Fatalf("NormalizeEntity(quantity, %q) failed", tt.value)
+			}
+			if got.Amount != tt.want || got.Unit != tt.unit {
+				t.Errorf("NormalizeEntity(quantity, %q) = %v %q, want %v %q", tt.value, got.Amount, got.Unit, tt.want, tt.unit)
+			}
+		})
+	}
+
+	if got, ok := NormalizeEntity("quantity", "some"); ok {
+		t.Errorf("NormalizeEntity(quantity, some) = %+v, want no match", got)
+	}
+}
@@ -0,0 +1,258 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// nluJSON mirrors the response schema requested from the model in JSON mode.
+// Fields are explicit (no free-form metadata objects) because Gemini response
+// schemas cannot express open maps.
+type nluJSON struct {
+	Intents []struct {
+		Name          string  `json:"name"`
+		Confidence    float64 `json:"confidence"`
+		Priority      float64 `json:"priority"`
+		ExtractedFrom string  `json:"extracted_from"`
+		ClosestMatch  bool    `json:"closest_match"`
+	} `json:"intents"`
+	Entities []struct {
+		Type       string  `json:"type"`
+		Value      string  `json:"value"`
+		Confidence float64 `json:"confidence"`
+		Start      *int    `json:"start"`
+		End        *int    `json:"end"`
+		Category   string  `json:"category"`
+	} `json:"entities"`
+	Languages []struct {
+		Code           string  `json:"code"`
+		Confidence     float64 `json:"confidence"`
+		IsPrimary      bool    `json:"is_primary"`
+		Script         string  `json:"script"`
+		DetectedTokens *int    `json:"detected_tokens"`
+	} `json:"languages"`
+	Sentiment *struct {
+		Label        string   `json:"label"`
+		Confidence   float64  `json:"confidence"`
+		Polarity     *float64 `json:"polarity"`
+		Subjectivity *float64 `json:"subjectivity"`
+	} `json:"sentiment"`
+}
+
+// NLUResponseSchema returns the OpenAPI schema passed to Gemini as the
+// response schema in JSON mode. It maps 1:1 onto model.NLUResponse.
+func NLUResponseSchema() *openapi3.Schema {
+	unit := func() *openapi3.Schema { return openapi3.NewFloat64Schema().WithMin(0).WithMax(1) }
+
+	intent := openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"name":           openapi3.NewStringSchema(),
+		"confidence":     unit(),
+		"priority":       unit(),
+		"extracted_from": openapi3.NewStringSchema().WithEnum("default", "additional"),
+		"closest_match":  openapi3.NewBoolSchema(),
+	})
+	intent.Required = []string{"name", "confidence", "priority"}
+
+	entity := openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"type":       openapi3.NewStringSchema(),
+		"value":      openapi3.NewStringSchema(),
+		"confidence": unit(),
+		"start":      openapi3.NewIntegerSchema(),
+		"end":        openapi3.NewIntegerSchema(),
+		"category":   openapi3.NewStringSchema(),
+	})
+	entity.Required = []string{"type", "value", "confidence"}
+
+	language := openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"code":            openapi3.NewStringSchema(),
+		"confidence":      unit(),
+		"is_primary":      openapi3.NewBoolSchema(),
+		"script":          openapi3.NewStringSchema(),
+		"detected_tokens": openapi3.NewIntegerSchema(),
+	})
+	language.Required = []string{"code", "confidence", "is_primary"}
+
+	sentiment := openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"label":        openapi3.NewStringSchema().WithEnum("positive", "neutral", "negative"),
+		"confidence":   unit(),
+		"polarity":     openapi3.NewFloat64Schema().WithMin(-1).WithMax(1),
+		"subjectivity": unit(),
+	})
+	sentiment.Required = []string{"label", "confidence"}
+
+	root := openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"intents":   openapi3.NewArraySchema().WithItems(intent),
+		"entities":  openapi3.NewArraySchema().WithItems(entity),
+		"languages": openapi3.NewArraySchema().WithItems(language),
+		"sentiment": sentiment,
+	})
+	root.Required = []string{"intents", "entities", "languages", "sentiment"}
+	return root
+}
+
+// ParseNLU parses model output according to the configured NLU mode. In JSON
+// mode, content that is not a valid JSON object falls back to the tuple parser
+// so a drifting model still yields a best-effort result.
+func ParseNLU(content string, mode string) (*model.NLUResponse, error) {
+	if mode != model.NLUModeJSON {
+		return ParseNLUResponse(content)
+	}
+	resp, err := ParseNLUJSONResponse(content)
+	if err == nil {
+		return resp, nil
+	}
+	logx.Warn().
+		Str("component", "nlu_parser").
+		Err(err).
+		Msg("json nlu output invalid; falling back to tuple parser")
+	resp, terr := ParseNLUResponse(content)
+	if terr != nil {
+		return nil, terr
+	}
+	resp.Metadata["parser"] = "tuple_fallback"
+	resp.ParsingMetadata["json_error"] = safeSnippet(err.Error())
+	return resp, nil
+}
+
+// ParseNLUJSONResponse maps a schema-constrained JSON object onto
+// model.NLUResponse, applying the same validation rules as the tuple parser.
+// Invalid items are skipped and recorded in ParsingMetadata["parsing_errors"].
+func ParseNLUJSONResponse(content string) (resp *model.NLUResponse, err error) {
+	// panic safety
+	defer func() {
+		if r := recover(); r != nil {
+			logx.Error().Str("component", "nlu_parser").Msgf("panic recovered: %v", r)
+			err = errx.New(fmt.Errorf("nlu json parser panic"), http.StatusInternalServerError, errx.SystemErrorMessage)
+			resp = nil
+		}
+	}()
+
+	if len(content) > maxContentLen {
+		return nil, fmt.Errorf("json content too large")
+	}
+	content = strings.TrimSpace(content)
+	// tolerate fenced output (```json ... ```)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "{") {
+		return nil, fmt.Errorf("content is not a json object")
+	}
+
+	var raw nluJSON
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("decode nlu json: %w", err)
+	}
+
+	resp = &model.NLUResponse{
+		Intents:         []model.Intent{},
+		Entities:        []model.Entity{},
+		Languages:       []model.Language{},
+		Metadata:        map[string]any{"parser": "json"},
+		ParsingMetadata: map[string]any{},
+		Timestamp:       time.Now().UTC(),
+	}
+	addErr := func(msg string) {
+		v, _ := resp.ParsingMetadata["parsing_errors"].([]string)
+		resp.ParsingMetadata["parsing_errors"] = append(v, msg)
+	}
+	inUnit := func(v float64) bool { return !math.IsNaN(v) && v >= 0 && v <= 1 }
+
+	for _, it := range raw.Intents {
+		name := strings.TrimSpace(it.Name)
+		if name == "" || mustValidUTF8(name, "intent.name") != nil {
+			addErr("intent: invalid name")
+			continue
+		}
+		if !inUnit(it.Confidence) || !inUnit(it.Priority) {
+			addErr("intent: invalid confidence")
+			continue
+		}
+		meta := map[string]any{}
+		if it.ExtractedFrom != "" {
+			meta["extracted_from"] = it.ExtractedFrom
+		}
+		if it.ClosestMatch {
+			meta["closest_match"] = true
+		}
+		resp.Intents = append(resp.Intents, model.Intent{Name: name, Confidence: it.Confidence, Priority: it.Priority, Metadata: meta})
+	}
+
+	for _, en := range raw.Entities {
+		etype := strings.TrimSpace(en.Type)
+		val := strings.TrimSpace(en.Value)
+		if etype == "" || val == "" || mustValidUTF8(etype, "entity.type") != nil || mustValidUTF8(val, "entity.value") != nil {
+			addErr("entity: invalid type or value")
+			continue
+		}
+		if !inUnit(en.Confidence) {
+			addErr("entity: invalid confidence")
+			continue
+		}
+		e := model.Entity{Type: etype, Value: val, Confidence: en.Confidence, Metadata: map[string]any{}}
+		if en.Start != nil && en.End != nil && *en.Start >= 0 && *en.Start <= *en.End {
+			e.Position = []int{*en.Start, *en.End}
+			e.Metadata["entity_position"] = []any{float64(*en.Start), float64(*en.End)}
+		}
+		if en.Category != "" {
+			e.Metadata["entity_category"] = en.Category
+		}
+		resp.Entities = append(resp.Entities, e)
+	}
+
+	for _, l := range raw.Languages {
+		code := strings.ToLower(strings.TrimSpace(l.Code))
+		if !isISO639_3(code) {
+			addErr("language: invalid code")
+			continue
+		}
+		if !inUnit(l.Confidence) {
+			addErr("language: invalid confidence")
+			continue
+		}
+		meta := map[string]any{}
+		if l.Script != "" {
+			meta["script"] = l.Script
+		}
+		if l.DetectedTokens != nil && *l.DetectedTokens >= 0 {
+			meta["detected_tokens"] = float64(*l.DetectedTokens)
+		}
+		resp.Languages = append(resp.Languages, model.Language{Code: code, Confidence: l.Confidence, IsPrimary: l.IsPrimary, Metadata: meta})
+	}
+
+	if s := raw.Sentiment; s != nil {
+		label := strings.TrimSpace(s.Label)
+		switch {
+		case label == "":
+			addErr("sentiment: invalid label")
+		case !inUnit(s.Confidence):
+			addErr("sentiment: invalid confidence")
+		default:
+			meta := map[string]any{}
+			if s.Polarity != nil {
+				meta["polarity"] = *s.Polarity
+			}
+			if s.Subjectivity != nil {
+				meta["subjectivity"] = *s.Subjectivity
+			}
+			sanitizeSentimentMeta(meta)
+			resp.Sentiment = model.Sentiment{Label: label, Confidence: s.Confidence, Metadata: meta}
+		}
+	} else {
+		addErr("sentiment: missing")
+	}
+
+	deriveNLUFields(resp)
+	return resp, nil
+}
@@ -308,7 +308,16 @@ func ParseNLUResponse(content string) (resp *model.NLUResponse, err error) {
 		}
 	}
 
-	// Derived fields
+	deriveNLUFields(resp)
+
+	return resp, nil
+}
+
+// --- helpers ---
+
+// deriveNLUFields fills PrimaryIntent, PrimaryLanguage and ImportanceScore
+// from the parsed intents and languages.
+func deriveNLUFields(resp *model.NLUResponse) {
 	// PrimaryIntent: highest confidence
 	bestConf := -1.0
 	for _, it := range resp.Intents {
@@ -346,12 +355,8 @@ func ParseNLUResponse(content string) (resp *model.NLUResponse, err error) {
 		}
 		resp.ImportanceScore = conf*0.6 + prio*0.4
 	}
-
-	return resp, nil
 }
 
-// --- helpers ---
-
 func safeSnippet(s string) string {
 	s = strings.TrimSpace(s)
 	if len(s) <= maxErrSnippet {
@@ -15,6 +15,9 @@ import (
 //go:embed template/nlu_prompt.txt
 var nluSystemPrompt string
 
+//go:embed template/nlu_prompt_json.txt
+var nluJSONSystemPrompt string
+
 // RenderNLUSystem renders the NLU system prompt via Eino prompt component.
 // This triggers Prompt callbacks and returns the final system prompt string.
 func RenderNLUSystem(ctx context.Context, nluConfig *model.NLUModelConfig) (string, error) {
@@ -22,6 +25,11 @@ func RenderNLUSystem(ctx context.Context, nluConfig *model.NLUModelConfig) (stri
 		return "", fmt.Errorf("nlu config is nil")
 	}
 
+	template := nluSystemPrompt
+	if nluConfig.Mode == model.NLUModeJSON {
+		template = nluJSONSystemPrompt
+	}
+
 	// Safely render known tokens only to avoid interfering with JSON braces in template
 	content := strings.NewReplacer(
 		"{TD}", "<||>",
@@ -31,7 +39,7 @@ func RenderNLUSystem(ctx context.Context, nluConfig *model.NLUModelConfig) (stri
 		"{additional_intent}", nluConfig.AdditionalIntent,
 		"{default_entity}", nluConfig.DefaultEntity,
 		"{additional_entity}", nluConfig.AdditionalEntity,
-	).Replace(nluSystemPrompt)
+	).Replace(template)
 
 	// Wrap via Eino prompt component using a messages placeholder to emit callbacks
 	tpl := prompt.FromMessages(
@@ -0,0 +1,33 @@
+package prompts
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+//go:embed template/nlu_repair_prompt.txt
+var nluRepairPrompt string
+
+// RenderNLURepair renders the follow-up user message that asks the NLU model to
+// correct an unparseable answer. problems are the parser's error hints.
+func RenderNLURepair(mode string, problems []string) string {
+	var errs strings.Builder
+	if len(problems) == 0 {
+		errs.WriteString("- no valid intent found\n")
+	}
+	for _, p := range problems {
+		errs.WriteString("- " + p + "\n")
+	}
+
+	format := "- Records (intent|entity|language|sentiment) in parentheses, fields separated by <||>, records separated by ##, ending with <|COMPLETE|>."
+	if mode == model.NLUModeJSON {
+		format = "- One JSON object with keys intents, entities, languages, sentiment, matching the response schema; no code fences."
+	}
+
+	return strings.NewReplacer(
+		"{errors}", strings.TrimRight(errs.String(), "\n"),
+		"{format}", format,
+	).Replace(nluRepairPrompt)
+}
@@ -17,7 +17,13 @@ import (
 var coreSystemPrompt string
 
 // RenderResponseSystem renders the dynamic Response system prompt and triggers prompt callbacks.
-func RenderResponseSystem(ctx context.Context, config model.ResponsePromptConfig, nlu model.NLUResponse) (string, error) {
+// enabled lists the tools bound to the response model; only their guidance is rendered.
+// dialogue carries slot values remembered from earlier turns and may be nil.
+// degraded marks an NLU analysis produced by the rule fallback.
+// confirmToolCalls reports whether side-effecting calls wait for the
+// customer's confirmation (CONVERSATION_TOOL_CONFIRM); only then is the model
+// told not to ask for it itself.
+func RenderResponseSystem(ctx context.Context, config model.ResponsePromptConfig, enabled []tools.Registration, confirmToolCalls bool, nlu model.NLUResponse, dialogue *model.DialogueState, degraded bool) (string, error) {
 	// derive and normalize primary language for the template
 	pl := strings.ToLower(strings.TrimSpace(nlu.PrimaryLanguage))
 	if pl == "" {
@@ -36,11 +42,15 @@ func RenderResponseSystem(ctx context.Context, config model.ResponsePromptConfig
 		schema.SystemMessage(coreSystemPrompt),
 	)
 	vars := map[string]any{
-		"BusinessType":    config.BusinessType,
-		"BusinessName":    config.BusinessName,
-		"PrimaryLanguage": pl,
-		"SearchTool":      tools.ToolSearchProduct,
-		"DetailsTool":     tools.ToolGetProductDetails,
+		"BusinessType":      config.BusinessType,
+		"BusinessName":      config.BusinessName,
+		"PrimaryLanguage":   pl,
+		"ToolGuidance":      promptToolGuidance(enabled),
+		"ConfirmsToolCalls": confirmToolCalls && hasSideEffects(enabled),
+		"Entities":          promptEntities(nlu),
+		"Slots":             promptSlots(dialogue),
+		"PendingSlots":      promptPendingSlots(dialogue),
+		"Degraded":          degraded,
 	}
 	msgs, err := tpl.Format(ctx, vars)
 	if err != nil {
@@ -51,3 +61,85 @@ func RenderResponseSystem(ctx context.Context, config model.ResponsePromptConfig
 	}
 	return msgs[0].Content, nil
 }
+
+// promptToolGuidance lists the when-to-call lines of the enabled tools,
+// without repeating lines shared by several tools.
+func promptToolGuidance(enabled []tools.Registration) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, r := range enabled {
+		for _, line := range r.Guidance {
+			if !seen[line] {
+				seen[line] = true
+				out = append(out, line)
+			}
+		}
+	}
+	return out
+}
+
+// hasSideEffects reports whether any enabled tool changes carts or orders.
+func hasSideEffects(enabled []tools.Registration) bool {
+	for _, r := range enabled {
+		if r.Risk == tools.RiskSideEffect {
+			return true
+		}
+	}
+	return false
+}
+
+// promptEntity is the template view of an extracted entity.
+type promptEntity struct {
+	Type       string
+	Value      string
+	Normalized string
+}
+
+// promptEntities lists extracted entities with their normalized values (if any)
+// so the model can reuse canonical amounts/brands as tool filters.
+func promptEntities(nlu model.NLUResponse) []promptEntity {
+	out := make([]promptEntity, 0, len(nlu.Entities))
+	for _, e := range nlu.Entities {
+		pe := promptEntity{Type: e.Type, Value: e.Value}
+		if nv, ok := e.Normalized(); ok {
+			pe.Normalized = nv.String()
+		}
+		out = append(out, pe)
+	}
+	return out
+}
+
+// promptSlot is the template view of a dialogue slot.
+type promptSlot struct {
+	Name      string
+	Value     string
+	Confirmed bool
+}
+
+// promptSlots lists filled slots in a stable order.
+func promptSlots(d *model.DialogueState) []promptSlot {
+	if d == nil {
+		return nil
+	}
+	var out []promptSlot
+	for _, name := range model.DialogueSlots {
+		if v, ok := d.Slots[name]; ok {
+			out = append(out, promptSlot{Name: name, Value: v.Value, Confirmed: v.Confirmed})
+		}
+	}
+	return out
+}
+
+// promptPendingSlots lists unconfirmed changes the assistant should confirm.
+func promptPendingSlots(d *model.DialogueState) []promptSlot {
+	if d == nil {
+		return nil
+	}
+	var out []promptSlot
+	for _, name := range model.DialogueSlots {
+		if v, ok := d.Pending[name]; ok {
+			out = append(out, promptSlot{Name: name, Value: v.Value})
+		}
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+func TestRenderResponseSystemConfirmationLine(t *testing.T) {
+	const line = "Cart and order changes are confirmed with the customer"
+	readOnly := tools.Registration{Name: "search_product", Risk: tools.RiskReadOnly}
+	sideEffect := tools.Registration{Name: "add_to_cart", Risk: tools.RiskSideEffect}
+
+	tests := []struct {
+		name    string
+		enabled []tools.Registration
+		confirm bool
+		want    bool
+	}{
+		{"confirming side-effecting tools", []tools.Registration{readOnly, sideEffect}, true, true},
+		{"confirmation disabled", []tools.Registration{readOnly, sideEffect}, false, false},
+		{"no side-effecting tools", []tools.Registration{readOnly}, true, false},
+		{"neither", []tools.Registration{readOnly}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderResponseSystem(context.Background(), model.ResponsePromptConfig{}, tt.enabled, tt.confirm, model.NLUResponse{}, nil, false)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if strings.Contains(got, line) != tt.want {
+				t.Errorf("confirmation line rendered = %v, want %v", !tt.want, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,461 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/parsers"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// Argument problems reported to the model.
+const (
+	IssueRequired = "required" // missing, null or empty
+	IssueType     = "type"     // cannot be read as the declared type
+	IssueEnum     = "enum"     // not one of the allowed values
+	IssueRange    = "range"    // number below the minimum
+	IssueItems    = "items"    // array length outside its limits
+)
+
+// Well-known parameters get domain parsing in every tool that declares them.
+var (
+	// numberParsers read text values of number parameters.
+	numberParsers = map[string]func(string) (float64, bool){
+		"min_price":      parsers.ParseAmount, // "40,000 บาท", "4 หมื่น"
+		"max_price":      parsers.ParseAmount,
+		"quantity":       parsers.ParseAmount, // "สอง", "2 ชิ้น"
+		"min_ram_gb":     ParseCapacityGB,     // "16GB"
+		"min_storage_gb": ParseCapacityGB,     // "1TB"
+	}
+	// stringNormalizers canonicalize case-insensitive codes.
+	stringNormalizers = map[string]func(string) string{
+		"coupon_code": strings.ToUpper,
+		"order_id":    strings.ToUpper,
+	}
+	// enumAliases map common synonyms onto enum values.
+	enumAliases = map[string]map[string]string{
+		"sort_by": {
+			"price_asc": SortPrice, "cheapest": SortPrice, "low_to_high": SortPrice,
+			"-price": SortPriceDesc, "expensive": SortPriceDesc, "high_to_low": SortPriceDesc,
+		},
+	}
+)
+
+// ArgumentProblem is one invalid parameter of a tool call.
+type ArgumentProblem struct {
+	Param    string   `json:"param"`
+	Issue    string   `json:"issue"`
+	Expected string   `json:"expected"`          // e.g. "integer 1-20", "array of 2-5 strings"
+	Got      any      `json:"got,omitempty"`     // the rejected value
+	Allowed  []string `json:"allowed,omitempty"` // enum values
+	About    string   `json:"about,omitempty"`   // parameter description, for missing ones
+}
+
+// ArgumentError is returned by ArgumentValidator.Validate for calls the
+// validator cannot repair. Its JSON form is the tool result, so the model
+// can fix the call and retry.
+type ArgumentError struct {
+	Kind     string            `json:"error"` // always invalid_arguments
+	Tool     string            `json:"tool"`
+	Problems []ArgumentProblem `json:"problems"`
+	Hint     string            `json:"hint"`
+}
+
+func (e *ArgumentError) Error() string {
+	parts := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		parts[i] = p.Param + ": " + p.Issue
+	}
+	return fmt.Sprintf("invalid arguments for %s (%s)", e.Tool, strings.Join(parts, ", "))
+}
+
+// JSON returns the tool result for the model.
+func (e *ArgumentError) JSON() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Sprintf(`{"error":"invalid_arguments","tool":%q}`, e.Tool)
+	}
+	return string(b)
+}
+
+// ArgumentValidator checks tool call arguments against each tool's parameter
+// schema: types, required parameters, enums, number and array limits. It
+// repairs near misses on the way (trims strings, reads numbers and booleans
+// from text, splits comma-separated lists, matches enums case-insensitively,
+// caps numbers at their maximum, drops unknown, null and empty parameters)
+// and returns canonical JSON.
+type ArgumentValidator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewArgumentValidator reads the parameter schemas of ts.
+func NewArgumentValidator(ctx context.Context, ts []tool.BaseTool) (*ArgumentValidator, error) {
+	v := &ArgumentValidator{schemas: make(map[string]*jsonschema.Schema, len(ts))}
+	for _, t := range ts {
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool info: %w", err)
+		}
+		if err := v.add(info); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (v *ArgumentValidator) add(info *schema.ToolInfo) error {
+	js := &jsonschema.Schema{Type: string(schema.Object)}
+	if info.ParamsOneOf != nil {
+		s, err := info.ParamsOneOf.ToJSONSchema()
+		if err != nil {
+			return fmt.Errorf("tool %s params: %w", info.Name, err)
+		}
+		if s != nil {
+			js = s
+		}
+	}
+	v.schemas[info.Name] = js
+	return nil
+}
+
+// Validate returns the canonical arguments of a call to the named tool, or
+// an *ArgumentError. Tools it does not know are passed through.
+func (v *ArgumentValidator) Validate(name, arguments string) (string, error) {
+	js, ok := v.schemas[name]
+	if !ok {
+		return arguments, nil
+	}
+
+	var raw any = map[string]any{}
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &raw); err != nil {
+			raw = arguments
+		}
+	}
+	obj, isObj := raw.(map[string]any)
+	if !isObj {
+		return "", &ArgumentError{
+			Kind:     "invalid_arguments",
+			Tool:     name,
+			Problems: []ArgumentProblem{{Param: "(arguments)", Issue: IssueType, Expected: "a JSON object", Got: raw}},
+			Hint:     "Send the arguments as one JSON object.",
+		}
+	}
+
+	out := map[string]any{}
+	var problems []ArgumentProblem
+	if js.Properties != nil {
+		for p := js.Properties.Oldest(); p != nil; p = p.Next() {
+			val, present := obj[p.Key]
+			if !present {
+				continue
+			}
+			coerced, keep, problem := coerce(p.Key, val, p.Value)
+			if problem != nil {
+				problems = append(problems, *problem)
+				continue
+			}
+			if keep {
+				out[p.Key] = coerced
+			}
+		}
+	}
+	for key := range obj {
+		if js.Properties == nil {
+			break
+		}
+		if _, known := js.Properties.Get(key); !known {
+			logx.Debug().Str("tool_name", name).Str("param", key).Msg("Dropping unknown tool argument")
+		}
+	}
+	for _, req := range js.Required {
+		if _, ok := out[req]; ok || hasProblem(problems, req) {
+			continue
+		}
+		prop, _ := js.Properties.Get(req)
+		problem := ArgumentProblem{Param: req, Issue: IssueRequired, Expected: expected(prop)}
+		if prop != nil {
+			problem.About = prop.Description
+		}
+		problems = append(problems, problem)
+	}
+
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Param < problems[j].Param })
+		return "", &ArgumentError{
+			Kind:     "invalid_arguments",
+			Tool:     name,
+			Problems: problems,
+			Hint:     "Fix the listed parameters and call the tool again; do not invent values the customer has not given.",
+		}
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("marshal arguments: %w", err)
+	}
+	return string(b), nil
+}
+
+func hasProblem(problems []ArgumentProblem, param string) bool {
+	for _, p := range problems {
+		if p.Param == param {
+			return true
+		}
+	}
+	return false
+}
+
+// coerce converts val to the type of s. keep is false for values that count
+// as absent (null, empty strings and arrays).
+func coerce(name string, val any, s *jsonschema.Schema) (out any, keep bool, problem *ArgumentProblem) {
+	if val == nil {
+		return nil, false, nil
+	}
+	if s == nil {
+		return val, true, nil
+	}
+	bad := func(issue string) (any, bool, *ArgumentProblem) {
+		p := &ArgumentProblem{Param: name, Issue: issue, Expected: expected(s), Got: val}
+		for _, e := range s.Enum {
+			p.Allowed = append(p.Allowed, fmt.Sprint(e))
+		}
+		return nil, false, p
+	}
+
+	switch s.Type {
+	case string(schema.String), "":
+		str, ok := scalarString(val)
+		if !ok {
+			if s.Type == "" {
+				return val, true, nil // untyped: accept as is
+			}
+			return bad(IssueType)
+		}
+		str = strings.TrimSpace(str)
+		if str == "" {
+			return nil, false, nil
+		}
+		if norm, ok := stringNormalizers[name]; ok {
+			str = norm(str)
+		}
+		if len(s.Enum) > 0 {
+			canon, ok := matchEnum(name, str, s.Enum)
+			if !ok {
+				return bad(IssueEnum)
+			}
+			str = canon
+		}
+		return str, true, nil
+
+	case string(schema.Number), string(schema.Integer):
+		n, ok := scalarNumber(name, val)
+		if !ok {
+			return bad(IssueType)
+		}
+		if s.Type == string(schema.Integer) {
+			n = math.Round(n)
+		}
+		if lo, err := s.Minimum.Float64(); err == nil && n < lo {
+			return bad(IssueRange)
+		}
+		if hi, err := s.Maximum.Float64(); err == nil && n > hi {
+			n = hi
+		}
+		if s.Type == string(schema.Integer) {
+			return int64(n), true, nil
+		}
+		return n, true, nil
+
+	case string(schema.Boolean):
+		switch b := val.(type) {
+		case bool:
+			return b, true, nil
+		case string:
+			if parsed, err := strconv.ParseBool(strings.TrimSpace(b)); err == nil {
+				return parsed, true, nil
+			}
+		}
+		return bad(IssueType)
+
+	case string(schema.Array):
+		var elems []any
+		switch a := val.(type) {
+		case []any:
+			elems = a
+		case string:
+			// comma-separated lists
+			for _, part := range strings.Split(a, ",") {
+				elems = append(elems, part)
+			}
+		default:
+			elems = []any{val}
+		}
+		items := make([]any, 0, len(elems))
+		for _, e := range elems {
+			c, keep, p := coerce(name, e, s.Items)
+			if p != nil {
+				p.Expected = expected(s)
+				return nil, false, p
+			}
+			if keep {
+				items = append(items, c)
+			}
+		}
+		if len(items) == 0 {
+			return nil, false, nil
+		}
+		if (s.MinItems != nil && uint64(len(items)) < *s.MinItems) || (s.MaxItems != nil && uint64(len(items)) > *s.MaxItems) {
+			return bad(IssueItems)
+		}
+		return items, true, nil
+
+	case string(schema.Object):
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return bad(IssueType)
+		}
+		return obj, true, nil
+	}
+	return val, true, nil
+}
+
+// scalarString reads strings, numbers and booleans as text.
+func scalarString(val any) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// scalarNumber reads numbers and numeric text, using the parameter's domain
+// parser when it has one.
+func scalarNumber(name string, val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case string:
+		v = strings.TrimSpace(v)
+		if parse, ok := numberParsers[name]; ok {
+			return parse(v)
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// matchEnum finds str among the allowed values, ignoring case and aliases.
+func matchEnum(name, str string, allowed []any) (string, bool) {
+	lower := strings.ToLower(str)
+	if alias, ok := enumAliases[name][lower]; ok {
+		lower = alias
+	}
+	for _, a := range allowed {
+		if s := fmt.Sprint(a); strings.ToLower(s) == lower {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// expected describes a parameter's schema for the model.
+func expected(s *jsonschema.Schema) string {
+	if s == nil {
+		return "any value"
+	}
+	switch s.Type {
+	case string(schema.Array):
+		count := ""
+		switch {
+		case s.MinItems != nil && s.MaxItems != nil:
+			count = fmt.Sprintf("%d-%d ", *s.MinItems, *s.MaxItems)
+		case s.MinItems != nil:
+			count = fmt.Sprintf("at least %d ", *s.MinItems)
+		case s.MaxItems != nil:
+			count = fmt.Sprintf("at most %d ", *s.MaxItems)
+		}
+		return "array of " + count + plural(expected(s.Items))
+	case string(schema.Number), string(schema.Integer):
+		lo, hi := string(s.Minimum), string(s.Maximum)
+		switch {
+		case lo != "" && hi != "":
+			return fmt.Sprintf("%s %s-%s", s.Type, lo, hi)
+		case lo != "":
+			return fmt.Sprintf("%s >= %s", s.Type, lo)
+		case hi != "":
+			return fmt.Sprintf("%s <= %s", s.Type, hi)
+		}
+		return s.Type
+	case "":
+		return "string"
+	}
+	if len(s.Enum) > 0 {
+		vals := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			vals[i] = fmt.Sprint(e)
+		}
+		return "one of " + strings.Join(vals, ", ")
+	}
+	return s.Type
+}
+
+func plural(s string) string {
+	if strings.HasPrefix(s, "one of ") {
+		return "values " + s
+	}
+	return s + "s"
+}
+
+// ValidateArguments wraps each invokable tool so that its arguments are
+// checked by v first. Invalid calls return the *ArgumentError JSON as the
+// tool result instead of failing the run.
+func ValidateArguments(ts []tool.BaseTool, v *ArgumentValidator) []tool.BaseTool {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		if it, ok := t.(tool.InvokableTool); ok {
+			out[i] = &validatedTool{InvokableTool: it, validator: v}
+		} else {
+			out[i] = t
+		}
+	}
+	return out
+}
+
+type validatedTool struct {
+	tool.InvokableTool
+	validator *ArgumentValidator
+}
+
+func (t *validatedTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	info, err := t.Info(ctx)
+	if err != nil {
+		return "", err
+	}
+	args, err := t.validator.Validate(info.Name, arguments)
+	if argErr, ok := err.(*ArgumentError); ok {
+		logx.Warn().
+			Str("tool_name", info.Name).
+			Str("arguments", arguments).
+			Str("problems", argErr.Error()).
+			Msg("Invalid tool arguments; returning them to the model")
+		return argErr.JSON(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return t.InvokableTool.InvokableRun(ctx, args, opts...)
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+)
+
+// newTestValidator knows one tool, "test_tool", whose parameters cover every
+// coercion: a required ID, an enum with aliases, numbers with and without
+// domain parsers and limits, a boolean, an array with length limits and a
+// normalized code.
+func newTestValidator(t *testing.T) *ArgumentValidator {
+	t.Helper()
+	v := &ArgumentValidator{schemas: map[string]*jsonschema.Schema{}}
+	info := &schema.ToolInfo{
+		Name: "test_tool",
+		ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
+			"product_id":    {Type: schema.String, Desc: "Product ID from search results", Required: true},
+			"query":         {Type: schema.String},
+			"sort_by":       {Type: schema.String, Enum: []string{SortRelevance, SortPrice, SortPriceDesc}},
+			"quantity":      {Type: schema.Integer},
+			"limit":         {Type: schema.Integer},
+			"min_price":     {Type: schema.Number},
+			"min_ram_gb":    {Type: schema.Number},
+			"in_stock_only": {Type: schema.Boolean},
+			"product_ids":   {Type: schema.Array, ElemInfo: &schema.ParameterInfo{Type: schema.String}},
+			"coupon_code":   {Type: schema.String},
+		}, map[string]paramLimits{
+			"quantity":    between(1, 10),
+			"limit":       between(1, 20),
+			"min_price":   atLeast(0),
+			"product_ids": itemsBetween(2, 5),
+		}),
+	}
+	if err := v.add(info); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return v
+}
+
+func TestArgumentValidatorCoerces(t *testing.T) {
+	v := newTestValidator(t)
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"valid call unchanged", `{"product_id":"prod-001","quantity":2}`, `{"product_id":"prod-001","quantity":2}`},
+		{"trims strings", `{"product_id":" prod-001 ","query":"  iphone  "}`, `{"product_id":"prod-001","query":"iphone"}`},
+		{"number as string", `{"product_id":123}`, `{"product_id":"123"}`},
+		{"enum ignores case", `{"product_id":"p","sort_by":"PRICE"}`, `{"product_id":"p","sort_by":"price"}`},
+		{"enum alias cheapest", `{"product_id":"p","sort_by":"cheapest"}`, `{"product_id":"p","sort_by":"price"}`},
+		{"enum alias high_to_low", `{"product_id":"p","sort_by":"High_To_Low"}`, `{"product_id":"p","sort_by":"price_desc"}`},
+		{"quantity Thai word", `{"product_id":"p","quantity":"สอง"}`, `{"product_id":"p","quantity":2}`},
+		{"quantity with unit", `{"product_id":"p","quantity":"3 ชิ้น"}`, `{"product_id":"p","quantity":3}`},
+		{"integer rounded", `{"product_id":"p","quantity":2.6}`, `{"product_id":"p","quantity":3}`},
+		{"capped at max", `{"product_id":"p","quantity":25}`, `{"product_id":"p","quantity":10}`},
+		{"text capped at max", `{"product_id":"p","limit":"50"}`, `{"product_id":"p","limit":20}`},
+		{"plain number text", `{"product_id":"p","limit":"1,5"}`, `{"product_id":"p","limit":15}`},
+		{"min_price with currency", `{"product_id":"p","min_price":"40,000 บาท"}`, `{"product_id":"p","min_price":40000}`},
+		{"min_price Thai multiplier", `{"product_id":"p","min_price":"4 หมื่น"}`, `{"product_id":"p","min_price":40000}`},
+		{"capacity with unit", `{"product_id":"p","min_ram_gb":"16GB"}`, `{"product_id":"p","min_ram_gb":16}`},
+		{"boolean text", `{"product_id":"p","in_stock_only":"true"}`, `{"product_id":"p","in_stock_only":true}`},
+		{"comma-split array", `{"product_id":"p","product_ids":"prod-001, prod-002"}`, `{"product_id":"p","product_ids":["prod-001","prod-002"]}`},
+		{"array drops empty items", `{"product_id":"p","product_ids":["prod-001","","prod-002"]}`, `{"product_id":"p","product_ids":["prod-001","prod-002"]}`},
+		{"normalized code", `{"product_id":"p","coupon_code":"welcome500"}`, `{"coupon_code":"WELCOME500","product_id":"p"}`},
+		{"drops unknown", `{"product_id":"p","color":"red"}`, `{"product_id":"p"}`},
+		{"drops null and empty", `{"product_id":"p","query":null,"sort_by":"","product_ids":[]}`, `{"product_id":"p"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Validate("test_tool", tt.args)
+			if err != nil {
+				t.Fatalf("Validate(%s): %v", tt.args, err)
+			}
+			var gotObj, wantObj map[string]any
+			if err := json.Unmarshal([]byte(got), &gotObj); err != nil {
+				t.Fatalf("output %s is not a JSON object: %v", got, err)
+			}
+			_ = json.Unmarshal([]byte(tt.want), &wantObj)
+			if !reflect.DeepEqual(gotObj, wantObj) {
+				t.Errorf("Validate(%s) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgumentValidatorProblems(t *testing.T) {
+	v := newTestValidator(t)
+	tests := []struct {
+		name     string
+		args     string
+		param    string
+		issue    string
+		expected string
+	}{
+		{"not an object", `"iphone"`, "(arguments)", IssueType, "a JSON object"},
+		{"required missing", `{}`, "product_id", IssueRequired, "string"},
+		{"required empty", `{"product_id":"  "}`, "product_id", IssueRequired, "string"},
+		{"string type", `{"product_id":["p"]}`, "product_id", IssueType, "string"},
+		{"number type", `{"product_id":"p","limit":"many"}`, "limit", IssueType, "integer 1-20"},
+		{"boolean type", `{"product_id":"p","in_stock_only":"maybe"}`, "in_stock_only", IssueType, "boolean"},
+		{"array item type", `{"product_id":"p","product_ids":[{"id":"prod-001"}]}`, "product_ids", IssueType, "array of 2-5 strings"},
+		{"enum", `{"product_id":"p","sort_by":"newest"}`, "sort_by", IssueEnum, "one of relevance, price, price_desc"},
+		{"below minimum", `{"product_id":"p","quantity":0}`, "quantity", IssueRange, "integer 1-10"},
+		{"negative price", `{"product_id":"p","min_price":-5}`, "min_price", IssueRange, "number >= 0"},
+		{"too few items", `{"product_id":"p","product_ids":["prod-001"]}`, "product_ids", IssueItems, "array of 2-5 strings"},
+		{"too many items", `{"product_id":"p","product_ids":"a,b,c,d,e,f"}`, "product_ids", IssueItems, "array of 2-5 strings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Validate("test_tool", tt.args)
+			var argErr *ArgumentError
+			if !errors.As(err, &argErr) {
+				t.Fatalf("Validate(%s) error = %v, want *ArgumentError", tt.args, err)
+			}
+			if len(argErr.Problems) != 1 {
+				t.Fatalf("problems = %+v, want one", argErr.Problems)
+			}
+			p := argErr.Problems[0]
+			if p.Param != tt.param || p.Issue != tt.issue || p.Expected != tt.expected {
+				t.Errorf("problem = %s/%s expected %q, want %s/%s expected %q", p.Param, p.Issue, p.Expected, tt.param, tt.issue, tt.expected)
+			}
+			if tt.issue == IssueEnum && len(p.Allowed) != 3 {
+				t.Errorf("allowed = %v, want the three sort orders", p.Allowed)
+			}
+			if tt.issue == IssueRequired && p.About == "" {
+				t.Errorf("missing parameter has no description")
+			}
+			if argErr.Kind != "invalid_arguments" || argErr.Tool != "test_tool" {
+				t.Errorf("error = %s/%s, want invalid_arguments/test_tool", argErr.Kind, argErr.Tool)
+			}
+		})
+	}
+}
+
+func TestArgumentValidatorPassesUnknownTools(t *testing.T) {
+	v := newTestValidator(t)
+	args := `{"anything": [1, 2]}`
+	got, err := v.Validate("other_tool", args)
+	if err != nil || got != args {
+		t.Errorf("Validate(other_tool) = %s, %v; want the arguments unchanged", got, err)
+	}
+}
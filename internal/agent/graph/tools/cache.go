@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// ChangeNotifier is implemented by backends that report when their data
+// changes (e.g. a reloaded catalog file), so cached results can be dropped.
+type ChangeNotifier interface {
+	OnChange(fn func())
+}
+
+// ToolCache reuses results of read-only tools with a CacheTTL, within and
+// across conversations. Results are keyed by tool name and a hash of the
+// canonical arguments plus the tool's CacheKey. Error results are not
+// cached, and cache failures only cost a miss.
+type ToolCache struct {
+	store model.ToolResultCache
+	gen   atomic.Uint64 // bumped by Invalidate; results computed across it are not stored
+}
+
+// NewToolCache caches tool results in store.
+func NewToolCache(store model.ToolResultCache) *ToolCache {
+	return &ToolCache{store: store}
+}
+
+// Wrap adds caching to the registered read-only tools of ts with a CacheTTL;
+// other tools are returned as is.
+func (c *ToolCache) Wrap(ctx context.Context, ts []tool.BaseTool) ([]tool.BaseTool, error) {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		out[i] = t
+		it, ok := t.(tool.InvokableTool)
+		if !ok {
+			continue
+		}
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool info: %w", err)
+		}
+		reg, ok := Lookup(info.Name)
+		if !ok || reg.Risk != RiskReadOnly || reg.CacheTTL <= 0 {
+			continue
+		}
+		out[i] = &cachedTool{InvokableTool: it, name: info.Name, ttl: reg.CacheTTL, key: reg.CacheKey, cache: c}
+	}
+	return out, nil
+}
+
+// Invalidate drops the cached results of the named tools, or of all tools
+// when none are named.
+func (c *ToolCache) Invalidate(ctx context.Context, names ...string) error {
+	c.gen.Add(1)
+	if len(names) == 0 {
+		if err := c.store.Purge(ctx); err != nil {
+			return fmt.Errorf("purge tool cache: %w", err)
+		}
+		logx.Info().Msg("Tool result cache purged")
+		return nil
+	}
+	for _, name := range names {
+		if err := c.store.Invalidate(ctx, name); err != nil {
+			return fmt.Errorf("invalidate tool cache %s: %w", name, err)
+		}
+	}
+	logx.Info().Strs("tools", names).Msg("Tool result cache invalidated")
+	return nil
+}
+
+type cachedTool struct {
+	tool.InvokableTool
+	name  string
+	ttl   time.Duration
+	key   func(context.Context) string
+	cache *ToolCache
+}
+
+func (t *cachedTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	key := t.cacheKey(ctx, arguments)
+	store := t.cache.store
+	if out, ok, err := store.Get(ctx, t.name, key); err != nil {
+		logx.Warn().Err(err).Str("tool_name", t.name).Msg("Tool cache lookup failed; calling the tool")
+	} else if ok {
+		annotate(ctx, "cache_hit", true)
+		logx.Debug().Str("tool_name", t.name).Msg("Tool cache hit")
+		return out, nil
+	}
+	annotate(ctx, "cache_hit", false)
+
+	gen := t.cache.gen.Load()
+	out, err := t.InvokableTool.InvokableRun(ctx, arguments, opts...)
+	if err != nil || IsErrorResult(out) || t.cache.gen.Load() != gen {
+		return out, err
+	}
+	if err := store.Set(ctx, t.name, key, out, t.ttl); err != nil {
+		logx.Warn().Err(err).Str("tool_name", t.name).Msg("Failed to cache tool result")
+	}
+	return out, nil
+}
+
+// CanonicalArguments returns the JSON arguments of a tool call with object
+// keys sorted and whitespace dropped, so equal calls compare equal. Arguments
+// that are not valid JSON are only trimmed.
+func CanonicalArguments(arguments string) string {
+	canonical := strings.TrimSpace(arguments)
+	var v any
+	dec := json.NewDecoder(strings.NewReader(canonical))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			canonical = string(b)
+		}
+	}
+	return canonical
+}
+
+// CallKey identifies a tool call by its tool name and canonical arguments,
+// independently of the provider's tool call ID.
+func CallKey(name, arguments string) string {
+	return name + "\x00" + CanonicalArguments(arguments)
+}
+
+// cacheKey hashes the canonical arguments and the tool's
+// conversation-dependent key.
+func (t *cachedTool) cacheKey(ctx context.Context, arguments string) string {
+	h := sha256.New()
+	h.Write([]byte(CanonicalArguments(arguments)))
+	if t.key != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(t.key(ctx)))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// IsErrorResult reports whether a tool result is an error payload
+// ({"error": ...}), such as invalid_arguments or temporarily_unavailable.
+func IsErrorResult(out string) bool {
+	trimmed := strings.TrimSpace(out)
+	if !strings.HasPrefix(trimmed, "{") {
+		return false
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &obj); err != nil {
+		return false
+	}
+	_, ok := obj["error"]
+	return ok
+}
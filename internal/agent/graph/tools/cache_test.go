@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
+)
+
+func TestToolCacheDropsLiveStockOnReservation(t *testing.T) {
+	ctx := context.Background()
+	catalog := NewMemoryCatalog([]model.Product{{ID: "prod-a", Name: "Phone A", Category: "smartphone", Price: 30000, InStock: true}})
+	inventory := NewMemoryInventory(
+		[]Branch{{ID: "wh", Name: "Warehouse", Kind: BranchWarehouse}},
+		[]StockRecord{{ProductID: "prod-a", BranchID: "wh", OnHand: 1}},
+	)
+	cache := NewToolCache(repo.NewMemoryToolResultCache(100))
+	// as wired by the graph
+	live := LiveStockTools()
+	if !slices.Contains(live, ToolSearchProduct) {
+		t.Fatalf("LiveStockTools() = %v, want %s among them", live, ToolSearchProduct)
+	}
+	inventory.OnChange(func() {
+		if err := cache.Invalidate(ctx, live...); err != nil {
+			t.Errorf("Invalidate: %v", err)
+		}
+	})
+
+	wrapped, err := cache.Wrap(ctx, []tool.BaseTool{createSearchProductTool(catalog, inventory)})
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	search := wrapped[0].(tool.InvokableTool)
+	inStock := func() bool {
+		t.Helper()
+		out, err := search.InvokableRun(ctx, `{"query":"phone"}`)
+		if err != nil {
+			t.Fatalf("search_product: %v", err)
+		}
+		var res SearchProductOutput
+		if err := json.Unmarshal([]byte(out), &res); err != nil || len(res.Products) != 1 {
+			t.Fatalf("search_product result %s: %v", out, err)
+		}
+		return res.Products[0].InStock
+	}
+
+	if !inStock() {
+		t.Fatal("prod-a out of stock before the reservation")
+	}
+	if err := inventory.Reserve(ctx, "ORD-1", "prod-a", "wh", 1, time.Hour); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if inStock() {
+		t.Error("search after the last unit was reserved still reports prod-a in stock")
+	}
+	if err := inventory.Release(ctx, "ORD-1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if !inStock() {
+		t.Error("search after the reservation was released still reports prod-a out of stock")
+	}
+}
@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools/search"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ErrProductNotFound is returned by ProductCatalog.Get for unknown IDs.
+var ErrProductNotFound = errors.New("product not found")
+
+// CatalogQuery describes a catalog search.
+type CatalogQuery struct {
+	Text     string // free-text keywords (Thai/English)
+	Category string // optional exact category filter
+	Limit    int    // maximum results; <= 0 means no limit
+}
+
+// SearchHit is a product with its relevance score for the query (0 when the
+// query has no text). It marshals as the product's fields plus "score".
+type SearchHit struct {
+	model.Product
+	Score float64 `json:"score"`
+}
+
+// ProductCatalog is the product source behind the query tools.
+type ProductCatalog interface {
+	// Search returns products matching q, best matches first
+	Search(ctx context.Context, q CatalogQuery) ([]SearchHit, error)
+
+	// Get returns one product with details and specifications, or ErrProductNotFound
+	Get(ctx context.Context, id string) (*model.Product, error)
+
+	// ListCategories returns the distinct product categories, sorted
+	ListCategories(ctx context.Context) ([]string, error)
+}
+
+// MemoryCatalog serves products from memory with a ranked full-text index
+// (see package search). It is safe for concurrent use and its contents can be
+// swapped atomically with Replace (used by file reloads).
+type MemoryCatalog struct {
+	mu       sync.RWMutex
+	products []model.Product
+	byID     map[string]int
+	index    *search.Index
+	onChange []func()
+}
+
+// NewMemoryCatalog creates a catalog over products (copied).
+func NewMemoryCatalog(products []model.Product) *MemoryCatalog {
+	c := &MemoryCatalog{}
+	c.Replace(products)
+	return c
+}
+
+// Replace swaps the catalog contents.
+func (c *MemoryCatalog) Replace(products []model.Product) {
+	items := make([]model.Product, len(products))
+	byID := make(map[string]int, len(products))
+	docs := make([]search.Document, len(products))
+	for i, p := range products {
+		items[i] = cloneProduct(p)
+		byID[p.ID] = i
+		docs[i] = productDocument(p)
+	}
+	index := search.NewIndex(docs)
+	c.mu.Lock()
+	c.products, c.byID, c.index = items, byID, index
+	listeners := c.onChange
+	c.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// OnChange registers fn to run after each Replace.
+func (c *MemoryCatalog) OnChange(fn func()) {
+	c.mu.Lock()
+	c.onChange = append(c.onChange, fn)
+	c.mu.Unlock()
+}
+
+// Len returns the number of products.
+func (c *MemoryCatalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.products)
+}
+
+// Search ranks products by relevance to q.Text (BM25 over name, brand,
+// category, description, details and specification values). Without text it
+// lists the (category-filtered) catalog in order.
+func (c *MemoryCatalog) Search(ctx context.Context, q CatalogQuery) ([]SearchHit, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	inCategory := func(p model.Product) bool {
+		return q.Category == "" || strings.EqualFold(p.Category, q.Category)
+	}
+
+	var out []SearchHit
+	if strings.TrimSpace(q.Text) == "" {
+		for _, p := range c.products {
+			if !inCategory(p) {
+				continue
+			}
+			out = append(out, SearchHit{Product: cloneProduct(p)})
+			if q.Limit > 0 && len(out) >= q.Limit {
+				break
+			}
+		}
+		return out, nil
+	}
+
+	hits := c.index.Search(q.Text, search.Options{
+		Limit: q.Limit,
+		Filter: func(id string) bool {
+			return inCategory(c.products[c.byID[id]])
+		},
+	})
+	for _, h := range hits {
+		out = append(out, SearchHit{Product: cloneProduct(c.products[c.byID[h.ID]]), Score: h.Score})
+	}
+	return out, nil
+}
+
+func (c *MemoryCatalog) Get(ctx context.Context, id string) (*model.Product, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	i, ok := c.byID[id]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	p := cloneProduct(c.products[i])
+	return &p, nil
+}
+
+func (c *MemoryCatalog) ListCategories(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return categoriesOf(c.products), nil
+}
+
+// categoriesOf returns the sorted distinct categories of products.
+func categoriesOf(products []model.Product) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range products {
+		if p.Category != "" && !seen[p.Category] {
+			seen[p.Category] = true
+			out = append(out, p.Category)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// productDocument maps a product to weighted index fields.
+func productDocument(p model.Product) search.Document {
+	specs := make([]string, 0, len(p.Specifications))
+	for _, v := range p.Specifications {
+		specs = append(specs, v)
+	}
+	sort.Strings(specs)
+	return search.Document{ID: p.ID, Fields: []search.Field{
+		{Text: p.Name, Weight: 3},
+		{Text: p.Brand, Weight: 3},
+		{Text: p.Category, Weight: 2},
+		{Text: p.Description, Weight: 1},
+		{Text: p.Details, Weight: 0.5},
+		{Text: strings.Join(specs, " "), Weight: 0.5},
+	}}
+}
+
+// cloneProduct copies p so callers cannot mutate catalog-owned maps.
+func cloneProduct(p model.Product) model.Product {
+	p.Specifications = maps.Clone(p.Specifications)
+	return p
+}
+
+// productSummary strips catalog-only fields for search results.
+func productSummary(p model.Product) model.Product {
+	p.Details = ""
+	p.Specifications = nil
+	return p
+}
+
+var _ ProductCatalog = (*MemoryCatalog)(nil)
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NewFakeInventoryHandler serves the inventory catalog API that HTTPCatalog
+// consumes from any ProductCatalog. Use it with httptest.NewServer (or as a
+// local stand-in service) to exercise the HTTP backend without the real
+// inventory service. apiKey, when set, is required as a Bearer token.
+func NewFakeInventoryHandler(catalog ProductCatalog, apiKey string) http.Handler {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	auth := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if apiKey != "" && r.Header.Get("Authorization") != "Bearer "+apiKey {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+				return
+			}
+			next(w, r)
+		}
+	}
+
+	mux.HandleFunc("GET /products", auth(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		products, err := catalog.Search(r.Context(), CatalogQuery{
+			Text:     r.URL.Query().Get("q"),
+			Category: r.URL.Query().Get("category"),
+			Limit:    limit,
+		})
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"products": products})
+	}))
+	mux.HandleFunc("GET /products/{id}", auth(func(w http.ResponseWriter, r *http.Request) {
+		p, err := catalog.Get(r.Context(), strings.TrimSpace(r.PathValue("id")))
+		switch {
+		case errors.Is(err, ErrProductNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "product not found"})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		default:
+			writeJSON(w, http.StatusOK, p)
+		}
+	}))
+	mux.HandleFunc("GET /categories", auth(func(w http.ResponseWriter, r *http.Request) {
+		categories, err := catalog.ListCategories(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"categories": categories})
+	}))
+	return mux
+}
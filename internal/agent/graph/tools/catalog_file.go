@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// FileCatalog loads products from a JSON or CSV file and reloads it when the
+// file changes. Reads are served from an in-memory snapshot; a reload that
+// fails keeps the previous snapshot.
+//
+// JSON: an array of products (model.Product fields) or {"products": [...]}.
+// CSV: header row with id, name, brand, category, price, description,
+// in_stock, details; any "spec:<key>" column becomes a specification.
+type FileCatalog struct {
+	*MemoryCatalog
+	path     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewFileCatalog loads path and, when interval > 0, polls it for changes
+// until Close is called.
+func NewFileCatalog(path string, interval time.Duration) (*FileCatalog, error) {
+	c := &FileCatalog{MemoryCatalog: NewMemoryCatalog(nil), path: path, interval: interval}
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.watch()
+	}
+	return c, nil
+}
+
+// Reload re-reads the file if its size or modification time changed and
+// reports whether the catalog was replaced.
+func (c *FileCatalog) Reload() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return false, fmt.Errorf("stat catalog file: %w", err)
+	}
+	if info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return false, nil
+	}
+	// remember the attempt so a broken file is reported once, not on every poll
+	c.modTime, c.size = info.ModTime(), info.Size()
+	products, err := LoadProductsFile(c.path)
+	if err != nil {
+		return false, err
+	}
+	c.Replace(products)
+	logx.Info().Str("path", c.path).Int("products", len(products)).Msg("Product catalog loaded")
+	return true, nil
+}
+
+// Close stops the change watcher.
+func (c *FileCatalog) Close() error {
+	if c.stop != nil {
+		close(c.stop)
+		<-c.done
+		c.stop = nil
+	}
+	return nil
+}
+
+func (c *FileCatalog) watch() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if _, err := c.Reload(); err != nil {
+				logx.Warn().Err(err).Str("path", c.path).Msg("Product catalog reload failed; keeping previous data")
+			}
+		}
+	}
+}
+
+// LoadProductsFile parses a JSON or CSV product file (chosen by extension).
+func LoadProductsFile(path string) ([]model.Product, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open catalog file: %w", err)
+	}
+	defer f.Close()
+
+	var products []model.Product
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		products, err = decodeProductsJSON(f)
+	case ".csv":
+		products, err = decodeProductsCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported catalog file type %q (expected .json or .csv)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse catalog file %s: %w", path, err)
+	}
+	if err := validateProducts(products); err != nil {
+		return nil, fmt.Errorf("invalid catalog file %s: %w", path, err)
+	}
+	return products, nil
+}
+
+func decodeProductsJSON(r io.Reader) ([]model.Product, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var products []model.Product
+	if err := json.Unmarshal(raw, &products); err == nil {
+		return products, nil
+	}
+	var wrapped struct {
+		Products []model.Product `json:"products"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err != nil {
+		return nil, err
+	}
+	return wrapped.Products, nil
+}
+
+func decodeProductsCSV(r io.Reader) ([]model.Product, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	header := rows[0]
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	products := make([]model.Product, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		var p model.Product
+		for i, col := range header {
+			if i >= len(row) {
+				break
+			}
+			v := strings.TrimSpace(row[i])
+			switch {
+			case col == "id":
+				p.ID = v
+			case col == "name":
+				p.Name = v
+			case col == "brand":
+				p.Brand = v
+			case col == "category":
+				p.Category = v
+			case col == "description":
+				p.Description = v
+			case col == "details":
+				p.Details = v
+			case col == "price":
+				price, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: invalid price %q", n+2, v)
+				}
+				p.Price = price
+			case col == "in_stock":
+				if v != "" {
+					inStock, err := strconv.ParseBool(v)
+					if err != nil {
+						return nil, fmt.Errorf("row %d: invalid in_stock %q", n+2, v)
+					}
+					p.InStock = inStock
+				}
+			case strings.HasPrefix(col, "spec:"):
+				if v == "" {
+					continue
+				}
+				if p.Specifications == nil {
+					p.Specifications = map[string]string{}
+				}
+				p.Specifications[strings.TrimPrefix(col, "spec:")] = v
+			}
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+// validateProducts rejects files with missing or duplicate IDs or negative prices.
+func validateProducts(products []model.Product) error {
+	seen := make(map[string]bool, len(products))
+	for i, p := range products {
+		if p.ID == "" {
+			return fmt.Errorf("product %d: missing id", i)
+		}
+		if seen[p.ID] {
+			return fmt.Errorf("duplicate product id %q", p.ID)
+		}
+		seen[p.ID] = true
+		if p.Price < 0 {
+			return fmt.Errorf("product %q: negative price", p.ID)
+		}
+	}
+	return nil
+}
+
+var _ ProductCatalog = (*FileCatalog)(nil)
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeCatalogFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadProductsFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantIDs []string
+		wantErr string
+	}{
+		{
+			name:    "json array",
+			file:    "array.json",
+			content: `[{"id":"p1","name":"Phone","category":"smartphones","price":100,"in_stock":true}]`,
+			wantIDs: []string{"p1"},
+		},
+		{
+			name:    "json object",
+			file:    "object.json",
+			content: `{"products":[{"id":"p1","name":"Phone"},{"id":"p2","name":"Laptop"}]}`,
+			wantIDs: []string{"p1", "p2"},
+		},
+		{
+			name:    "csv with specs",
+			file:    "products.csv",
+			content: "id,name,category,price,in_stock,spec:ram\np1,Phone,smartphones,\"12,900\",true,8GB\n",
+			wantIDs: []string{"p1"},
+		},
+		{name: "duplicate id", file: "dup.json", content: `[{"id":"p1"},{"id":"p1"}]`, wantErr: "duplicate product id"},
+		{name: "missing id", file: "noid.json", content: `[{"name":"Phone"}]`, wantErr: "missing id"},
+		{name: "negative price", file: "neg.json", content: `[{"id":"p1","price":-1}]`, wantErr: "negative price"},
+		{name: "bad csv price", file: "bad.csv", content: "id,price\np1,abc\n", wantErr: "invalid price"},
+		{name: "unsupported type", file: "products.txt", content: "p1", wantErr: "unsupported catalog file type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := LoadProductsFile(writeCatalogFile(t, dir, tt.file, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadProductsFile: %v", err)
+			}
+			if len(products) != len(tt.wantIDs) {
+				t.Fatalf("got %d products, want %d", len(products), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if products[i].ID != id {
+					t.Errorf("product %d id = %q, want %q", i, products[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadProductsFileCSVFields(t *testing.T) {
+	path := writeCatalogFile(t, t.TempDir(), "products.csv",
+		"ID, Name, Brand, Category, Price, In_Stock, spec:RAM, spec:storage\n"+
+			"p1, Galaxy, Samsung, smartphones, \"32,900\", false, 12GB,\n")
+	products, err := LoadProductsFile(path)
+	if err != nil {
+		t.Fatalf("LoadProductsFile: %v", err)
+	}
+	p := products[0]
+	if p.Name != "Galaxy" || p.Brand != "Samsung" || p.Price != 32900 || p.InStock {
+		t.Errorf("product = %+v", p)
+	}
+	if got := p.Specifications["ram"]; got != "12GB" {
+		t.Errorf("spec ram = %q, want 12GB", got)
+	}
+	if _, ok := p.Specifications["storage"]; ok {
+		t.Errorf("empty spec column should be skipped: %v", p.Specifications)
+	}
+}
+
+func TestFileCatalogReload(t *testing.T) {
+	ctx := context.Background()
+	path := writeCatalogFile(t, t.TempDir(), "products.json", `[{"id":"p1","name":"Phone","category":"smartphones"}]`)
+	c, err := NewFileCatalog(path, 0)
+	if err != nil {
+		t.Fatalf("NewFileCatalog: %v", err)
+	}
+	defer c.Close()
+
+	changed := 0
+	c.OnChange(func() { changed++ })
+
+	if replaced, err := c.Reload(); err != nil || replaced {
+		t.Fatalf("Reload of an unchanged file = %v, %v; want false, nil", replaced, err)
+	}
+
+	// a later modification time with new content is picked up
+	if err := os.WriteFile(path, []byte(`[{"id":"p1","name":"Phone"},{"id":"p2","name":"Tablet","category":"tablets"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if replaced, err := c.Reload(); err != nil || !replaced {
+		t.Fatalf("Reload after change = %v, %v; want true, nil", replaced, err)
+	}
+	if _, err := c.Get(ctx, "p2"); err != nil {
+		t.Errorf("Get(p2) after reload: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("change listeners called %d times, want 1", changed)
+	}
+
+	// a broken file keeps the previous snapshot
+	if err := os.WriteFile(path, []byte(`[{"id":"p3"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Reload(); err == nil {
+		t.Fatal("Reload of a broken file succeeded")
+	}
+	if _, err := c.Get(ctx, "p2"); err != nil {
+		t.Errorf("Get(p2) after failed reload: %v", err)
+	}
+	if _, err := c.Get(ctx, "p3"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Get(p3) = %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestNewFileCatalogMissingFile(t *testing.T) {
+	if _, err := NewFileCatalog(filepath.Join(t.TempDir(), "missing.json"), 0); err == nil {
+		t.Fatal("NewFileCatalog of a missing file succeeded")
+	}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// HTTPCatalog is a client for the inventory service catalog API:
+//
+//	GET {base}/products?q=&category=&limit=  -> {"products": [...]} (ranked, each with "score")
+//	GET {base}/products/{id}                  -> product (404 when unknown)
+//	GET {base}/categories                     -> {"categories": [...]}
+type HTTPCatalog struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewHTTPCatalog creates a client for baseURL. apiKey (optional) is sent as a
+// Bearer token; timeout bounds each request.
+func NewHTTPCatalog(baseURL, apiKey string, timeout time.Duration) (*HTTPCatalog, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid catalog url %q", baseURL)
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &HTTPCatalog{baseURL: u.String(), apiKey: apiKey, client: &http.Client{Timeout: timeout}}, nil
+}
+
+func (c *HTTPCatalog) Search(ctx context.Context, q CatalogQuery) ([]SearchHit, error) {
+	params := url.Values{}
+	if q.Text != "" {
+		params.Set("q", q.Text)
+	}
+	if q.Category != "" {
+		params.Set("category", q.Category)
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	var out struct {
+		Products []SearchHit `json:"products"`
+	}
+	if err := c.get(ctx, "/products?"+params.Encode(), &out); err != nil {
+		return nil, err
+	}
+	return out.Products, nil
+}
+
+func (c *HTTPCatalog) Get(ctx context.Context, id string) (*model.Product, error) {
+	var p model.Product
+	if err := c.get(ctx, "/products/"+url.PathEscape(id), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (c *HTTPCatalog) ListCategories(ctx context.Context) ([]string, error) {
+	var out struct {
+		Categories []string `json:"categories"`
+	}
+	if err := c.get(ctx, "/categories", &out); err != nil {
+		return nil, err
+	}
+	return out.Categories, nil
+}
+
+// get performs a GET and decodes the JSON body into dst. 404 maps to ErrProductNotFound.
+func (c *HTTPCatalog) get(ctx context.Context, path string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("catalog request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("catalog request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrProductNotFound
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("catalog service returned %d: %s: %w", resp.StatusCode, strings.TrimSpace(string(body)), ErrTransient)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("catalog service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8<<20)).Decode(dst); err != nil {
+		return fmt.Errorf("decode catalog response: %w", err)
+	}
+	return nil
+}
+
+var _ ProductCatalog = (*HTTPCatalog)(nil)
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+func newTestHTTPCatalog(t *testing.T, handler http.Handler, apiKey string) *HTTPCatalog {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := NewHTTPCatalog(srv.URL, apiKey, time.Second)
+	if err != nil {
+		t.Fatalf("NewHTTPCatalog: %v", err)
+	}
+	return c
+}
+
+func TestHTTPCatalogAgainstFakeInventory(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryCatalog([]model.Product{
+		{ID: "p1", Name: "iPhone 15", Brand: "Apple", Category: "smartphones", Price: 32900, InStock: true},
+		{ID: "p2", Name: "Galaxy S24", Brand: "Samsung", Category: "smartphones", Price: 29900},
+		{ID: "p3", Name: "MacBook Air", Brand: "Apple", Category: "laptops", Price: 39900, Specifications: map[string]string{"ram": "16GB"}},
+	})
+	c := newTestHTTPCatalog(t, NewFakeInventoryHandler(backend, "secret"), "secret")
+
+	hits, err := c.Search(ctx, CatalogQuery{Category: "smartphones", Limit: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Category != "smartphones" {
+		t.Errorf("Search(category, limit 1) = %+v", hits)
+	}
+
+	hits, err = c.Search(ctx, CatalogQuery{Text: "macbook"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) == 0 || hits[0].ID != "p3" || hits[0].Score <= 0 {
+		t.Errorf("Search(macbook) = %+v, want p3 first with a score", hits)
+	}
+
+	p, err := c.Get(ctx, "p3")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if p.Name != "MacBook Air" || p.Specifications["ram"] != "16GB" {
+		t.Errorf("Get(p3) = %+v", p)
+	}
+	if _, err := c.Get(ctx, "nope"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Get(unknown) = %v, want ErrProductNotFound", err)
+	}
+
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories: %v", err)
+	}
+	if len(categories) != 2 || categories[0] != "laptops" || categories[1] != "smartphones" {
+		t.Errorf("ListCategories = %v", categories)
+	}
+}
+
+func TestHTTPCatalogErrors(t *testing.T) {
+	ctx := context.Background()
+	status := func(code int) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", code)
+		})
+	}
+	tests := []struct {
+		name          string
+		handler       http.Handler
+		apiKey        string
+		wantTransient bool
+	}{
+		{name: "server error", handler: status(http.StatusBadGateway), wantTransient: true},
+		{name: "rate limited", handler: status(http.StatusTooManyRequests), wantTransient: true},
+		{name: "bad request", handler: status(http.StatusBadRequest)},
+		{name: "wrong api key", handler: NewFakeInventoryHandler(NewMemoryCatalog(nil), "secret"), apiKey: "other"},
+		{name: "malformed body", handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"products":`))
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestHTTPCatalog(t, tt.handler, tt.apiKey)
+			_, err := c.Search(ctx, CatalogQuery{Text: "phone"})
+			if err == nil {
+				t.Fatal("Search succeeded")
+			}
+			if got := errors.Is(err, ErrTransient); got != tt.wantTransient {
+				t.Errorf("errors.Is(%v, ErrTransient) = %v, want %v", err, got, tt.wantTransient)
+			}
+		})
+	}
+}
+
+func TestNewHTTPCatalogRejectsInvalidURL(t *testing.T) {
+	for _, u := range []string{"", "inventory.local", "://bad"} {
+		if _, err := NewHTTPCatalog(u, "", 0); err == nil {
+			t.Errorf("NewHTTPCatalog(%q) succeeded", u)
+		}
+	}
+}
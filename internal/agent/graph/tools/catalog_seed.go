@@ -0,0 +1,188 @@
+package tools
+
+import "github.com/Chative-core-poc-v1/server/internal/agent/model"
+
+// DefaultProducts returns a copy of the built-in demo catalog used by the
+// memory backend when no catalog source is configured.
+func DefaultProducts() []model.Product {
+	out := make([]model.Product, len(seedProducts))
+	for i, p := range seedProducts {
+		out[i] = cloneProduct(p)
+	}
+	return out
+}
+
+// seedProducts is the demo catalog. Thai queries reach these English texts
+// through the search index's segmentation and synonyms; Details and
+// Specifications are returned by get_product_details.
+var seedProducts = []model.Product{
+	{
+		ID:          "prod-001",
+		Name:        "iPhone 15 Pro",
+		Brand:       "Apple",
+		Category:    "smartphones",
+		Price:       39900.00,
+		Description: "Latest iPhone smartphone with A17 Pro chip, titanium design, and advanced camera system",
+		Details:     "The iPhone 15 Pro features a titanium design, A17 Pro chip with 6-core GPU, advanced camera system with 48MP main camera, and USB-C connectivity.",
+		Specifications: map[string]string{
+			"battery":      "Up to 23 hours video playback",
+			"camera":       "48MP Main, 12MP Ultra Wide, 12MP Telephoto",
+			"chip":         "A17 Pro",
+			"color":        "Natural Titanium, Blue Titanium, White Titanium, Black Titanium",
+			"connectivity": "5G, WiFi 6E, Bluetooth 5.3",
+			"display":      "6.1-inch Super Retina XDR",
+			"storage":      "128GB, 256GB, 512GB, 1TB",
+		},
+		InStock: true,
+	},
+	{
+		ID:          "prod-002",
+		Name:        "Samsung Galaxy S24 Ultra",
+		Brand:       "Samsung",
+		Category:    "smartphones",
+		Price:       42900.00,
+		Description: "Premium Android smartphone with S Pen, 200MP camera, and AI features",
+		Details:     "Premium flagship with S Pen, 200MP camera, AI-powered features, and titanium frame for ultimate productivity and creativity.",
+		Specifications: map[string]string{
+			"battery":   "5000mAh with 45W fast charging",
+			"camera":    "200MP Wide, 50MP Periscope Telephoto, 10MP Telephoto, 12MP Ultra Wide",
+			"color":     "Titanium Gray, Titanium Black, Titanium Violet, Titanium Yellow",
+			"display":   "6.8-inch Dynamic AMOLED 2X",
+			"processor": "Snapdragon 8 Gen 3",
+			"s_pen":     "Built-in S Pen with Air Actions",
+			"storage":   "256GB, 512GB, 1TB",
+		},
+		InStock: true,
+	},
+	{
+		ID:          "prod-003",
+		Name:        "MacBook Air M3",
+		Brand:       "Apple",
+		Category:    "laptops",
+		Price:       42900.00,
+		Description: "Lightweight laptop with M3 chip, 13-inch Liquid Retina display for everyday work",
+		Details:     "The new MacBook Air with M3 chip delivers exceptional performance and battery life in an incredibly thin and light design.",
+		Specifications: map[string]string{
+			"battery": "Up to 18 hours",
+			"chip":    "Apple M3 with 8-core CPU and 10-core GPU",
+			"color":   "Space Gray, Silver, Starlight, Midnight",
+			"display": "13.6-inch Liquid Retina",
+			"memory":  "8GB, 16GB, 24GB unified memory",
+			"ports":   "2x Thunderbolt / USB 4, 3.5mm headphone jack, MagSafe 3",
+			"storage": "256GB, 512GB, 1TB, 2TB SSD",
+		},
+		InStock: false,
+	},
+	{
+		ID:          "prod-004",
+		Name:        "AirPods Pro (3rd generation)",
+		Brand:       "Apple",
+		Category:    "audio",
+		Price:       8900.00,
+		Description: "Wireless earbuds with active noise cancellation and spatial audio",
+		InStock:     true,
+	},
+	{
+		ID:          "prod-005",
+		Name:        "iPad Pro 12.9-inch",
+		Brand:       "Apple",
+		Category:    "tablets",
+		Price:       35900.00,
+		Description: "Professional tablet with M2 chip and Liquid Retina XDR display",
+		InStock:     true,
+	},
+	{
+		ID:          "prod-006",
+		Name:        "Sony WH-1000XM5",
+		Brand:       "Sony",
+		Category:    "audio",
+		Price:       12900.00,
+		Description: "Premium wireless headphones with industry-leading noise cancellation",
+		InStock:     true,
+	},
+	{
+		ID:          "prod-007",
+		Name:        "Dell XPS 13",
+		Brand:       "Dell",
+		Category:    "laptops",
+		Price:       35900.00,
+		Description: "Premium ultrabook laptop with Intel 13th Gen processors and InfinityEdge display for everyday work",
+		InStock:     true,
+	},
+	{
+		ID:          "prod-008",
+		Name:        "Apple Watch Ultra 2",
+		Brand:       "Apple",
+		Category:    "wearables",
+		Price:       29900.00,
+		Description: "Rugged smartwatch for outdoor adventures with precise GPS",
+		InStock:     false,
+	},
+	// เพิ่มโน้ตบุ๊คสำหรับงบประมาณ 30,000 บาท
+	{
+		ID:          "prod-009",
+		Name:        "Acer Aspire 5 A515-58",
+		Brand:       "Acer",
+		Category:    "laptops",
+		Price:       28900.00,
+		Description: "Budget laptop Intel Core i5, 8GB RAM, 512GB SSD for everyday work and light gaming",
+		Details:     "Budget-friendly laptop perfect for everyday tasks and light gaming. Features Intel Core i5 processor, 8GB RAM, and 512GB SSD storage.",
+		Specifications: map[string]string{
+			"battery":   "Up to 8 hours",
+			"color":     "Silver, Black",
+			"display":   "15.6-inch Full HD IPS",
+			"graphics":  "Intel Iris Xe Graphics",
+			"memory":    "8GB DDR4 RAM",
+			"processor": "Intel Core i5-1235U",
+			"storage":   "512GB NVMe SSD",
+		},
+		InStock: true,
+	},
+	{
+		ID:          "prod-010",
+		Name:        "Lenovo IdeaPad 3 Gaming",
+		Brand:       "Lenovo",
+		Category:    "laptops",
+		Price:       29500.00,
+		Description: "Gaming laptop AMD Ryzen 5, 8GB RAM, GTX 1650 for gaming and everyday work",
+		Details:     "Gaming laptop with AMD Ryzen 5 processor and dedicated graphics card. Perfect for gaming and multimedia tasks.",
+		Specifications: map[string]string{
+			"battery":   "Up to 6 hours",
+			"color":     "Shadow Black",
+			"display":   "15.6-inch Full HD 120Hz",
+			"graphics":  "NVIDIA GTX 1650 4GB",
+			"memory":    "8GB DDR4 RAM",
+			"processor": "AMD Ryzen 5 5500H",
+			"storage":   "512GB NVMe SSD",
+		},
+		InStock: true,
+	},
+	{
+		ID:          "prod-011",
+		Name:        "HP Pavilion 15-eh3000",
+		Brand:       "HP",
+		Category:    "laptops",
+		Price:       27900.00,
+		Description: "All-purpose laptop AMD Ryzen 5, 8GB RAM, 256GB SSD for everyday work and light gaming",
+		Details:     "Versatile laptop for work and light entertainment. AMD Ryzen 5 processor with good performance and battery life.",
+		Specifications: map[string]string{
+			"battery":   "Up to 9 hours",
+			"color":     "Natural Silver, Warm Gold",
+			"display":   "15.6-inch Full HD IPS",
+			"graphics":  "AMD Radeon Graphics",
+			"memory":    "8GB DDR4 RAM",
+			"processor": "AMD Ryzen 5 5625U",
+			"storage":   "256GB NVMe SSD",
+		},
+		InStock: true,
+	},
+	{
+		ID:          "prod-012",
+		Name:        "ASUS VivoBook 15 X1502ZA",
+		Brand:       "ASUS",
+		Category:    "laptops",
+		Price:       24900.00,
+		Description: "Affordable budget laptop Intel Core i3, 8GB RAM, 512GB SSD for light everyday work",
+		InStock:     true,
+	},
+}
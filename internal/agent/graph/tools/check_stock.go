@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ===================================
+// Check Stock Tool
+// ===================================
+
+const (
+	alternativePriceBand = 0.2 // alternatives cost within ±20% of the unavailable product
+	maxAlternatives      = 3
+)
+
+type CheckStockInput struct {
+	ProductID string `json:"product_id"`
+	Branch    string `json:"branch,omitempty"`
+}
+
+type BranchStock struct {
+	BranchID        string `json:"branch_id"`
+	BranchName      string `json:"branch_name"`
+	Kind            string `json:"kind"`
+	Available       int    `json:"available"`
+	Reserved        int    `json:"reserved,omitempty"` // units held for pending orders
+	RestockETA      string `json:"restock_eta,omitempty"`
+	RestockQuantity int    `json:"restock_quantity,omitempty"`
+}
+
+type CheckStockOutput struct {
+	ProductID      string          `json:"product_id"`
+	Name           string          `json:"name"`
+	InStock        bool            `json:"in_stock"`
+	Tracked        bool            `json:"tracked"`            // false: no branch data, in_stock comes from the catalog
+	TotalAvailable int             `json:"total_available"`    // across all branches
+	Branches       []BranchStock   `json:"branches,omitempty"` // only the requested branch when one is given
+	NextRestock    string          `json:"next_restock,omitempty"`
+	Alternatives   []model.Product `json:"alternatives,omitempty"` // in-stock products of the same category and price band
+}
+
+func init() {
+	Register(Registration{
+		Name:     ToolCheckStock,
+		Category: CategoryQuery,
+		Order:    60,
+		Timeout:  3 * time.Second,
+		Owner:    "inventory",
+		Guidance: []string{"Customer asks about availability, branches or restock → call " + ToolCheckStock + "; when out of stock, give the restock date and offer the returned alternatives"},
+		New:      func(d Dependencies) tool.BaseTool { return createCheckStockTool(d.Catalog, d.Inventory) },
+	})
+}
+
+func createCheckStockTool(catalog ProductCatalog, inventory Inventory) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolCheckStock,
+			Desc: "Check stock availability of a product per branch/warehouse: available units, reserved units, restock date and quantity. When the product is unavailable, also returns in-stock alternatives from the same category and price band. Use this tool when customer asks if a product is available, where to buy it, or when it will be back (มีของไหม, สาขาไหนมี, ของเข้าเมื่อไหร่).",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"product_id": {
+					Type:     "string",
+					Desc:     "Product ID obtained from search_product results (e.g., prod-003). Must be exact ID from search results.",
+					Required: true,
+				},
+				"branch": {
+					Type: "string",
+					Desc: "Optional branch ID or name fragment (e.g., siam, ladprao, chiang mai, warehouse)",
+				},
+			}),
+		},
+		func(ctx context.Context, in *CheckStockInput) (*CheckStockOutput, error) {
+			if in.ProductID == "" {
+				return nil, fmt.Errorf("%w: product_id is required", ErrInvalidRequest)
+			}
+			product, err := catalog.Get(ctx, in.ProductID)
+			if errors.Is(err, ErrProductNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrProductNotFound, in.ProductID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get product %s: %w", in.ProductID, err)
+			}
+
+			levels, err := inventory.Stock(ctx, product.ID)
+			if err != nil {
+				return nil, fmt.Errorf("check stock %s: %w", product.ID, err)
+			}
+
+			out := &CheckStockOutput{ProductID: product.ID, Name: product.Name, Tracked: len(levels) > 0}
+			if out.Tracked {
+				// totals cover every branch; the branch filter only narrows the list
+				total, next := totalAvailable(levels)
+				out.TotalAvailable = total
+				out.InStock = total > 0
+				if next != nil {
+					out.NextRestock = next.Format(time.DateOnly)
+				}
+				if in.Branch != "" {
+					levels = filterBranches(levels, in.Branch)
+				}
+				for _, l := range levels {
+					bs := BranchStock{
+						BranchID:   l.Branch.ID,
+						BranchName: l.Branch.Name,
+						Kind:       l.Branch.Kind,
+						Available:  l.Available,
+						Reserved:   l.Reserved,
+					}
+					if l.RestockETA != nil {
+						bs.RestockETA = l.RestockETA.Format(time.DateOnly)
+						bs.RestockQuantity = l.RestockQuantity
+					}
+					out.Branches = append(out.Branches, bs)
+				}
+			} else {
+				out.InStock = product.InStock
+			}
+
+			if !out.InStock {
+				alts, err := findAlternatives(ctx, catalog, inventory, *product)
+				if err != nil {
+					return nil, err
+				}
+				out.Alternatives = alts
+			}
+			return out, nil
+		},
+	)
+}
+
+// filterBranches keeps levels whose branch ID, name or kind contains query.
+func filterBranches(levels []StockLevel, query string) []StockLevel {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var out []StockLevel
+	for _, l := range levels {
+		if strings.Contains(strings.ToLower(l.Branch.ID), q) ||
+			strings.Contains(strings.ToLower(l.Branch.Name), q) ||
+			strings.EqualFold(l.Branch.Kind, q) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// availableUnits returns the units available across branches; tracked is
+// false when the inventory has no data for p (use p.InStock instead).
+func availableUnits(ctx context.Context, inventory Inventory, p model.Product) (units int, tracked bool, err error) {
+	levels, err := inventory.Stock(ctx, p.ID)
+	if err != nil {
+		return 0, false, fmt.Errorf("check stock %s: %w", p.ID, err)
+	}
+	if len(levels) == 0 {
+		return 0, false, nil
+	}
+	units, _ = totalAvailable(levels)
+	return units, true, nil
+}
+
+// liveQuote prices p with the active promotions (and coupon, when set) and
+// reports live inventory in InStock, so every tool quotes the same price and
+// availability.
+func liveQuote(ctx context.Context, pricer *Pricer, inventory Inventory, p model.Product, coupon string) (*PriceQuote, error) {
+	quote, err := pricer.Quote(ctx, p, coupon)
+	if err != nil {
+		return nil, err
+	}
+	units, tracked, err := availableUnits(ctx, inventory, p)
+	if err != nil {
+		return nil, err
+	}
+	if tracked {
+		quote.InStock = units > 0
+	}
+	return quote, nil
+}
+
+// applyAvailability overwrites InStock with live inventory for tracked products.
+func applyAvailability(ctx context.Context, inventory Inventory, hits []SearchHit) error {
+	for i := range hits {
+		units, tracked, err := availableUnits(ctx, inventory, hits[i].Product)
+		if err != nil {
+			return err
+		}
+		if tracked {
+			hits[i].InStock = units > 0
+		}
+	}
+	return nil
+}
+
+// findAlternatives returns up to maxAlternatives available products of p's
+// category priced within alternativePriceBand of p, closest price first.
+func findAlternatives(ctx context.Context, catalog ProductCatalog, inventory Inventory, p model.Product) ([]model.Product, error) {
+	if p.Category == "" {
+		return nil, nil
+	}
+	candidates, err := catalog.Search(ctx, CatalogQuery{Category: p.Category})
+	if err != nil {
+		return nil, fmt.Errorf("search alternatives: %w", err)
+	}
+	lo, hi := p.Price*(1-alternativePriceBand), p.Price*(1+alternativePriceBand)
+
+	var out []model.Product
+	for _, c := range candidates {
+		if c.ID == p.ID || c.Price < lo || c.Price > hi {
+			continue
+		}
+		units, tracked, err := availableUnits(ctx, inventory, c.Product)
+		if err != nil {
+			return nil, err
+		}
+		if (tracked && units == 0) || (!tracked && !c.InStock) {
+			continue
+		}
+		c.InStock = true
+		out = append(out, productSummary(c.Product))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return math.Abs(out[i].Price-p.Price) < math.Abs(out[j].Price-p.Price)
+	})
+	if len(out) > maxAlternatives {
+		out = out[:maxAlternatives]
+	}
+	return out, nil
+}
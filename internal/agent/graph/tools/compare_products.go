@@ -0,0 +1,351 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ===================================
+// Compare Products Tool
+// ===================================
+
+const (
+	minCompareProducts = 2
+	maxCompareProducts = 5
+)
+
+// notAvailable fills comparison cells for products lacking an attribute.
+const notAvailable = "n/a"
+
+// specField is one attribute of the canonical comparison schema.
+type specField struct {
+	Key     string
+	Label   string
+	Aliases []string // catalog spec keys mapped onto Key
+	Higher  bool     // larger capacity is better (compared with ParseCapacityGB)
+}
+
+// canonicalSpecs is the comparison schema, in display order. Catalog spec
+// keys are matched case-insensitively; unknown keys are compared as-is after
+// the canonical ones.
+var canonicalSpecs = []specField{
+	{Key: "processor", Label: "Processor", Aliases: []string{"processor", "chip", "cpu", "chipset", "soc"}},
+	{Key: "graphics", Label: "Graphics", Aliases: []string{"graphics", "gpu", "graphics_card"}},
+	{Key: "memory", Label: "Memory", Aliases: []string{"memory", "ram"}, Higher: true},
+	{Key: "storage", Label: "Storage", Aliases: []string{"storage", "ssd", "capacity"}, Higher: true},
+	{Key: "display", Label: "Display", Aliases: []string{"display", "screen"}},
+	{Key: "camera", Label: "Camera", Aliases: []string{"camera", "cameras"}},
+	{Key: "battery", Label: "Battery", Aliases: []string{"battery", "battery_life"}},
+	{Key: "connectivity", Label: "Connectivity", Aliases: []string{"connectivity", "network", "wireless"}},
+	{Key: "ports", Label: "Ports", Aliases: []string{"ports", "io"}},
+	{Key: "weight", Label: "Weight", Aliases: []string{"weight"}},
+	{Key: "color", Label: "Colors", Aliases: []string{"color", "colors", "colour"}},
+}
+
+// canonicalSpecKey maps a catalog spec key onto the comparison schema.
+func canonicalSpecKey(key string) string {
+	k := strings.ToLower(strings.TrimSpace(key))
+	k = strings.NewReplacer(" ", "_", "-", "_").Replace(k)
+	for _, f := range canonicalSpecs {
+		for _, a := range f.Aliases {
+			if k == a {
+				return f.Key
+			}
+		}
+	}
+	return k
+}
+
+// normalizeSpecs re-keys specs onto the canonical schema. When several keys
+// map to the same attribute the first in sorted key order wins.
+func normalizeSpecs(specs map[string]string) map[string]string {
+	keys := make([]string, 0, len(specs))
+	for k := range specs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make(map[string]string, len(specs))
+	for _, k := range keys {
+		v := strings.TrimSpace(specs[k])
+		if v == "" {
+			continue
+		}
+		ck := canonicalSpecKey(k)
+		if _, dup := out[ck]; !dup {
+			out[ck] = v
+		}
+	}
+	return out
+}
+
+type CompareProductsInput struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+type ComparedProduct struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	Brand             string  `json:"brand,omitempty"`
+	Price             float64 `json:"price"`                    // after active promotions
+	OriginalPrice     float64 `json:"original_price,omitempty"` // list price, only when discounted
+	InStock           bool    `json:"in_stock"`                 // live inventory when tracked
+	PriceDelta        float64 `json:"price_delta"`              // THB above the cheapest compared product
+	PriceDeltaPercent float64 `json:"price_delta_percent"`      // PriceDelta relative to the cheapest price
+}
+
+// ComparisonRow is one attribute across the compared products; Values align
+// with CompareProductsOutput.Products.
+type ComparisonRow struct {
+	Attribute string   `json:"attribute"`
+	Label     string   `json:"label"`
+	Values    []string `json:"values"`
+	Differs   bool     `json:"differs"`
+	Best      string   `json:"best,omitempty"` // product ID with the best value (lowest price, largest memory/storage) when it differs
+}
+
+type CompareProductsOutput struct {
+	Products    []ComparedProduct `json:"products"`
+	Rows        []ComparisonRow   `json:"rows"`
+	Differences []string          `json:"differences"` // labels of rows whose values differ
+	Cheapest    string            `json:"cheapest"`
+	NotFound    []string          `json:"not_found,omitempty"`
+}
+
+func init() {
+	Register(Registration{
+		Name:      ToolCompareProducts,
+		Category:  CategoryQuery,
+		Order:     30,
+		Timeout:   5 * time.Second,
+		Owner:     "catalog",
+		CacheTTL:  time.Minute, // promotions start and end on the minute
+		LiveStock: true,
+		Guidance:  []string{"Customer compares 2–5 products → call " + ToolCompareProducts + " with their product_ids; present the differing rows and price differences"},
+		New:       func(d Dependencies) tool.BaseTool { return createCompareProductsTool(d.Catalog, d.Pricer, d.Inventory) },
+	})
+}
+
+func createCompareProductsTool(catalog ProductCatalog, pricer *Pricer, inventory Inventory) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolCompareProducts,
+			Desc: "Compare 2-5 products side by side. Returns a normalized spec table (processor, graphics, memory, storage, display, camera, battery, ...) with rows that differ highlighted, the best value per row, and price differences relative to the cheapest product. Use this tool when customer asks to compare models or which one is better (เปรียบเทียบ, ต่างกันยังไง, รุ่นไหนดีกว่า).",
+			ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
+				"product_ids": {
+					Type:     "array",
+					ElemInfo: &schema.ParameterInfo{Type: "string"},
+					Desc:     "2-5 product IDs from search_product results (e.g., [\"prod-009\", \"prod-010\"]). Must be exact IDs from search results.",
+					Required: true,
+				},
+			}, map[string]paramLimits{
+				"product_ids": itemsBetween(minCompareProducts, maxCompareProducts),
+			}),
+		},
+		func(ctx context.Context, in *CompareProductsInput) (*CompareProductsOutput, error) {
+			ids := dedupeIDs(in.ProductIDs)
+			if len(ids) < minCompareProducts || len(ids) > maxCompareProducts {
+				return nil, fmt.Errorf("%w: product_ids must contain %d-%d distinct IDs, got %d", ErrInvalidRequest, minCompareProducts, maxCompareProducts, len(ids))
+			}
+
+			var (
+				products []model.Product
+				quotes   []*PriceQuote
+				notFound []string
+			)
+			for _, id := range ids {
+				p, err := catalog.Get(ctx, id)
+				if errors.Is(err, ErrProductNotFound) {
+					notFound = append(notFound, id)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("get product %s: %w", id, err)
+				}
+				quote, err := liveQuote(ctx, pricer, inventory, *p, "")
+				if err != nil {
+					return nil, err
+				}
+				products = append(products, *p)
+				quotes = append(quotes, quote)
+			}
+			if len(products) < minCompareProducts {
+				return nil, fmt.Errorf("need at least %d existing products to compare: %w: %s", minCompareProducts, ErrProductNotFound, strings.Join(notFound, ", "))
+			}
+
+			result := compareProducts(products, quotes)
+			result.NotFound = notFound
+			return result, nil
+		},
+	)
+}
+
+// compareProducts builds the side-by-side table for products (at least one);
+// quotes align with products and supply the current price and availability.
+func compareProducts(products []model.Product, quotes []*PriceQuote) *CompareProductsOutput {
+	out := &CompareProductsOutput{Differences: []string{}}
+
+	cheapest := quotes[0]
+	for _, q := range quotes[1:] {
+		if q.CurrentPrice < cheapest.CurrentPrice {
+			cheapest = q
+		}
+	}
+	out.Cheapest = cheapest.ProductID
+
+	specs := make([]map[string]string, len(products))
+	for i, p := range products {
+		specs[i] = normalizeSpecs(p.Specifications)
+		q := quotes[i]
+		cp := ComparedProduct{
+			ID:         p.ID,
+			Name:       p.Name,
+			Brand:      p.Brand,
+			Price:      q.CurrentPrice,
+			InStock:    q.InStock,
+			PriceDelta: q.CurrentPrice - cheapest.CurrentPrice,
+		}
+		if q.Discount > 0 {
+			cp.OriginalPrice = q.OriginalPrice
+		}
+		if cheapest.CurrentPrice > 0 {
+			cp.PriceDeltaPercent = math.Round(cp.PriceDelta/cheapest.CurrentPrice*1000) / 10
+		}
+		out.Products = append(out.Products, cp)
+	}
+
+	addRow := func(row ComparisonRow) {
+		missing := 0
+		for _, v := range row.Values {
+			if v == notAvailable {
+				missing++
+			}
+		}
+		if missing == len(row.Values) {
+			return
+		}
+		row.Differs = !allEqualFold(row.Values)
+		if row.Differs {
+			out.Differences = append(out.Differences, row.Label)
+		} else {
+			row.Best = ""
+		}
+		out.Rows = append(out.Rows, row)
+	}
+
+	priceRow := ComparisonRow{Attribute: "price", Label: "Price (THB)", Best: cheapest.ProductID}
+	stockRow := ComparisonRow{Attribute: "in_stock", Label: "In stock"}
+	brandRow := ComparisonRow{Attribute: "brand", Label: "Brand"}
+	categoryRow := ComparisonRow{Attribute: "category", Label: "Category"}
+	for i, p := range products {
+		priceRow.Values = append(priceRow.Values, formatPrice(quotes[i].CurrentPrice))
+		stockRow.Values = append(stockRow.Values, fmt.Sprintf("%v", quotes[i].InStock))
+		brandRow.Values = append(brandRow.Values, orNotAvailable(p.Brand))
+		categoryRow.Values = append(categoryRow.Values, orNotAvailable(p.Category))
+	}
+	addRow(priceRow)
+	addRow(stockRow)
+	addRow(brandRow)
+	addRow(categoryRow)
+
+	known := map[string]bool{}
+	for _, f := range canonicalSpecs {
+		known[f.Key] = true
+		row := ComparisonRow{Attribute: f.Key, Label: f.Label}
+		for _, s := range specs {
+			row.Values = append(row.Values, orNotAvailable(s[f.Key]))
+		}
+		if f.Higher {
+			row.Best = largestCapacity(products, row.Values)
+		}
+		addRow(row)
+	}
+
+	// Remaining catalog-specific keys (e.g. s_pen), alphabetically
+	var extra []string
+	seen := map[string]bool{}
+	for _, s := range specs {
+		for k := range s {
+			if !known[k] && !seen[k] {
+				seen[k] = true
+				extra = append(extra, k)
+			}
+		}
+	}
+	sort.Strings(extra)
+	for _, k := range extra {
+		row := ComparisonRow{Attribute: k, Label: strings.ReplaceAll(k, "_", " ")}
+		for _, s := range specs {
+			row.Values = append(row.Values, orNotAvailable(s[k]))
+		}
+		addRow(row)
+	}
+	return out
+}
+
+// largestCapacity returns the ID of the product with the strictly largest
+// capacity in values, or "" when unparseable or tied.
+func largestCapacity(products []model.Product, values []string) string {
+	best, bestID, tied := -1.0, "", false
+	for i, v := range values {
+		gb, ok := ParseCapacityGB(v)
+		if !ok {
+			continue
+		}
+		switch {
+		case gb > best:
+			best, bestID, tied = gb, products[i].ID, false
+		case gb == best:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return bestID
+}
+
+func allEqualFold(values []string) bool {
+	for _, v := range values[1:] {
+		if !strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(values[0])) {
+			return false
+		}
+	}
+	return true
+}
+
+func orNotAvailable(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return notAvailable
+	}
+	return s
+}
+
+func formatPrice(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// dedupeIDs trims ids and drops empties and repeats, keeping order.
+func dedupeIDs(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
+)
+
+func TestCompareAndDetailsQuoteLivePriceAndStock(t *testing.T) {
+	ctx := context.Background()
+	catalog := NewMemoryCatalog([]model.Product{
+		{ID: "prod-a", Name: "Phone A", Category: "smartphone", Price: 30000, InStock: true},
+		{ID: "prod-b", Name: "Phone B", Category: "smartphone", Price: 28000, InStock: true},
+	})
+	pricer := NewPricer(repo.NewMemoryPromotionStore([]model.Promotion{
+		{ID: "promo-a", Title: "Phone A sale", Kind: model.PromotionDiscount, ProductIDs: []string{"prod-a"}, AmountOff: 5000},
+	}))
+	// prod-a is sold out at its only branch; prod-b has no branch data
+	inventory := NewMemoryInventory(
+		[]Branch{{ID: "siam", Name: "Siam", Kind: "store"}},
+		[]StockRecord{{ProductID: "prod-a", BranchID: "siam", OnHand: 0}},
+	)
+
+	compare := createCompareProductsTool(catalog, pricer, inventory).(tool.InvokableTool)
+	raw, err := compare.InvokableRun(ctx, `{"product_ids":["prod-a","prod-b"]}`)
+	if err != nil {
+		t.Fatalf("compare_products: %v", err)
+	}
+	var cmp CompareProductsOutput
+	if err := json.Unmarshal([]byte(raw), &cmp); err != nil {
+		t.Fatalf("decode compare_products: %v", err)
+	}
+	if cmp.Cheapest != "prod-a" {
+		t.Errorf("cheapest = %q, want prod-a after its discount", cmp.Cheapest)
+	}
+	a := cmp.Products[0]
+	if a.Price != 25000 || a.OriginalPrice != 30000 || a.InStock {
+		t.Errorf("compared prod-a = price %v, original %v, in stock %v; want 25000, 30000, false", a.Price, a.OriginalPrice, a.InStock)
+	}
+	if b := cmp.Products[1]; b.Price != 28000 || b.OriginalPrice != 0 || !b.InStock {
+		t.Errorf("compared prod-b = price %v, original %v, in stock %v; want 28000, 0, true", b.Price, b.OriginalPrice, b.InStock)
+	}
+
+	details := createGetProductDetailsTool(catalog, pricer, inventory).(tool.InvokableTool)
+	raw, err = details.InvokableRun(ctx, `{"product_id":"prod-a"}`)
+	if err != nil {
+		t.Fatalf("get_product_details: %v", err)
+	}
+	var det GetProductDetailsOutput
+	if err := json.Unmarshal([]byte(raw), &det); err != nil {
+		t.Fatalf("decode get_product_details: %v", err)
+	}
+	if det.Price != 25000 || det.OriginalPrice != 30000 || det.InStock {
+		t.Errorf("details prod-a = price %v, original %v, in stock %v; want 25000, 30000, false", det.Price, det.OriginalPrice, det.InStock)
+	}
+}
@@ -1,7 +1,18 @@
 package tools
 
-// Tool constants - these should match the actual tool names defined in the tool files
+// Tool names, shared by the tool infos, their registrations and the prompts
 const (
 	ToolSearchProduct     = "search_product"
 	ToolGetProductDetails = "get_product_details"
+	ToolCompareProducts   = "compare_products"
+	ToolGetProductPrice   = "get_product_price"
+	ToolListPromotions    = "list_promotions"
+	ToolCheckStock        = "check_stock"
+	ToolAddToCart         = "add_to_cart"
+	ToolViewCart          = "view_cart"
+	ToolRemoveFromCart    = "remove_from_cart"
+	ToolCreateOrder       = "create_order"
+	ToolGetOrderStatus    = "get_order_status"
+	ToolTrackShipment     = "track_shipment"
+	ToolSearchKnowledge   = "search_knowledge_base"
 )
@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+type callSlotsKey struct{}
+
+// callSlots are shared by the tool calls of one query.
+type callSlots struct {
+	slots  chan struct{} // nil = unbounded
+	effect sync.Mutex    // side-effecting calls run one at a time
+}
+
+// WithConcurrencyLimit bounds how many tool calls made under ctx run at
+// once (n <= 0 = unbounded); the graph runner sets it per query so parallel
+// tool calls of one message share n slots. Side-effecting calls under ctx
+// also never overlap each other, since they update the same cart.
+func WithConcurrencyLimit(ctx context.Context, n int) context.Context {
+	cs := &callSlots{}
+	if n > 0 {
+		cs.slots = make(chan struct{}, n)
+	}
+	return context.WithValue(ctx, callSlotsKey{}, cs)
+}
+
+type callNotesKey struct{}
+
+// callNotes collect what the layers of one call report about it (e.g. a
+// cache hit); they reach the tool callbacks as CallbackOutput.Extra.
+type callNotes struct {
+	mu    sync.Mutex
+	extra map[string]any
+}
+
+// annotate records a note about the running tool call.
+func annotate(ctx context.Context, key string, value any) {
+	if n, ok := ctx.Value(callNotesKey{}).(*callNotes); ok {
+		n.mu.Lock()
+		n.extra[key] = value
+		n.mu.Unlock()
+	}
+}
+
+// IsolateFailures wraps the invokable tools of ts so that each call waits for
+// a slot under the context's concurrency limit, and a failed call becomes a
+// tool_failed (or request_rejected) result instead of an error. One failing call then no longer
+// aborts the run: the model gets the results of the calls that succeeded and
+// can answer with those. Cancellation of the run and interrupts still return
+// their errors. The wrapped tools emit their own tool callbacks, whose output
+// Extra carries the call's notes (cache_hit, tool_failed).
+func IsolateFailures(ts []tool.BaseTool) []tool.BaseTool {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		if it, ok := t.(tool.InvokableTool); ok {
+			out[i] = &isolatedTool{InvokableTool: it, exclusive: sideEffecting(t)}
+		} else {
+			out[i] = t
+		}
+	}
+	return out
+}
+
+// sideEffecting reports whether t is registered as side-effecting.
+func sideEffecting(t tool.BaseTool) bool {
+	info, err := t.Info(context.Background())
+	return err == nil && RiskOf(info.Name) == RiskSideEffect
+}
+
+type isolatedTool struct {
+	tool.InvokableTool
+	exclusive bool // side-effecting; holds the query's effect lock
+}
+
+// IsCallbacksEnabled tells the tools node not to wrap the tool in callbacks;
+// InvokableRun emits them with the call's notes.
+func (t *isolatedTool) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (t *isolatedTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	ctx = callbacks.OnStart(ctx, &tool.CallbackInput{ArgumentsInJSON: arguments})
+	notes := &callNotes{extra: map[string]any{}}
+	ctx = context.WithValue(ctx, callNotesKey{}, notes)
+
+	out, err := t.run(ctx, arguments, opts...)
+	if err != nil {
+		callbacks.OnError(ctx, err)
+		return "", err
+	}
+	notes.mu.Lock()
+	extra := notes.extra
+	notes.mu.Unlock()
+	callbacks.OnEnd(ctx, &tool.CallbackOutput{Response: out, Extra: extra})
+	return out, nil
+}
+
+func (t *isolatedTool) run(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	if cs, ok := ctx.Value(callSlotsKey{}).(*callSlots); ok {
+		if t.exclusive {
+			cs.effect.Lock()
+			defer cs.effect.Unlock()
+		}
+		if cs.slots != nil {
+			select {
+			case cs.slots <- struct{}{}:
+				defer func() { <-cs.slots }()
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+	}
+
+	out, err := t.InvokableTool.InvokableRun(ctx, arguments, opts...)
+	if err == nil || ctx.Err() != nil {
+		return out, err
+	}
+	if _, ok := compose.IsInterruptRerunError(err); ok {
+		return out, err
+	}
+	info, ierr := t.Info(ctx)
+	if ierr != nil {
+		return out, err
+	}
+	logx.Warn().
+		Err(err).
+		Str("tool_name", info.Name).
+		Str("tool_call_id", compose.GetToolCallID(ctx)).
+		Msg("Tool call failed; returning the error to the model")
+	annotate(ctx, "tool_failed", true)
+	return failedResult(info.Name, err), nil
+}
+
+// failedResult is the tool result for a call that failed. Answers such as an
+// unknown ID or a missing argument are request_rejected, so the model can
+// correct the call; outages are tool_failed and must not be retried.
+func failedResult(name string, err error) string {
+	kind := "tool_failed"
+	hint := "This call failed; do not call this tool again this turn. Answer with the results of the other calls, tell the customer what could not be looked up and offer human help."
+	if Answered(err) && !Retryable(err) {
+		kind = "request_rejected"
+		hint = "The request was rejected, not the tool down. If the arguments were wrong, correct them and call once more; otherwise tell the customer why."
+	}
+	b, merr := json.Marshal(map[string]any{
+		"error":  kind,
+		"tool":   name,
+		"detail": err.Error(),
+		"hint":   hint,
+	})
+	if merr != nil {
+		return fmt.Sprintf(`{"error":%q,"tool":%q}`, kind, name)
+	}
+	return string(b)
+}
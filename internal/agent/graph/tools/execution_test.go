@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFailedResultHint(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		kind    string
+		noRetry bool
+	}{
+		{"outage", fmt.Errorf("status 503: %w", ErrTransient), "tool_failed", true},
+		{"unclassified error", errors.New("decode response: unexpected EOF"), "tool_failed", true},
+		{"unknown product", fmt.Errorf("%w: prod-999", ErrProductNotFound), "request_rejected", false},
+		{"missing argument", fmt.Errorf("%w: product_id is required", ErrInvalidRequest), "request_rejected", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res struct {
+				Error string `json:"error"`
+				Hint  string `json:"hint"`
+			}
+			if err := json.Unmarshal([]byte(failedResult("check_stock", tt.err)), &res); err != nil {
+				t.Fatalf("result is not JSON: %v", err)
+			}
+			if res.Error != tt.kind {
+				t.Errorf("error = %q, want %q", res.Error, tt.kind)
+			}
+			if got := strings.Contains(res.Hint, "do not call this tool again"); got != tt.noRetry {
+				t.Errorf("hint %q: no-retry = %v, want %v", res.Hint, got, tt.noRetry)
+			}
+		})
+	}
+}
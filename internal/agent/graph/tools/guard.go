@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// GuardCalls wraps the invokable tools of ts so that calls refused before the
+// tools node ran (AppState.ToolCallOverrides, set by the ToolExecutor
+// pre-handler for repeated calls, calls over a tool's cap and calls after the
+// turn budget) return their stand-in result without running. Outside a graph
+// run every call runs.
+//
+// Calls are matched by OverrideKey, not by ID alone: providers may reuse one
+// ID for several calls of a message (Gemini sets it to the tool name).
+func GuardCalls(ts []tool.BaseTool) []tool.BaseTool {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		if it, ok := t.(tool.InvokableTool); ok {
+			out[i] = &guardedTool{InvokableTool: it}
+		} else {
+			out[i] = t
+		}
+	}
+	return out
+}
+
+type guardedTool struct {
+	tool.InvokableTool
+}
+
+// OverrideKey identifies a refused call by its ID, tool name and canonical
+// arguments.
+func OverrideKey(callID, name, arguments string) string {
+	return callID + "\x00" + CallKey(name, arguments)
+}
+
+func (t *guardedTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	info, err := t.Info(ctx)
+	if err != nil {
+		return "", err
+	}
+	if result, ok := overrideFromContext(ctx, info.Name, arguments); ok {
+		annotate(ctx, "refused", true)
+		logx.Debug().
+			Str("tool_call_id", compose.GetToolCallID(ctx)).
+			Msg("Tool call refused; returning its stand-in result")
+		return result, nil
+	}
+	return t.InvokableTool.InvokableRun(ctx, arguments, opts...)
+}
+
+// overrideFromContext takes the stand-in result of the running tool call, if
+// it was refused. Identical calls sharing an ID take the results in turn.
+func overrideFromContext(ctx context.Context, name, arguments string) (string, bool) {
+	id := compose.GetToolCallID(ctx)
+	if id == "" {
+		return "", false
+	}
+	key := OverrideKey(id, name, arguments)
+	var (
+		result string
+		ok     bool
+	)
+	_ = compose.ProcessState(ctx, func(_ context.Context, s *model.AppState) error {
+		queue := s.ToolCallOverrides[key]
+		if len(queue) == 0 {
+			return nil
+		}
+		result, ok = queue[0], true
+		if len(queue) == 1 {
+			delete(s.ToolCallOverrides, key)
+		} else {
+			s.ToolCallOverrides[key] = queue[1:]
+		}
+		return nil
+	})
+	return result, ok
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+func TestGuardCallsMatchesCallsSharingAnID(t *testing.T) {
+	ctx := context.Background()
+	lookup := &failingTool{name: "lookup"}
+	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{
+		Tools:               GuardCalls([]tool.BaseTool{lookup}),
+		ExecuteSequentially: true,
+	})
+	if err != nil {
+		t.Fatalf("NewToolNode: %v", err)
+	}
+	state := &model.AppState{ToolCallOverrides: map[string][]string{
+		OverrideKey("lookup", "lookup", `{"id":"b"}`): {`{"error":"tool_call_limit","tool":"lookup"}`},
+	}}
+	g := compose.NewGraph[*schema.Message, []*schema.Message](compose.WithGenLocalState(func(context.Context) *model.AppState {
+		return state
+	}))
+	_ = g.AddToolsNode("tools", toolsNode)
+	_ = g.AddEdge(compose.START, "tools")
+	_ = g.AddEdge("tools", compose.END)
+	r, err := g.Compile(ctx)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	// both calls carry the tool name as ID, as Gemini sends them
+	msg := &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{
+		{ID: "lookup", Function: schema.FunctionCall{Name: "lookup", Arguments: `{"id":"a"}`}},
+		{ID: "lookup", Function: schema.FunctionCall{Name: "lookup", Arguments: `{"id": "b"}`}},
+	}}
+	out, err := r.Invoke(ctx, msg)
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if got := out[0].Content; got != `{"ok":true}` {
+		t.Errorf("first call = %s, want it to run", got)
+	}
+	if got := out[1].Content; got != `{"error":"tool_call_limit","tool":"lookup"}` {
+		t.Errorf("second call = %s, want its stand-in result", got)
+	}
+	if lookup.calls != 1 {
+		t.Errorf("tool ran %d times, want 1", lookup.calls)
+	}
+	if len(state.ToolCallOverrides) != 0 {
+		t.Errorf("overrides left = %v, want the used one removed", state.ToolCallOverrides)
+	}
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// applyOpenAPI fills the method, URL, description and parameters of s from
+// operation s.Operation of the OpenAPI 3 document at path. Params already
+// given in the spec override the document's.
+func (s *HTTPToolSpec) applyOpenAPI(path string) error {
+	if s.Operation == "" {
+		return fmt.Errorf("openapi %s: operation is required", s.OpenAPI)
+	}
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("load openapi %s: %w", path, err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return fmt.Errorf("invalid openapi %s: %w", path, err)
+	}
+
+	urlPath, method, op := findOperation(doc, s.Operation)
+	if op == nil {
+		return fmt.Errorf("openapi %s: no operation %q", path, s.Operation)
+	}
+	base := os.ExpandEnv(s.BaseURL)
+	if base == "" && len(doc.Servers) > 0 {
+		base = doc.Servers[0].URL
+	}
+	if base == "" {
+		return fmt.Errorf("openapi %s: no server url; set base_url", path)
+	}
+	s.Method = method
+	s.URL = strings.TrimRight(base, "/") + urlPath
+	if s.Description == "" {
+		s.Description = strings.TrimSpace(strings.Join(nonEmpty(op.Summary, op.Description), ". "))
+	}
+
+	params := map[string]HTTPParam{}
+	all := append(append(openapi3.Parameters{}, doc.Paths[urlPath].Parameters...), op.Parameters...)
+	for _, ref := range all {
+		p := ref.Value
+		if p == nil || p.In == openapi3.ParameterInCookie {
+			continue
+		}
+		// credentials come from the spec's auth, never from the model
+		if p.In == openapi3.ParameterInHeader && strings.EqualFold(p.Name, "Authorization") {
+			continue
+		}
+		hp := httpParamOf(p.Schema, p.Description)
+		hp.Required, hp.In = p.Required, p.In
+		params[p.Name] = hp
+	}
+	if body := op.RequestBody; body != nil && body.Value != nil {
+		if media := body.Value.Content.Get("application/json"); media != nil && media.Schema != nil && media.Schema.Value != nil {
+			required := map[string]bool{}
+			for _, name := range media.Schema.Value.Required {
+				required[name] = true
+			}
+			for name, prop := range media.Schema.Value.Properties {
+				hp := httpParamOf(prop, "")
+				hp.Required, hp.In = required[name] && body.Value.Required, ParamInBody
+				params[name] = hp
+			}
+		}
+	}
+	for name, p := range s.Params {
+		params[name] = p
+	}
+	s.Params = params
+	return nil
+}
+
+// findOperation returns the path, method and operation with the given ID.
+func findOperation(doc *openapi3.T, id string) (string, string, *openapi3.Operation) {
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		for method, op := range doc.Paths[p].Operations() {
+			if op.OperationID == id {
+				return p, method, op
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// httpParamOf converts an OpenAPI schema to a tool parameter.
+func httpParamOf(ref *openapi3.SchemaRef, desc string) HTTPParam {
+	var p HTTPParam
+	if ref == nil || ref.Value == nil {
+		p.Description = desc
+		return p
+	}
+	sc := ref.Value
+	p.Type = sc.Type
+	p.Description = strings.TrimSpace(strings.Join(nonEmpty(desc, sc.Description), ". "))
+	for _, e := range sc.Enum {
+		p.Enum = append(p.Enum, fmt.Sprint(e))
+	}
+	p.Minimum, p.Maximum = sc.Min, sc.Max
+	if sc.Items != nil && sc.Items.Value != nil {
+		p.Items = sc.Items.Value.Type
+	}
+	return p
+}
+
+func nonEmpty(vals ...string) []string {
+	var out []string
+	for _, v := range vals {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,452 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// ===================================
+// HTTP Tools (REST endpoints described in YAML or OpenAPI)
+// ===================================
+
+const (
+	defaultHTTPToolTimeout = 10 * time.Second
+	maxHTTPResponseBytes   = 1 << 20 // larger bodies are cut before decoding
+	maxHTTPErrorRunes      = 300     // error body excerpt returned to the model
+)
+
+// Parameter locations of HTTP tools.
+const (
+	ParamInPath   = "path"
+	ParamInQuery  = "query"
+	ParamInHeader = "header"
+	ParamInBody   = "body" // top-level field of a JSON request body
+)
+
+// HTTPToolSpec describes a tool backed by one REST endpoint. Either Method,
+// URL and Params are given, or OpenAPI and Operation name an operation in an
+// OpenAPI 3 document that supplies them. String values may reference
+// environment variables as ${VAR}.
+//
+//	tools:
+//	  - name: get_warranty_status
+//	    description: Look up the warranty of a product by serial number
+//	    method: GET
+//	    url: ${WARRANTY_URL}/warranties/{serial}
+//	    params:
+//	      serial: {type: string, description: Serial number, required: true}
+//	    auth: {header: Authorization, value: Bearer ${WARRANTY_TOKEN}}
+//	    response: {path: $.data, fields: {status: $.status, expires: $.expires_at}}
+type HTTPToolSpec struct {
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Category    ToolCategory  `yaml:"category"` // default query for GET/HEAD, action otherwise
+	Risk        ToolRisk      `yaml:"risk"`     // default read_only for GET/HEAD, side_effect otherwise
+	Owner       string        `yaml:"owner"`
+	Timeout     time.Duration `yaml:"timeout"`   // default 10s
+	CacheTTL    time.Duration `yaml:"cache_ttl"` // reuse results of read-only tools this long; default off
+	MaxItems    int           `yaml:"max_items"` // longest array passed to the model; default all
+	Guidance    string        `yaml:"guidance"`  // optional when-to-call line for the response prompt
+
+	Method   string               `yaml:"method"`
+	URL      string               `yaml:"url"` // {param} placeholders are path parameters
+	Params   map[string]HTTPParam `yaml:"params"`
+	Headers  map[string]string    `yaml:"headers"`
+	Auth     *HTTPAuth            `yaml:"auth"`
+	Response HTTPProjection       `yaml:"response"`
+
+	OpenAPI   string `yaml:"openapi"`   // OpenAPI document, relative to the spec file
+	Operation string `yaml:"operation"` // operationId in the document
+	BaseURL   string `yaml:"base_url"`  // overrides the document's first server
+}
+
+// HTTPParam is one argument of an HTTP tool.
+type HTTPParam struct {
+	Type        string   `yaml:"type"` // string, number, integer, boolean, array, object
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Enum        []string `yaml:"enum"`
+	Items       string   `yaml:"items"` // element type of arrays, default string
+	In          string   `yaml:"in"`    // default path when the URL has {name}, else query for GET/HEAD/DELETE, else body
+	Minimum     *float64 `yaml:"minimum"`
+	Maximum     *float64 `yaml:"maximum"`
+}
+
+// HTTPAuth is a header carrying credentials, e.g. Authorization: Bearer ${TOKEN}.
+type HTTPAuth struct {
+	Header string `yaml:"header"`
+	Value  string `yaml:"value"`
+}
+
+// HTTPProjection selects what of a JSON response reaches the model: Path picks
+// a node and Fields (output key -> path) reshape it, or each element of it
+// when it is an array. Paths use the JSONPath subset of evalJSONPath. In YAML
+// a plain string is a Path.
+type HTTPProjection struct {
+	Path   string            `yaml:"path"`
+	Fields map[string]string `yaml:"fields"`
+}
+
+func (p *HTTPProjection) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Path = node.Value
+		return nil
+	}
+	type plain HTTPProjection
+	return node.Decode((*plain)(p))
+}
+
+// LoadHTTPToolSpecs reads the tool specs of a YAML file and resolves their
+// OpenAPI operations.
+func LoadHTTPToolSpecs(path string) ([]HTTPToolSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read http tools %s: %w", path, err)
+	}
+	var file struct {
+		Tools []HTTPToolSpec `yaml:"tools"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse http tools %s: %w", path, err)
+	}
+	for i := range file.Tools {
+		spec := &file.Tools[i]
+		if spec.OpenAPI != "" {
+			doc := spec.OpenAPI
+			if !filepath.IsAbs(doc) {
+				doc = filepath.Join(filepath.Dir(path), doc)
+			}
+			if err := spec.applyOpenAPI(doc); err != nil {
+				return nil, fmt.Errorf("http tool %q: %w", spec.Name, err)
+			}
+		}
+		if err := spec.normalize(); err != nil {
+			return nil, fmt.Errorf("http tool %q: %w", spec.Name, err)
+		}
+	}
+	return file.Tools, nil
+}
+
+// normalize expands environment variables, fills defaults and checks the spec.
+func (s *HTTPToolSpec) normalize() error {
+	if s.Name == "" {
+		return errors.New("name is required")
+	}
+	s.Method = strings.ToUpper(strings.TrimSpace(s.Method))
+	if s.Method == "" {
+		s.Method = http.MethodGet
+	}
+	s.URL = os.ExpandEnv(s.URL)
+	u, err := url.Parse(s.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid url %q", s.URL)
+	}
+	for k, v := range s.Headers {
+		s.Headers[k] = os.ExpandEnv(v)
+	}
+	if s.Auth != nil {
+		if s.Auth.Header == "" {
+			s.Auth.Header = "Authorization"
+		}
+		s.Auth.Value = os.ExpandEnv(s.Auth.Value)
+	}
+
+	readOnly := s.Method == http.MethodGet || s.Method == http.MethodHead
+	if s.Category == "" {
+		s.Category = CategoryQuery
+		if !readOnly {
+			s.Category = CategoryAction
+		}
+	}
+	if !s.Category.Valid() {
+		return fmt.Errorf("unknown category %q", s.Category)
+	}
+	if s.Risk == "" {
+		s.Risk = RiskReadOnly
+		if !readOnly {
+			s.Risk = RiskSideEffect
+		}
+	}
+	if !s.Risk.Valid() {
+		return fmt.Errorf("unknown risk %q", s.Risk)
+	}
+	if s.Timeout <= 0 {
+		s.Timeout = defaultHTTPToolTimeout
+	}
+
+	for name, p := range s.Params {
+		placeholder := strings.Contains(s.URL, "{"+name+"}")
+		switch {
+		case p.In == "" && placeholder:
+			p.In = ParamInPath
+		case p.In == "" && (readOnly || s.Method == http.MethodDelete):
+			p.In = ParamInQuery
+		case p.In == "":
+			p.In = ParamInBody
+		}
+		switch p.In {
+		case ParamInPath:
+			if !placeholder {
+				return fmt.Errorf("path parameter %q has no {%s} in the url", name, name)
+			}
+			p.Required = true
+		case ParamInQuery, ParamInHeader, ParamInBody:
+		default:
+			return fmt.Errorf("parameter %q: unknown location %q", name, p.In)
+		}
+		if p.Type == "" {
+			p.Type = string(schema.String)
+		}
+		s.Params[name] = p
+	}
+	return nil
+}
+
+// info describes the tool's parameters, with their limits, for the model.
+func (s *HTTPToolSpec) info() *schema.ToolInfo {
+	params := make(map[string]*schema.ParameterInfo, len(s.Params))
+	limits := map[string]paramLimits{}
+	for name, p := range s.Params {
+		pi := &schema.ParameterInfo{
+			Type:     schema.DataType(p.Type),
+			Desc:     p.Description,
+			Enum:     p.Enum,
+			Required: p.Required,
+		}
+		if pi.Type == schema.Array {
+			elem := p.Items
+			if elem == "" {
+				elem = string(schema.String)
+			}
+			pi.ElemInfo = &schema.ParameterInfo{Type: schema.DataType(elem)}
+		}
+		params[name] = pi
+		if p.Minimum != nil || p.Maximum != nil {
+			limits[name] = paramLimits{min: p.Minimum, max: p.Maximum}
+		}
+	}
+	return &schema.ToolInfo{Name: s.Name, Desc: s.Description, ParamsOneOf: newParams(params, limits)}
+}
+
+// RegisterHTTPTools adds the specs to the tool registry. Names must not clash
+// with registered tools.
+func RegisterHTTPTools(specs []HTTPToolSpec) error {
+	for _, spec := range specs {
+		if _, dup := Lookup(spec.Name); dup {
+			return fmt.Errorf("http tool %q: a tool with this name is already registered", spec.Name)
+		}
+		t := NewHTTPTool(spec)
+		var guidance []string
+		if spec.Guidance != "" {
+			guidance = []string{spec.Guidance}
+		}
+		Register(Registration{
+			Name:     spec.Name,
+			Category: spec.Category,
+			Order:    100, // after the built-in tools of the category
+			Risk:     spec.Risk,
+			Timeout:  spec.Timeout,
+			Owner:    spec.Owner,
+			CacheTTL: spec.CacheTTL,
+			Output:   OutputShape{MaxItems: spec.MaxItems},
+			Guidance: guidance,
+			New:      func(Dependencies) tool.BaseTool { return t },
+		})
+	}
+	return nil
+}
+
+// HTTPTool calls the endpoint of an HTTPToolSpec. Responses the model can act
+// on (2xx, and 4xx as an error result) are returned as JSON; transport
+// failures and 5xx responses are errors.
+type HTTPTool struct {
+	spec   HTTPToolSpec
+	info   *schema.ToolInfo
+	client *http.Client
+}
+
+// NewHTTPTool creates the tool of a normalized spec (see LoadHTTPToolSpecs).
+func NewHTTPTool(spec HTTPToolSpec) *HTTPTool {
+	return &HTTPTool{spec: spec, info: spec.info(), client: &http.Client{Timeout: spec.Timeout}}
+}
+
+func (t *HTTPTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+func (t *HTTPTool) InvokableRun(ctx context.Context, arguments string, _ ...tool.Option) (string, error) {
+	args := map[string]any{}
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("%s arguments: %w", t.spec.Name, err)
+		}
+	}
+	req, err := t.newRequest(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s request: %w: %w", t.spec.Name, ErrTransient, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("%s read response: %w: %w", t.spec.Name, ErrTransient, err)
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		return "", fmt.Errorf("%s: status %d: %w", t.spec.Name, resp.StatusCode, ErrTransient)
+	case resp.StatusCode == http.StatusNotFound:
+		return marshalResult(map[string]any{"error": "not_found", "status": resp.StatusCode})
+	case resp.StatusCode >= 400:
+		return marshalResult(map[string]any{
+			"error":  "request_rejected",
+			"status": resp.StatusCode,
+			"detail": truncateRunes(strings.TrimSpace(string(body)), maxHTTPErrorRunes),
+		})
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return marshalResult(map[string]any{"status": resp.StatusCode})
+	}
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return marshalResult(map[string]any{"text": truncateRunes(string(body), maxHTTPErrorRunes)})
+	}
+	out, err := t.spec.Response.apply(doc)
+	if err != nil {
+		return "", fmt.Errorf("%s response: %w", t.spec.Name, err)
+	}
+	return marshalResult(out)
+}
+
+// newRequest places each argument in the path, query, headers or JSON body.
+func (t *HTTPTool) newRequest(ctx context.Context, args map[string]any) (*http.Request, error) {
+	target := t.spec.URL
+	query := url.Values{}
+	headers := http.Header{}
+	body := map[string]any{}
+
+	names := make([]string, 0, len(t.spec.Params))
+	for name := range t.spec.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		val, ok := args[name]
+		if !ok {
+			continue
+		}
+		switch t.spec.Params[name].In {
+		case ParamInPath:
+			s, _ := scalarString(val)
+			target = strings.ReplaceAll(target, "{"+name+"}", url.PathEscape(s))
+		case ParamInQuery:
+			if list, isList := val.([]any); isList {
+				for _, e := range list {
+					s, _ := scalarString(e)
+					query.Add(name, s)
+				}
+			} else {
+				s, _ := scalarString(val)
+				query.Set(name, s)
+			}
+		case ParamInHeader:
+			s, _ := scalarString(val)
+			headers.Set(name, s)
+		case ParamInBody:
+			body[name] = val
+		}
+	}
+	if len(query) > 0 {
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		target += sep + query.Encode()
+	}
+
+	var reader io.Reader
+	if len(body) > 0 {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("%s body: %w", t.spec.Name, err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, t.spec.Method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s request: %w", t.spec.Name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range t.spec.Headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+	if t.spec.Auth != nil && t.spec.Auth.Value != "" {
+		req.Header.Set(t.spec.Auth.Header, t.spec.Auth.Value)
+	}
+	return req, nil
+}
+
+// apply projects a decoded JSON response.
+func (p HTTPProjection) apply(doc any) (any, error) {
+	if p.Path != "" {
+		v, err := evalJSONPath(doc, p.Path)
+		if err != nil {
+			return nil, err
+		}
+		doc = v
+	}
+	if len(p.Fields) == 0 {
+		return doc, nil
+	}
+	shape := func(item any) map[string]any {
+		out := make(map[string]any, len(p.Fields))
+		for key, path := range p.Fields {
+			if v, err := evalJSONPath(item, path); err == nil {
+				out[key] = v
+			}
+		}
+		return out
+	}
+	if list, ok := doc.([]any); ok {
+		out := make([]any, len(list))
+		for i, item := range list {
+			out[i] = shape(item)
+		}
+		return out, nil
+	}
+	return shape(doc), nil
+}
+
+func marshalResult(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,386 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// capturedRequest is what the fake endpoint saw of a call.
+type capturedRequest struct {
+	method string
+	path   string
+	query  map[string][]string
+	header http.Header
+	body   map[string]any
+}
+
+// newTestEndpoint serves status and body for every request and records the
+// last one.
+func newTestEndpoint(t *testing.T, status int, body string) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	got := &capturedRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.method, got.path, got.query, got.header = r.Method, r.URL.Path, r.URL.Query(), r.Header.Clone()
+		got.body = nil
+		if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+			if err := json.Unmarshal(b, &got.body); err != nil {
+				t.Errorf("request body %q: %v", b, err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func newTestHTTPTool(t *testing.T, spec HTTPToolSpec) *HTTPTool {
+	t.Helper()
+	if err := spec.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	return NewHTTPTool(spec)
+}
+
+func TestHTTPToolParamPlacement(t *testing.T) {
+	srv, got := newTestEndpoint(t, http.StatusOK, `{"ok":true}`)
+	tl := newTestHTTPTool(t, HTTPToolSpec{
+		Name:   "add_order_note",
+		Method: "post",
+		URL:    srv.URL + "/orders/{order_id}/notes",
+		Params: map[string]HTTPParam{
+			"order_id": {Required: true},
+			"lang":     {In: ParamInQuery},
+			"tags":     {Type: "array", In: ParamInQuery},
+			"X-Trace":  {In: ParamInHeader},
+			"note":     {},
+			"urgent":   {Type: "boolean"},
+		},
+		Headers: map[string]string{"X-Channel": "chat"},
+		Auth:    &HTTPAuth{Value: "Bearer secret"},
+	})
+
+	for name, want := range map[string]string{"order_id": ParamInPath, "lang": ParamInQuery, "note": ParamInBody, "urgent": ParamInBody} {
+		if in := tl.spec.Params[name].In; in != want {
+			t.Errorf("param %s in %q, want %q", name, in, want)
+		}
+	}
+	if tl.spec.Category != CategoryAction || tl.spec.Risk != RiskSideEffect {
+		t.Errorf("POST defaults = %s/%s, want action/side_effect", tl.spec.Category, tl.spec.Risk)
+	}
+
+	args := `{"order_id":"A/1","lang":"th","tags":["gift","fragile"],"X-Trace":"t-1","note":"leave at door","urgent":true}`
+	if _, err := tl.InvokableRun(context.Background(), args); err != nil {
+		t.Fatalf("InvokableRun: %v", err)
+	}
+	if got.method != http.MethodPost {
+		t.Errorf("method = %s", got.method)
+	}
+	if got.path != "/orders/A/1/notes" {
+		t.Errorf("path = %q, want the escaped order id", got.path)
+	}
+	if got.query["lang"][0] != "th" || strings.Join(got.query["tags"], ",") != "gift,fragile" {
+		t.Errorf("query = %v", got.query)
+	}
+	if got.header.Get("X-Trace") != "t-1" || got.header.Get("X-Channel") != "chat" || got.header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("headers = %v", got.header)
+	}
+	if got.header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", got.header.Get("Content-Type"))
+	}
+	if len(got.body) != 2 || got.body["note"] != "leave at door" || got.body["urgent"] != true {
+		t.Errorf("body = %v, want note and urgent only", got.body)
+	}
+}
+
+func TestHTTPToolGETDefaultsToQuery(t *testing.T) {
+	srv, got := newTestEndpoint(t, http.StatusOK, `{}`)
+	tl := newTestHTTPTool(t, HTTPToolSpec{
+		Name:   "find_branch",
+		URL:    srv.URL + "/branches?active=1",
+		Params: map[string]HTTPParam{"city": {}, "limit": {Type: "integer"}},
+	})
+	if tl.spec.Method != http.MethodGet || tl.spec.Category != CategoryQuery || tl.spec.Risk != RiskReadOnly {
+		t.Errorf("defaults = %s %s/%s, want GET query/read_only", tl.spec.Method, tl.spec.Category, tl.spec.Risk)
+	}
+	if _, err := tl.InvokableRun(context.Background(), `{"city":"Bangkok","limit":3}`); err != nil {
+		t.Fatalf("InvokableRun: %v", err)
+	}
+	if got.query["city"][0] != "Bangkok" || got.query["limit"][0] != "3" || got.query["active"][0] != "1" {
+		t.Errorf("query = %v", got.query)
+	}
+	if got.body != nil {
+		t.Errorf("GET sent a body: %v", got.body)
+	}
+}
+
+func TestHTTPToolSpecRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec HTTPToolSpec
+	}{
+		{"missing name", HTTPToolSpec{URL: "http://x"}},
+		{"relative url", HTTPToolSpec{Name: "t", URL: "/x"}},
+		{"unknown category", HTTPToolSpec{Name: "t", URL: "http://x", Category: "querry"}},
+		{"unknown risk", HTTPToolSpec{Name: "t", URL: "http://x", Risk: "readonly"}},
+		{"path param without placeholder", HTTPToolSpec{Name: "t", URL: "http://x", Params: map[string]HTTPParam{"id": {In: ParamInPath}}}},
+		{"unknown location", HTTPToolSpec{Name: "t", URL: "http://x", Params: map[string]HTTPParam{"id": {In: "cookie"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.normalize(); err == nil {
+				t.Error("normalize accepted an invalid spec")
+			}
+		})
+	}
+}
+
+func TestHTTPToolStatusMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		want      map[string]any // nil when the call must fail
+		transient bool
+	}{
+		{"not found", http.StatusNotFound, `{"message":"no such serial"}`, map[string]any{"error": "not_found", "status": 404.0}, false},
+		{"rejected", http.StatusUnprocessableEntity, `  serial must be 12 digits  `, map[string]any{"error": "request_rejected", "status": 422.0, "detail": "serial must be 12 digits"}, false},
+		{"unauthorized", http.StatusUnauthorized, ``, map[string]any{"error": "request_rejected", "status": 401.0, "detail": ""}, false},
+		{"server error", http.StatusInternalServerError, `boom`, nil, true},
+		{"unavailable", http.StatusServiceUnavailable, ``, nil, true},
+		{"no content", http.StatusNoContent, ``, map[string]any{"status": 204.0}, false},
+		{"plain text", http.StatusOK, `active`, map[string]any{"text": "active"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTestEndpoint(t, tt.status, tt.body)
+			tl := newTestHTTPTool(t, HTTPToolSpec{Name: "get_warranty_status", URL: srv.URL + "/warranties/{serial}", Params: map[string]HTTPParam{"serial": {}}})
+			out, err := tl.InvokableRun(context.Background(), `{"serial":"123"}`)
+			if tt.want == nil {
+				if err == nil {
+					t.Fatalf("status %d returned %s, want an error", tt.status, out)
+				}
+				if errors.Is(err, ErrTransient) != tt.transient {
+					t.Errorf("errors.Is(%v, ErrTransient) = %v, want %v", err, !tt.transient, tt.transient)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InvokableRun: %v", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("result %q: %v", out, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("result = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("result[%s] = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPToolResponseProjection(t *testing.T) {
+	const body = `{"data":{"items":[
+		{"id":"w1","product":{"name":"iPhone 15"},"coverage":{"ends_at":"2026-01-01"},"internal":"x"},
+		{"id":"w2","product":{"name":"AirPods"},"coverage":{}}
+	],"total":2}}`
+	tests := []struct {
+		name string
+		proj HTTPProjection
+		want string
+	}{
+		{"whole document", HTTPProjection{}, `{"data":{"items":[{"coverage":{"ends_at":"2026-01-01"},"id":"w1","internal":"x","product":{"name":"iPhone 15"}},{"coverage":{},"id":"w2","product":{"name":"AirPods"}}],"total":2}}`},
+		{"path only", HTTPProjection{Path: "$.data.total"}, `2`},
+		{"index from the end", HTTPProjection{Path: "$.data.items[-1].product.name"}, `"AirPods"`},
+		{"fields of each element", HTTPProjection{
+			Path:   "$.data.items",
+			Fields: map[string]string{"name": "$.product.name", "ends": "$.coverage.ends_at"},
+		}, `[{"ends":"2026-01-01","name":"iPhone 15"},{"name":"AirPods"}]`},
+		{"wildcard", HTTPProjection{Path: "$.data.items[*].id"}, `["w1","w2"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTestEndpoint(t, http.StatusOK, body)
+			tl := newTestHTTPTool(t, HTTPToolSpec{Name: "list_warranties", URL: srv.URL, Response: tt.proj})
+			out, err := tl.InvokableRun(context.Background(), "")
+			if err != nil {
+				t.Fatalf("InvokableRun: %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("result = %s, want %s", out, tt.want)
+			}
+		})
+	}
+
+	srv, _ := newTestEndpoint(t, http.StatusOK, body)
+	tl := newTestHTTPTool(t, HTTPToolSpec{Name: "list_warranties", URL: srv.URL, Response: HTTPProjection{Path: "$.data.missing"}})
+	if out, err := tl.InvokableRun(context.Background(), ""); err == nil {
+		t.Errorf("a path matching nothing returned %s, want an error", out)
+	}
+}
+
+func TestLoadHTTPToolSpecsOpenAPI(t *testing.T) {
+	srv, got := newTestEndpoint(t, http.StatusOK, `{"events":[{"title":"iPhone launch","branch":{"name":"Siam"},"starts_at":"2026-09-20"}]}`)
+	t.Setenv("TEST_EVENTS_URL", srv.URL+"/api")
+
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "events.openapi.yaml", `openapi: 3.0.3
+info: {title: Store events, version: 1.0.0}
+servers:
+  - url: https://events.example.com/api
+paths:
+  /branches/{branch}/events:
+    parameters:
+      - name: branch
+        in: path
+        required: true
+        description: Branch ID
+        schema: {type: string}
+    get:
+      operationId: listEvents
+      summary: List upcoming in-store events
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer, minimum: 1, maximum: 20}
+        - name: Authorization
+          in: header
+          schema: {type: string}
+      responses:
+        "200": {description: Upcoming events}
+    post:
+      operationId: registerForEvent
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [event_id]
+              properties:
+                event_id: {type: string}
+                guests: {type: integer}
+      responses:
+        "201": {description: Registered}
+`)
+	path := writeSpecFile(t, dir, "tools.yaml", `tools:
+  - name: list_store_events
+    openapi: events.openapi.yaml
+    operation: listEvents
+    base_url: ${TEST_EVENTS_URL}
+    max_items: 5
+    auth:
+      value: Bearer ${TEST_EVENTS_TOKEN}
+    response:
+      path: $.events[*]
+      fields: {title: $.title, branch: $.branch.name}
+  - name: register_for_event
+    openapi: events.openapi.yaml
+    operation: registerForEvent
+    params:
+      guests: {type: integer, description: Guests besides the customer, maximum: 3}
+`)
+	t.Setenv("TEST_EVENTS_TOKEN", "tok")
+
+	specs, err := LoadHTTPToolSpecs(path)
+	if err != nil {
+		t.Fatalf("LoadHTTPToolSpecs: %v", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("loaded %d specs, want 2", len(specs))
+	}
+
+	list := specs[0]
+	if list.Method != http.MethodGet || list.URL != srv.URL+"/api/branches/{branch}/events" {
+		t.Errorf("list: %s %s", list.Method, list.URL)
+	}
+	if list.Description != "List upcoming in-store events" {
+		t.Errorf("list description = %q", list.Description)
+	}
+	if p := list.Params["branch"]; p.In != ParamInPath || !p.Required || p.Description != "Branch ID" {
+		t.Errorf("branch param = %+v", p)
+	}
+	if p := list.Params["limit"]; p.In != ParamInQuery || p.Type != "integer" || p.Minimum == nil || *p.Minimum != 1 || *p.Maximum != 20 {
+		t.Errorf("limit param = %+v", p)
+	}
+	if _, ok := list.Params["Authorization"]; ok {
+		t.Error("the Authorization header became a parameter the model fills in")
+	}
+
+	register := specs[1]
+	if register.Method != http.MethodPost || register.URL != "https://events.example.com/api/branches/{branch}/events" {
+		t.Errorf("register: %s %s", register.Method, register.URL)
+	}
+	if register.Risk != RiskSideEffect {
+		t.Errorf("register risk = %s", register.Risk)
+	}
+	if p := register.Params["event_id"]; p.In != ParamInBody || !p.Required {
+		t.Errorf("event_id param = %+v", p)
+	}
+	if p := register.Params["guests"]; p.In != ParamInBody || p.Maximum == nil || *p.Maximum != 3 || p.Description != "Guests besides the customer" {
+		t.Errorf("guests param = %+v, want the spec's override", p)
+	}
+
+	out, err := NewHTTPTool(list).InvokableRun(context.Background(), `{"branch":"bkk-siam","limit":5}`)
+	if err != nil {
+		t.Fatalf("InvokableRun: %v", err)
+	}
+	if out != `[{"branch":"Siam","title":"iPhone launch"}]` {
+		t.Errorf("result = %s", out)
+	}
+	if got.path != "/api/branches/bkk-siam/events" || got.query["limit"][0] != "5" || got.header.Get("Authorization") != "Bearer tok" {
+		t.Errorf("request = %s %v %q", got.path, got.query, got.header.Get("Authorization"))
+	}
+}
+
+func TestLoadHTTPToolSpecsOpenAPIErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "doc.yaml", `openapi: 3.0.3
+info: {title: Empty, version: 1.0.0}
+paths:
+  /x:
+    get:
+      operationId: getX
+      responses:
+        "200": {description: ok}
+`)
+	tests := []struct {
+		name, spec string
+	}{
+		{"unknown operation", "openapi: doc.yaml\n    operation: nope\n    base_url: http://x"},
+		{"no operation", "openapi: doc.yaml"},
+		{"no server", "openapi: doc.yaml\n    operation: getX"},
+		{"missing document", "openapi: missing.yaml\n    operation: getX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSpecFile(t, dir, "tools.yaml", "tools:\n  - name: t\n    "+tt.spec+"\n")
+			if _, err := LoadHTTPToolSpecs(path); err == nil {
+				t.Error("LoadHTTPToolSpecs accepted the spec")
+			}
+		})
+	}
+}
+
+func writeSpecFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInsufficientStock is returned by Inventory.Reserve when the branch has
+// fewer available units than requested.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// Branch kinds.
+const (
+	BranchStore     = "store"
+	BranchWarehouse = "warehouse"
+)
+
+// Branch is a store or warehouse holding stock.
+type Branch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"` // store, warehouse
+}
+
+// StockLevel is the stock of one product at one branch. Available excludes
+// units held by active reservations.
+type StockLevel struct {
+	Branch          Branch     `json:"branch"`
+	OnHand          int        `json:"on_hand"`
+	Reserved        int        `json:"reserved"`
+	Available       int        `json:"available"`
+	RestockETA      *time.Time `json:"restock_eta,omitempty"`
+	RestockQuantity int        `json:"restock_quantity,omitempty"`
+}
+
+// Inventory tracks per-branch stock and reservation holds.
+type Inventory interface {
+	// Stock returns the product's stock per branch; empty when the product is
+	// not tracked (callers then fall back to Product.InStock)
+	Stock(ctx context.Context, productID string) ([]StockLevel, error)
+
+	// Reserve holds qty units at branch under holdID until ttl passes or the
+	// hold is released; ErrInsufficientStock when not enough are available
+	Reserve(ctx context.Context, holdID, productID, branchID string, qty int, ttl time.Duration) error
+
+	// Release drops every unit held under holdID
+	Release(ctx context.Context, holdID string) error
+}
+
+// StockRecord seeds MemoryInventory with one product's stock at one branch.
+type StockRecord struct {
+	ProductID       string
+	BranchID        string
+	OnHand          int
+	RestockETA      time.Time // zero = no restock scheduled
+	RestockQuantity int
+}
+
+type stockKey struct{ product, branch string }
+
+type stockHold struct {
+	key       stockKey
+	qty       int
+	expiresAt time.Time
+}
+
+// MemoryInventory is a process-local Inventory. It is safe for concurrent use.
+type MemoryInventory struct {
+	mu       sync.Mutex
+	branches map[string]Branch
+	order    []string // branch IDs in display order
+	stock    map[stockKey]StockRecord
+	holds    map[string][]stockHold
+	onChange []func()
+	now      func() time.Time
+}
+
+func NewMemoryInventory(branches []Branch, records []StockRecord) *MemoryInventory {
+	inv := &MemoryInventory{
+		branches: map[string]Branch{},
+		stock:    map[stockKey]StockRecord{},
+		holds:    map[string][]stockHold{},
+		now:      time.Now,
+	}
+	for _, b := range branches {
+		inv.branches[b.ID] = b
+		inv.order = append(inv.order, b.ID)
+	}
+	for _, r := range records {
+		inv.stock[stockKey{r.ProductID, r.BranchID}] = r
+	}
+	return inv
+}
+
+// reservedLocked sums unexpired holds on key, dropping expired ones.
+func (inv *MemoryInventory) reservedLocked(key stockKey) int {
+	now := inv.now()
+	total := 0
+	for id, holds := range inv.holds {
+		kept := holds[:0]
+		for _, h := range holds {
+			if now.After(h.expiresAt) {
+				continue
+			}
+			kept = append(kept, h)
+			if h.key == key {
+				total += h.qty
+			}
+		}
+		if len(kept) == 0 {
+			delete(inv.holds, id)
+		} else {
+			inv.holds[id] = kept
+		}
+	}
+	return total
+}
+
+func (inv *MemoryInventory) Stock(ctx context.Context, productID string) ([]StockLevel, error) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	var out []StockLevel
+	for _, bid := range inv.order {
+		key := stockKey{productID, bid}
+		r, ok := inv.stock[key]
+		if !ok {
+			continue
+		}
+		reserved := inv.reservedLocked(key)
+		lvl := StockLevel{
+			Branch:    inv.branches[bid],
+			OnHand:    r.OnHand,
+			Reserved:  reserved,
+			Available: max(r.OnHand-reserved, 0),
+		}
+		if !r.RestockETA.IsZero() {
+			eta := r.RestockETA
+			lvl.RestockETA = &eta
+			lvl.RestockQuantity = r.RestockQuantity
+		}
+		out = append(out, lvl)
+	}
+	return out, nil
+}
+
+func (inv *MemoryInventory) Reserve(ctx context.Context, holdID, productID, branchID string, qty int, ttl time.Duration) error {
+	if qty <= 0 {
+		return fmt.Errorf("%w: reserve quantity must be positive, got %d", ErrInvalidRequest, qty)
+	}
+	inv.mu.Lock()
+	key := stockKey{productID, branchID}
+	r, ok := inv.stock[key]
+	if !ok {
+		inv.mu.Unlock()
+		return fmt.Errorf("%w: %s is not stocked at %s", ErrInsufficientStock, productID, branchID)
+	}
+	if avail := r.OnHand - inv.reservedLocked(key); avail < qty {
+		inv.mu.Unlock()
+		return fmt.Errorf("%w: %d of %s available at %s, %d requested", ErrInsufficientStock, max(avail, 0), productID, branchID, qty)
+	}
+	inv.holds[holdID] = append(inv.holds[holdID], stockHold{key: key, qty: qty, expiresAt: inv.now().Add(ttl)})
+	listeners := inv.onChange
+	inv.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+	return nil
+}
+
+func (inv *MemoryInventory) Release(ctx context.Context, holdID string) error {
+	inv.mu.Lock()
+	_, held := inv.holds[holdID]
+	delete(inv.holds, holdID)
+	listeners := inv.onChange
+	inv.mu.Unlock()
+	if held {
+		for _, fn := range listeners {
+			fn()
+		}
+	}
+	return nil
+}
+
+// OnChange registers fn to run after each reservation and release. Holds
+// that expire on their own are not reported.
+func (inv *MemoryInventory) OnChange(fn func()) {
+	inv.mu.Lock()
+	inv.onChange = append(inv.onChange, fn)
+	inv.mu.Unlock()
+}
+
+// totalAvailable sums Available over levels and returns the earliest restock.
+func totalAvailable(levels []StockLevel) (int, *time.Time) {
+	total := 0
+	var next *time.Time
+	for _, l := range levels {
+		total += l.Available
+		if l.RestockETA != nil && (next == nil || l.RestockETA.Before(*next)) {
+			next = l.RestockETA
+		}
+	}
+	return total, next
+}
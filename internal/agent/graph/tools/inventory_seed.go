@@ -0,0 +1,50 @@
+package tools
+
+import "time"
+
+// DefaultBranches are the demo stores and the online-order warehouse.
+func DefaultBranches() []Branch {
+	return []Branch{
+		{ID: "bkk-siam", Name: "TechHub Siam Paragon", Kind: BranchStore},
+		{ID: "bkk-ladprao", Name: "TechHub Central Ladprao", Kind: BranchStore},
+		{ID: "cnx-maya", Name: "TechHub Chiang Mai MAYA", Kind: BranchStore},
+		{ID: "wh-bangna", Name: "Bangna Warehouse (online orders)", Kind: BranchWarehouse},
+	}
+}
+
+// DefaultStock returns demo stock for the seed catalog, consistent with its
+// InStock flags. Restock dates are relative to now.
+func DefaultStock(now time.Time) []StockRecord {
+	day := 24 * time.Hour
+	restock := func(days int) time.Time { return now.Add(time.Duration(days) * day).Truncate(day) }
+	return []StockRecord{
+		{ProductID: "prod-001", BranchID: "bkk-siam", OnHand: 4},
+		{ProductID: "prod-001", BranchID: "bkk-ladprao", OnHand: 2},
+		{ProductID: "prod-001", BranchID: "wh-bangna", OnHand: 25},
+		{ProductID: "prod-002", BranchID: "bkk-siam", OnHand: 3},
+		{ProductID: "prod-002", BranchID: "wh-bangna", OnHand: 12},
+		{ProductID: "prod-003", BranchID: "bkk-siam", OnHand: 0, RestockETA: restock(5), RestockQuantity: 6},
+		{ProductID: "prod-003", BranchID: "wh-bangna", OnHand: 0, RestockETA: restock(3), RestockQuantity: 20},
+		{ProductID: "prod-004", BranchID: "bkk-siam", OnHand: 10},
+		{ProductID: "prod-004", BranchID: "bkk-ladprao", OnHand: 6},
+		{ProductID: "prod-004", BranchID: "cnx-maya", OnHand: 4},
+		{ProductID: "prod-004", BranchID: "wh-bangna", OnHand: 40},
+		{ProductID: "prod-005", BranchID: "bkk-siam", OnHand: 2},
+		{ProductID: "prod-005", BranchID: "wh-bangna", OnHand: 8},
+		{ProductID: "prod-006", BranchID: "bkk-ladprao", OnHand: 3},
+		{ProductID: "prod-006", BranchID: "wh-bangna", OnHand: 15},
+		{ProductID: "prod-007", BranchID: "bkk-siam", OnHand: 1},
+		{ProductID: "prod-007", BranchID: "wh-bangna", OnHand: 5},
+		{ProductID: "prod-008", BranchID: "bkk-siam", OnHand: 0},
+		{ProductID: "prod-008", BranchID: "wh-bangna", OnHand: 0, RestockETA: restock(14), RestockQuantity: 10},
+		{ProductID: "prod-009", BranchID: "bkk-ladprao", OnHand: 3},
+		{ProductID: "prod-009", BranchID: "cnx-maya", OnHand: 2},
+		{ProductID: "prod-009", BranchID: "wh-bangna", OnHand: 10},
+		{ProductID: "prod-010", BranchID: "bkk-siam", OnHand: 2},
+		{ProductID: "prod-010", BranchID: "wh-bangna", OnHand: 7},
+		{ProductID: "prod-011", BranchID: "bkk-ladprao", OnHand: 2},
+		{ProductID: "prod-011", BranchID: "wh-bangna", OnHand: 9},
+		{ProductID: "prod-012", BranchID: "cnx-maya", OnHand: 3},
+		{ProductID: "prod-012", BranchID: "wh-bangna", OnHand: 14},
+	}
+}
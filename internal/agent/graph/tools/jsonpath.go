@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// errNoMatch marks a JSONPath that selects nothing.
+var errNoMatch = errors.New("no match")
+
+// evalJSONPath evaluates a JSONPath subset against decoded JSON: the root $,
+// child keys (.key or ['key']), array indexes ([0], [-1] from the end) and
+// wildcards ([*] or .*). After a wildcard the result is the list of matches.
+func evalJSONPath(doc any, path string) (any, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	values, multi := []any{doc}, false
+	for _, step := range steps {
+		var next []any
+		for _, v := range values {
+			switch {
+			case step.wildcard:
+				switch vv := v.(type) {
+				case []any:
+					next = append(next, vv...)
+				case map[string]any:
+					for _, e := range vv {
+						next = append(next, e)
+					}
+				}
+			case step.index != nil:
+				list, ok := v.([]any)
+				if !ok {
+					continue
+				}
+				i := *step.index
+				if i < 0 {
+					i += len(list)
+				}
+				if i >= 0 && i < len(list) {
+					next = append(next, list[i])
+				}
+			default:
+				if obj, ok := v.(map[string]any); ok {
+					if e, ok := obj[step.key]; ok {
+						next = append(next, e)
+					}
+				}
+			}
+		}
+		multi = multi || step.wildcard
+		values = next
+	}
+	if multi {
+		if values == nil {
+			values = []any{}
+		}
+		return values, nil
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s: %w", path, errNoMatch)
+	}
+	return values[0], nil
+}
+
+type jsonPathStep struct {
+	key      string
+	index    *int
+	wildcard bool
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	rest := strings.TrimSpace(path)
+	if !strings.HasPrefix(rest, "$") {
+		return nil, fmt.Errorf("jsonpath %q must start with $", path)
+	}
+	rest = rest[1:]
+	var steps []jsonPathStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("jsonpath %q: empty key", path)
+			}
+			if key == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else {
+				steps = append(steps, jsonPathStep{key: key})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q: unclosed [", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1]})
+			default:
+				i, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath %q: bad index [%s]", path, inner)
+				}
+				steps = append(steps, jsonPathStep{index: &i})
+			}
+		default:
+			return nil, fmt.Errorf("jsonpath %q: unexpected %q", path, rest[0])
+		}
+	}
+	return steps, nil
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/knowledge"
+)
+
+// ===================================
+// Knowledge Base Tool
+// ===================================
+
+const (
+	defaultKnowledgeResults = 3
+	maxKnowledgeResults     = 8
+	maxSnippetRunes         = 600
+)
+
+type SearchKnowledgeBaseInput struct {
+	Query      string `json:"query"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// KnowledgeSnippet is a cited passage of a policy document.
+type KnowledgeSnippet struct {
+	Ref     string  `json:"ref"`    // citation marker, e.g. [1]
+	Source  string  `json:"source"` // document file, e.g. returns.md
+	Title   string  `json:"title"`
+	Section string  `json:"section,omitempty"`
+	Page    int     `json:"page,omitempty"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+type SearchKnowledgeBaseOutput struct {
+	Query   string             `json:"query"`
+	Results []KnowledgeSnippet `json:"results"`
+	Total   int                `json:"total"`
+	Message string             `json:"message,omitempty"` // set when nothing relevant was found
+}
+
+func init() {
+	Register(Registration{
+		Name:     ToolSearchKnowledge,
+		Category: CategoryUtility,
+		Order:    30,
+		Timeout:  3 * time.Second,
+		Owner:    "customer-care",
+		CacheTTL: 30 * time.Minute,
+		Guidance: []string{"Customer asks about warranty, returns/refunds, shipping fees or times, pickup, payment or installments → call " + ToolSearchKnowledge + "; answer only from the returned snippets and cite them (e.g., \"[1] returns.md\"); if nothing is found, say so and offer human help"},
+		New:      func(d Dependencies) tool.BaseTool { return createSearchKnowledgeBaseTool(d.Knowledge) },
+	})
+}
+
+func createSearchKnowledgeBaseTool(kb *knowledge.Base) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolSearchKnowledge,
+			Desc: "Search the store's policy documents (warranty, returns and refunds, shipping and pickup, payment and installments) and return cited snippets. Use this tool when customer asks about a store policy or procedure (ประกัน, เคลม, คืนสินค้า, คืนเงิน, ค่าส่ง, ผ่อน). Answer only from the returned snippets and cite their ref and source.",
+			ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
+				"query": {
+					Type:     "string",
+					Desc:     "Policy question or keywords in Thai or English (e.g., คืนสินค้าได้กี่วัน, laptop warranty)",
+					Required: true,
+				},
+				"max_results": {
+					Type: "integer",
+					Desc: "Maximum number of snippets to return (default 3, max 8)",
+				},
+			}, map[string]paramLimits{
+				"max_results": between(1, maxKnowledgeResults),
+			}),
+		},
+		func(ctx context.Context, in *SearchKnowledgeBaseInput) (*SearchKnowledgeBaseOutput, error) {
+			if in.Query == "" {
+				return nil, fmt.Errorf("%w: query is required", ErrInvalidRequest)
+			}
+			limit := in.MaxResults
+			if limit <= 0 {
+				limit = defaultKnowledgeResults
+			}
+			limit = min(limit, maxKnowledgeResults)
+
+			results, err := kb.Search(ctx, in.Query, limit)
+			if err != nil {
+				return nil, fmt.Errorf("search knowledge base: %w", err)
+			}
+			out := &SearchKnowledgeBaseOutput{Query: in.Query, Results: make([]KnowledgeSnippet, 0, len(results))}
+			for i, r := range results {
+				out.Results = append(out.Results, KnowledgeSnippet{
+					Ref:     fmt.Sprintf("[%d]", i+1),
+					Source:  r.Source,
+					Title:   r.Title,
+					Section: r.Section,
+					Page:    r.Page,
+					Snippet: truncateRunes(r.Text, maxSnippetRunes),
+					Score:   r.Score,
+				})
+			}
+			out.Total = len(out.Results)
+			if out.Total == 0 {
+				out.Message = "no policy document covers this; do not guess, offer to connect the customer with staff"
+			}
+			return out, nil
+		},
+	)
+}
+
+// truncateRunes shortens s to at most n runes, ending with an ellipsis when cut.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
@@ -7,23 +7,6 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
-func GetQueryTools() []tool.BaseTool {
-	return []tool.BaseTool{
-		createSearchProductTool(),
-		createGetProductDetailsTool(),
-	}
-}
-
-// func GetActionTools() []tool.BaseTool {
-// 	return []tool.BaseTool{
-// 	}
-// }
-
-// func GetUtilityTools() []tool.BaseTool {
-// 	return []tool.BaseTool{
-// 	}
-// }
-
 // GetToolInfos extracts ToolInfo from all tools
 func GetToolInfos(ctx context.Context, tools []tool.BaseTool) ([]*schema.ToolInfo, error) {
 	infos := make([]*schema.ToolInfo, len(tools))
@@ -0,0 +1,293 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ===================================
+// Cart and Order Tools
+// ===================================
+
+// errNoConversation is returned by action tools invoked outside a conversation.
+var errNoConversation = fmt.Errorf("%w: cart and order tools need a conversation", ErrInvalidRequest)
+
+type AddToCartInput struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity,omitempty"`
+}
+
+type RemoveFromCartInput struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity,omitempty"`
+}
+
+type ViewCartInput struct{}
+
+type CreateOrderInput struct {
+	Fulfillment  string `json:"fulfillment,omitempty"`
+	Address      string `json:"address,omitempty"`
+	PickupBranch string `json:"pickup_branch,omitempty"`
+	CouponCode   string `json:"coupon_code,omitempty"`
+}
+
+type CreateOrderOutput struct {
+	Order   *model.Order `json:"order"`
+	Message string       `json:"message"`
+}
+
+func init() {
+	Register(Registration{
+		Name:     ToolAddToCart,
+		Category: CategoryAction,
+		Order:    10,
+		Risk:     RiskSideEffect,
+		Timeout:  5 * time.Second,
+		Owner:    "commerce",
+		Guidance: []string{"Customer decides to buy → call " + ToolAddToCart + "; quote totals only from tool output, never compute them yourself"},
+		New:      func(d Dependencies) tool.BaseTool { return createAddToCartTool(d.Orders) },
+	})
+	Register(Registration{
+		Name:     ToolViewCart,
+		Category: CategoryAction,
+		Order:    20,
+		Risk:     RiskReadOnly,
+		Timeout:  3 * time.Second,
+		Owner:    "commerce",
+		Guidance: []string{"Customer wants to review the cart or the amount to pay → call " + ToolViewCart},
+		New:      func(d Dependencies) tool.BaseTool { return createViewCartTool(d.Orders) },
+	})
+	Register(Registration{
+		Name:     ToolRemoveFromCart,
+		Category: CategoryAction,
+		Order:    30,
+		Risk:     RiskSideEffect,
+		Timeout:  5 * time.Second,
+		Owner:    "commerce",
+		Guidance: []string{"Customer takes something out of the cart → call " + ToolRemoveFromCart},
+		New:      func(d Dependencies) tool.BaseTool { return createRemoveFromCartTool(d.Orders) },
+	})
+	Register(Registration{
+		Name:     ToolCreateOrder,
+		Category: CategoryAction,
+		Order:    40,
+		Risk:     RiskSideEffect,
+		Timeout:  10 * time.Second,
+		Owner:    "commerce",
+		Guidance: []string{"Placing the order → first confirm the cart and collect a shipping address or pickup branch, then call " + ToolCreateOrder},
+		New:      func(d Dependencies) tool.BaseTool { return createCreateOrderTool(d.Orders) },
+	})
+}
+
+func createAddToCartTool(orders *OrderService) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolAddToCart,
+			Desc: "Add a product to the customer's cart. Returns the whole cart priced with current promotions. Use this tool when customer decides to buy a product (เอาอันนี้, ตกลงเอา, สั่งซื้อ).",
+			ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
+				"product_id": {
+					Type:     "string",
+					Desc:     "Product ID obtained from search_product results (e.g., prod-009). Must be exact ID from search results.",
+					Required: true,
+				},
+				"quantity": {
+					Type: "integer",
+					Desc: "Units to add (default: 1, max: 10 per product)",
+				},
+			}, map[string]paramLimits{
+				"quantity": between(1, maxCartQuantity),
+			}),
+		},
+		func(ctx context.Context, in *AddToCartInput) (*CartView, error) {
+			owner, _, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			if in.ProductID == "" {
+				return nil, fmt.Errorf("%w: product_id is required", ErrInvalidRequest)
+			}
+			return orders.AddToCart(ctx, owner, in.ProductID, in.Quantity)
+		},
+	)
+}
+
+func createViewCartTool(orders *OrderService) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name:        ToolViewCart,
+			Desc:        "Show the customer's cart with quantities, promotions applied, subtotal, discount and total. Use this tool when customer asks what is in the cart or how much to pay.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{}),
+		},
+		func(ctx context.Context, _ *ViewCartInput) (*CartView, error) {
+			owner, _, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			return orders.ViewCart(ctx, owner)
+		},
+	)
+}
+
+func createRemoveFromCartTool(orders *OrderService) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolRemoveFromCart,
+			Desc: "Remove a product (or some units of it) from the customer's cart. Returns the updated cart.",
+			ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
+				"product_id": {
+					Type:     "string",
+					Desc:     "Product ID in the cart",
+					Required: true,
+				},
+				"quantity": {
+					Type: "integer",
+					Desc: "Units to remove; omit to remove the product entirely",
+				},
+			}, map[string]paramLimits{
+				"quantity": between(1, maxCartQuantity),
+			}),
+		},
+		func(ctx context.Context, in *RemoveFromCartInput) (*CartView, error) {
+			owner, _, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			if in.ProductID == "" {
+				return nil, fmt.Errorf("%w: product_id is required", ErrInvalidRequest)
+			}
+			return orders.RemoveFromCart(ctx, owner, in.ProductID, in.Quantity)
+		},
+	)
+}
+
+func createCreateOrderTool(orders *OrderService) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolCreateOrder,
+			Desc: "Place an order for everything in the customer's cart. Totals are computed from catalog prices and promotions; stock is reserved until payment. Only call after the customer has reviewed the cart and given delivery details.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"fulfillment": {
+					Type: "string",
+					Enum: []string{model.FulfillmentShipping, model.FulfillmentPickup},
+					Desc: "shipping (default) or pickup at a branch",
+				},
+				"address": {
+					Type: "string",
+					Desc: "Shipping address given by the customer (required for shipping)",
+				},
+				"pickup_branch": {
+					Type: "string",
+					Desc: "Branch ID from check_stock for pickup (e.g., bkk-siam)",
+				},
+				"coupon_code": {
+					Type: "string",
+					Desc: "Coupon code given by the customer",
+				},
+			}),
+		},
+		func(ctx context.Context, in *CreateOrderInput) (*CreateOrderOutput, error) {
+			owner, conversationID, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			order, err := orders.CreateOrder(ctx, owner, conversationID, OrderRequest{
+				Fulfillment:  in.Fulfillment,
+				Address:      in.Address,
+				PickupBranch: in.PickupBranch,
+				CouponCode:   in.CouponCode,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &CreateOrderOutput{
+				Order:   order,
+				Message: fmt.Sprintf("Order %s placed; stock is reserved until %s pending payment.", order.ID, order.ReservedUntil.Format("2006-01-02 15:04")),
+			}, nil
+		},
+	)
+}
+
+// DescribeAction renders a cart or order tool call as a short Thai sentence
+// for the confirmation prompt. Calls that cannot be decoded fall back to the
+// tool name and raw arguments.
+func (s *OrderService) DescribeAction(ctx context.Context, name, arguments string) string {
+	fallback := fmt.Sprintf("%s %s", name, arguments)
+	switch name {
+	case ToolAddToCart, ToolRemoveFromCart:
+		var in AddToCartInput
+		if err := json.Unmarshal([]byte(arguments), &in); err != nil || in.ProductID == "" {
+			return fallback
+		}
+		product := in.ProductID
+		if p, err := s.catalog.Get(ctx, in.ProductID); err == nil {
+			product = p.Name
+		}
+		if name == ToolRemoveFromCart {
+			if in.Quantity <= 0 {
+				return fmt.Sprintf("นำ %s ออกจากตะกร้า", product)
+			}
+			return fmt.Sprintf("นำ %s ออกจากตะกร้า %d ชิ้น", product, in.Quantity)
+		}
+		if in.Quantity <= 0 {
+			in.Quantity = 1
+		}
+		return fmt.Sprintf("เพิ่ม %s จำนวน %d ชิ้นลงตะกร้า", product, in.Quantity)
+	case ToolCreateOrder:
+		var in CreateOrderInput
+		if err := json.Unmarshal([]byte(arguments), &in); err != nil {
+			return fallback
+		}
+		var b strings.Builder
+		b.WriteString("สั่งซื้อสินค้าในตะกร้า")
+		if owner, _, ok := ownerFromContext(ctx); ok {
+			if cart, err := s.store.LoadCart(ctx, owner); err == nil {
+				if lines, _, err := s.resolve(ctx, cart); err == nil && len(lines) > 0 {
+					if quote, err := s.pricer.QuoteCart(ctx, lines, in.CouponCode); err == nil {
+						items := 0
+						for _, l := range lines {
+							items += l.Quantity
+						}
+						fmt.Fprintf(&b, " %d ชิ้น ยอดรวม %s บาท", items, formatThousands(quote.Total))
+					}
+				}
+			}
+		}
+		if in.Fulfillment == model.FulfillmentPickup {
+			fmt.Fprintf(&b, " รับสินค้าที่สาขา %s", in.PickupBranch)
+		} else if in.Address != "" {
+			fmt.Fprintf(&b, " จัดส่งที่ %s", in.Address)
+		}
+		if in.CouponCode != "" {
+			fmt.Fprintf(&b, " (คูปอง %s)", in.CouponCode)
+		}
+		return b.String()
+	}
+	return fallback
+}
+
+// formatThousands renders an amount like 28,900 or 28,900.50.
+func formatThousands(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 && whole[i-1] != '-' {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if frac != "00" {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
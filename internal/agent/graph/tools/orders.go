@@ -0,0 +1,394 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+const (
+	maxCartQuantity       = 10 // units of one product per cart
+	defaultReservationTTL = 30 * time.Minute
+	shippingLeadTime      = 3 * 24 * time.Hour // delivery promised for shipped orders
+	pickupLeadTime        = 24 * time.Hour     // pickup readiness promised for pickup orders
+)
+
+// ErrEmptyCart is returned by CreateOrder when the cart has no items.
+var ErrEmptyCart = errors.New("cart is empty")
+
+// CartView is a priced cart. Warnings explain items dropped because they left
+// the catalog.
+type CartView struct {
+	CartQuote
+	ItemCount int      `json:"item_count"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// OrderRequest carries the customer's choices for CreateOrder.
+type OrderRequest struct {
+	Fulfillment  string // shipping (default) or pickup
+	Address      string // required for shipping
+	PickupBranch string // branch ID, required for pickup
+	CouponCode   string
+}
+
+// OrderService implements carts and orders on top of an OrderStore. Prices
+// always come from the catalog and promotions, never from the caller.
+type OrderService struct {
+	store          model.OrderStore
+	catalog        ProductCatalog
+	pricer         *Pricer
+	inventory      Inventory
+	reservationTTL time.Duration
+	now            func() time.Time
+}
+
+// NewOrderService creates the service; reservationTTL is how long stock stays
+// held for an unpaid order (0 uses 30 minutes).
+func NewOrderService(store model.OrderStore, catalog ProductCatalog, pricer *Pricer, inventory Inventory, reservationTTL time.Duration) *OrderService {
+	if reservationTTL <= 0 {
+		reservationTTL = defaultReservationTTL
+	}
+	return &OrderService{
+		store:          store,
+		catalog:        catalog,
+		pricer:         pricer,
+		inventory:      inventory,
+		reservationTTL: reservationTTL,
+		now:            time.Now,
+	}
+}
+
+// AddToCart adds qty units of productID, capped at maxCartQuantity per product
+// and at the units available.
+func (s *OrderService) AddToCart(ctx context.Context, ownerID, productID string, qty int) (*CartView, error) {
+	if qty <= 0 {
+		qty = 1
+	}
+	product, err := s.catalog.Get(ctx, productID)
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get product %s: %w", productID, err)
+	}
+
+	cart, err := s.store.LoadCart(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("load cart: %w", err)
+	}
+	idx := -1
+	for i, it := range cart.Items {
+		if it.ProductID == productID {
+			idx = i
+			break
+		}
+	}
+	want := qty
+	if idx >= 0 {
+		want += cart.Items[idx].Quantity
+	}
+	if want > maxCartQuantity {
+		return nil, fmt.Errorf("%w: at most %d units of %s per order", ErrInvalidRequest, maxCartQuantity, product.Name)
+	}
+
+	units, tracked, err := availableUnits(ctx, s.inventory, *product)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case tracked && units == 0, !tracked && !product.InStock:
+		return nil, fmt.Errorf("%w: %s is out of stock", ErrInsufficientStock, product.Name)
+	case tracked && units < want:
+		return nil, fmt.Errorf("%w: only %d units of %s available", ErrInsufficientStock, units, product.Name)
+	}
+
+	if idx >= 0 {
+		cart.Items[idx].Quantity = want
+	} else {
+		cart.Items = append(cart.Items, model.CartItem{ProductID: productID, Quantity: qty, AddedAt: s.now()})
+	}
+	cart.UpdatedAt = s.now()
+	if err := s.store.SaveCart(ctx, cart); err != nil {
+		return nil, fmt.Errorf("save cart: %w", err)
+	}
+	return s.view(ctx, cart)
+}
+
+// RemoveFromCart removes qty units of productID, or all of them when qty <= 0.
+func (s *OrderService) RemoveFromCart(ctx context.Context, ownerID, productID string, qty int) (*CartView, error) {
+	cart, err := s.store.LoadCart(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("load cart: %w", err)
+	}
+	found := false
+	items := cart.Items[:0]
+	for _, it := range cart.Items {
+		if it.ProductID == productID {
+			found = true
+			if qty > 0 && qty < it.Quantity {
+				it.Quantity -= qty
+				items = append(items, it)
+			}
+			continue
+		}
+		items = append(items, it)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: product %s is not in the cart", ErrInvalidRequest, productID)
+	}
+	cart.Items = items
+	cart.UpdatedAt = s.now()
+	if err := s.store.SaveCart(ctx, cart); err != nil {
+		return nil, fmt.Errorf("save cart: %w", err)
+	}
+	return s.view(ctx, cart)
+}
+
+// ViewCart returns the owner's cart priced at current promotions.
+func (s *OrderService) ViewCart(ctx context.Context, ownerID string) (*CartView, error) {
+	cart, err := s.store.LoadCart(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("load cart: %w", err)
+	}
+	return s.view(ctx, cart)
+}
+
+func (s *OrderService) view(ctx context.Context, cart *model.Cart) (*CartView, error) {
+	lines, warnings, err := s.resolve(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.pricer.QuoteCart(ctx, lines, "")
+	if err != nil {
+		return nil, err
+	}
+	v := &CartView{CartQuote: *quote, Warnings: warnings}
+	for _, l := range lines {
+		v.ItemCount += l.Quantity
+	}
+	return v, nil
+}
+
+// resolve looks cart items up in the catalog, skipping products that no
+// longer exist.
+func (s *OrderService) resolve(ctx context.Context, cart *model.Cart) ([]CartLine, []string, error) {
+	var (
+		lines    []CartLine
+		warnings []string
+	)
+	for _, it := range cart.Items {
+		p, err := s.catalog.Get(ctx, it.ProductID)
+		if errors.Is(err, ErrProductNotFound) {
+			warnings = append(warnings, fmt.Sprintf("product %s is no longer available and was skipped", it.ProductID))
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("get product %s: %w", it.ProductID, err)
+		}
+		lines = append(lines, CartLine{Product: *p, Quantity: it.Quantity})
+	}
+	return lines, warnings, nil
+}
+
+// CreateOrder prices the owner's cart, reserves its stock and places an order
+// awaiting payment. The cart is cleared once the order is saved; failing to
+// clear it is logged, since the order already exists.
+func (s *OrderService) CreateOrder(ctx context.Context, ownerID, conversationID string, req OrderRequest) (*model.Order, error) {
+	fulfillment := strings.ToLower(strings.TrimSpace(req.Fulfillment))
+	if fulfillment == "" {
+		fulfillment = model.FulfillmentShipping
+	}
+	switch fulfillment {
+	case model.FulfillmentShipping:
+		if strings.TrimSpace(req.Address) == "" {
+			return nil, fmt.Errorf("%w: address is required for shipping", ErrInvalidRequest)
+		}
+	case model.FulfillmentPickup:
+		if strings.TrimSpace(req.PickupBranch) == "" {
+			return nil, fmt.Errorf("%w: pickup_branch is required for pickup", ErrInvalidRequest)
+		}
+	default:
+		return nil, fmt.Errorf("%w: fulfillment must be %s or %s", ErrInvalidRequest, model.FulfillmentShipping, model.FulfillmentPickup)
+	}
+
+	cart, err := s.store.LoadCart(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("load cart: %w", err)
+	}
+	lines, _, err := s.resolve(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, ErrEmptyCart
+	}
+	quote, err := s.pricer.QuoteCart(ctx, lines, req.CouponCode)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Coupon != nil && quote.Coupon.Status != CouponApplied {
+		return nil, fmt.Errorf("%w: coupon %s cannot be used: %s", ErrInvalidRequest, quote.Coupon.Code, quote.Coupon.Status)
+	}
+
+	now := s.now()
+	order := &model.Order{
+		ID:             newOrderID(now),
+		OwnerID:        ownerID,
+		ConversationID: conversationID,
+		Status:         model.OrderPendingPayment,
+		Lines:          quote.Lines,
+		Subtotal:       quote.Subtotal,
+		Discount:       quote.Discount,
+		Total:          quote.Total,
+		Currency:       quote.Currency,
+		Fulfillment:    fulfillment,
+		ReservedUntil:  now.Add(s.reservationTTL),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if quote.Coupon != nil {
+		order.CouponCode = strings.ToUpper(quote.Coupon.Code)
+	}
+	if fulfillment == model.FulfillmentShipping {
+		order.Address = strings.TrimSpace(req.Address)
+		order.PromisedBy = now.Add(shippingLeadTime)
+	} else {
+		order.PickupBranch = strings.TrimSpace(req.PickupBranch)
+		order.PromisedBy = now.Add(pickupLeadTime)
+	}
+
+	for i, l := range lines {
+		branch, err := s.reserve(ctx, order.ID, l, order.PickupBranch)
+		if err != nil {
+			if relErr := s.inventory.Release(ctx, order.ID); relErr != nil {
+				return nil, errors.Join(err, relErr)
+			}
+			return nil, err
+		}
+		order.Lines[i].BranchID = branch
+	}
+
+	if err := s.store.SaveOrder(ctx, order); err != nil {
+		_ = s.inventory.Release(ctx, order.ID)
+		return nil, fmt.Errorf("save order: %w", err)
+	}
+	if err := s.store.ClearCart(ctx, ownerID); err != nil {
+		logx.Error().Err(err).Str("order_id", order.ID).Str("owner_id", ownerID).Msg("Order placed but cart not cleared")
+	}
+	return order, nil
+}
+
+// reserve holds a line's units under holdID at pickupBranch, or for shipping
+// at the first warehouse, then store, with enough stock. Untracked products
+// are not reserved.
+func (s *OrderService) reserve(ctx context.Context, holdID string, l CartLine, pickupBranch string) (string, error) {
+	levels, err := s.inventory.Stock(ctx, l.Product.ID)
+	if err != nil {
+		return "", fmt.Errorf("check stock %s: %w", l.Product.ID, err)
+	}
+	if len(levels) == 0 {
+		if !l.Product.InStock {
+			return "", fmt.Errorf("%w: %s is out of stock", ErrInsufficientStock, l.Product.Name)
+		}
+		return "", nil
+	}
+	if pickupBranch != "" {
+		if err := s.inventory.Reserve(ctx, holdID, l.Product.ID, pickupBranch, l.Quantity, s.reservationTTL); err != nil {
+			return "", err
+		}
+		return pickupBranch, nil
+	}
+	for _, kind := range []string{BranchWarehouse, BranchStore} {
+		for _, lvl := range levels {
+			if lvl.Branch.Kind != kind || lvl.Available < l.Quantity {
+				continue
+			}
+			if err := s.inventory.Reserve(ctx, holdID, l.Product.ID, lvl.Branch.ID, l.Quantity, s.reservationTTL); err == nil {
+				return lvl.Branch.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: no branch has %d units of %s", ErrInsufficientStock, l.Quantity, l.Product.Name)
+}
+
+// Orders returns the owner's orders, newest first, or only orderID. Orders of
+// other owners are reported as not found. Unpaid orders past their
+// reservation are cancelled on the way out.
+func (s *OrderService) Orders(ctx context.Context, ownerID, orderID string) ([]*model.Order, error) {
+	var list []*model.Order
+	if orderID == "" {
+		var err error
+		if list, err = s.store.ListOrders(ctx, ownerID); err != nil {
+			return nil, err
+		}
+	} else {
+		o, err := s.store.GetOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if o.OwnerID != ownerID {
+			logx.Warn().Str("order_id", orderID).Str("owner_id", ownerID).Msg("Order requested by another owner; reporting not found")
+			return nil, model.ErrOrderNotFound
+		}
+		list = []*model.Order{o}
+	}
+	for _, o := range list {
+		if err := s.expire(ctx, o); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// expire cancels o and releases its stock when it is still unpaid after its
+// reservation ran out.
+func (s *OrderService) expire(ctx context.Context, o *model.Order) error {
+	now := s.now()
+	if o.Status != model.OrderPendingPayment || o.ReservedUntil.IsZero() || now.Before(o.ReservedUntil) {
+		return nil
+	}
+	if err := s.inventory.Release(ctx, o.ID); err != nil {
+		return fmt.Errorf("release order %s: %w", o.ID, err)
+	}
+	o.Status = model.OrderCancelled
+	o.UpdatedAt = now
+	if err := s.store.SaveOrder(ctx, o); err != nil {
+		return fmt.Errorf("save order: %w", err)
+	}
+	return nil
+}
+
+// Ship records the carrier handover of an order, for back-office or carrier
+// integrations.
+func (s *OrderService) Ship(ctx context.Context, orderID, carrier, trackingNumber string) (*model.Order, error) {
+	o, err := s.store.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Fulfillment != model.FulfillmentShipping {
+		return nil, fmt.Errorf("order %s is not a shipping order", orderID)
+	}
+	now := s.now()
+	o.Shipment = &model.Shipment{Carrier: carrier, TrackingNumber: trackingNumber, ShippedAt: now}
+	o.Status = model.OrderShipped
+	o.UpdatedAt = now
+	if err := s.store.SaveOrder(ctx, o); err != nil {
+		return nil, fmt.Errorf("save order: %w", err)
+	}
+	return o, nil
+}
+
+// newOrderID returns an ID like ORD-261018-3F9A1C.
+func newOrderID(now time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return "ORD-" + now.Format("060102") + "-" + strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
+)
+
+// stuckCartStore fails every ClearCart.
+type stuckCartStore struct {
+	*repo.MemoryOrderStore
+}
+
+func (stuckCartStore) ClearCart(context.Context, string) error {
+	return errors.New("redis: connection reset")
+}
+
+func newTestOrderService(store model.OrderStore) (*OrderService, *MemoryInventory) {
+	catalog := NewMemoryCatalog([]model.Product{{ID: "prod-a", Name: "Phone A", Price: 30000, InStock: true}})
+	inventory := NewMemoryInventory(
+		[]Branch{{ID: "wh", Name: "Warehouse", Kind: BranchWarehouse}},
+		[]StockRecord{{ProductID: "prod-a", BranchID: "wh", OnHand: 2}},
+	)
+	pricer := NewPricer(repo.NewMemoryPromotionStore(nil))
+	return NewOrderService(store, catalog, pricer, inventory, 30*time.Minute), inventory
+}
+
+func available(t *testing.T, inv *MemoryInventory) int {
+	t.Helper()
+	levels, err := inv.Stock(context.Background(), "prod-a")
+	if err != nil {
+		t.Fatalf("Stock: %v", err)
+	}
+	units, _ := totalAvailable(levels)
+	return units
+}
+
+func TestCreateOrderKeepsOrderWhenCartNotCleared(t *testing.T) {
+	ctx := context.Background()
+	store := stuckCartStore{repo.NewMemoryOrderStore()}
+	svc, _ := newTestOrderService(store)
+	if _, err := svc.AddToCart(ctx, "owner", "prod-a", 1); err != nil {
+		t.Fatalf("AddToCart: %v", err)
+	}
+
+	order, err := svc.CreateOrder(ctx, "owner", "conv", OrderRequest{Address: "1 Rama I Rd"})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if _, err := store.GetOrder(ctx, order.ID); err != nil {
+		t.Errorf("placed order %s not saved: %v", order.ID, err)
+	}
+}
+
+func TestOrdersCancelsUnpaidOrdersPastReservation(t *testing.T) {
+	ctx := context.Background()
+	store := repo.NewMemoryOrderStore()
+	svc, inventory := newTestOrderService(store)
+	if _, err := svc.AddToCart(ctx, "owner", "prod-a", 2); err != nil {
+		t.Fatalf("AddToCart: %v", err)
+	}
+	order, err := svc.CreateOrder(ctx, "owner", "conv", OrderRequest{Address: "1 Rama I Rd"})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	list, err := svc.Orders(ctx, "owner", order.ID)
+	if err != nil {
+		t.Fatalf("Orders: %v", err)
+	}
+	if got := list[0].Status; got != model.OrderPendingPayment {
+		t.Fatalf("status within reservation = %q, want %q", got, model.OrderPendingPayment)
+	}
+	if got := available(t, inventory); got != 0 {
+		t.Fatalf("available within reservation = %d, want 0", got)
+	}
+
+	svc.now = func() time.Time { return order.ReservedUntil.Add(time.Second) }
+	list, err = svc.Orders(ctx, "owner", "")
+	if err != nil {
+		t.Fatalf("Orders: %v", err)
+	}
+	if got := list[0].Status; got != model.OrderCancelled {
+		t.Errorf("status after reservation = %q, want %q", got, model.OrderCancelled)
+	}
+	saved, err := store.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if saved.Status != model.OrderCancelled {
+		t.Errorf("stored status = %q, want %q", saved.Status, model.OrderCancelled)
+	}
+	if got := available(t, inventory); got != 2 {
+		t.Errorf("available after cancel = %d, want 2", got)
+	}
+}
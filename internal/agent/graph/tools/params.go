@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// paramLimits are the JSON Schema constraints schema.ParameterInfo cannot
+// express. ArgumentValidator enforces them.
+type paramLimits struct {
+	min, max           *float64 // inclusive number bounds
+	minItems, maxItems *uint64  // array length bounds
+}
+
+// between limits a number to [lo, hi].
+func between(lo, hi float64) paramLimits {
+	return paramLimits{min: &lo, max: &hi}
+}
+
+// atLeast limits a number to [lo, ∞).
+func atLeast(lo float64) paramLimits {
+	return paramLimits{min: &lo}
+}
+
+// itemsBetween limits an array to lo..hi elements.
+func itemsBetween(lo, hi uint64) paramLimits {
+	return paramLimits{minItems: &lo, maxItems: &hi}
+}
+
+// newParams describes tool parameters like schema.NewParamsOneOfByParams,
+// adding limits for the named parameters. It panics on a limit for an
+// unknown parameter, since tools are built at startup.
+func newParams(params map[string]*schema.ParameterInfo, limits map[string]paramLimits) *schema.ParamsOneOf {
+	js, err := schema.NewParamsOneOfByParams(params).ToJSONSchema()
+	if err != nil {
+		panic(fmt.Sprintf("tool params: %v", err))
+	}
+	for name, l := range limits {
+		prop, ok := js.Properties.Get(name)
+		if !ok {
+			panic(fmt.Sprintf("tool params: limits for unknown parameter %q", name))
+		}
+		if l.min != nil {
+			prop.Minimum = json.Number(strconv.FormatFloat(*l.min, 'f', -1, 64))
+		}
+		if l.max != nil {
+			prop.Maximum = json.Number(strconv.FormatFloat(*l.max, 'f', -1, 64))
+		}
+		prop.MinItems, prop.MaxItems = l.minItems, l.maxItems
+	}
+	return schema.NewParamsOneOfByJSONSchema(js)
+}
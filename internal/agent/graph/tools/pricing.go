@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// Coupon outcomes reported in PriceQuote.Coupon.
+const (
+	CouponApplied       = "applied"
+	CouponInvalid       = "invalid"
+	CouponNotActive     = "not_active"
+	CouponNotApplicable = "not_applicable"
+)
+
+// Pricer quotes current prices from catalog prices and active promotions.
+// Only the best automatic discount applies; a valid coupon stacks on top.
+type Pricer struct {
+	store model.PromotionStore
+	now   func() time.Time
+}
+
+func NewPricer(store model.PromotionStore) *Pricer {
+	return &Pricer{store: store, now: time.Now}
+}
+
+// PromotionSummary is the tool-facing view of a promotion.
+type PromotionSummary struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Kind        string   `json:"kind"`
+	PercentOff  float64  `json:"percent_off,omitempty"`
+	AmountOff   float64  `json:"amount_off,omitempty"`
+	ProductIDs  []string `json:"product_ids,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+	BundleWith  []string `json:"bundle_with,omitempty"`
+	CouponCode  string   `json:"coupon_code,omitempty"`
+	EndsAt      string   `json:"ends_at,omitempty"` // RFC 3339
+}
+
+func summarizePromotion(p model.Promotion) PromotionSummary {
+	s := PromotionSummary{
+		ID:          p.ID,
+		Title:       p.Title,
+		Description: p.Description,
+		Kind:        p.Kind,
+		PercentOff:  p.PercentOff,
+		AmountOff:   p.AmountOff,
+		ProductIDs:  p.ProductIDs,
+		Categories:  p.Categories,
+		BundleWith:  p.BundleWith,
+	}
+	if !p.Hidden {
+		s.CouponCode = p.CouponCode
+	}
+	if !p.EndsAt.IsZero() {
+		s.EndsAt = p.EndsAt.Format(time.RFC3339)
+	}
+	return s
+}
+
+type CouponResult struct {
+	Code     string  `json:"code"`
+	Status   string  `json:"status"` // applied, invalid, not_active, not_applicable
+	Discount float64 `json:"discount,omitempty"`
+}
+
+// PriceQuote is the price a customer pays today. Discount is in THB.
+type PriceQuote struct {
+	model.ProductPrice
+	Name              string             `json:"name"`
+	Currency          string             `json:"currency"`
+	DiscountPercent   float64            `json:"discount_percent"`
+	AppliedPromotions []PromotionSummary `json:"applied_promotions,omitempty"`
+	BundleOffers      []PromotionSummary `json:"bundle_offers,omitempty"` // deals when bought with other products
+	Coupon            *CouponResult      `json:"coupon,omitempty"`
+	ValidUntil        string             `json:"valid_until,omitempty"` // earliest end of an applied promotion
+	InStock           bool               `json:"in_stock"`
+}
+
+// Active returns the promotions running now, optionally including hidden ones.
+func (pr *Pricer) Active(ctx context.Context, includeHidden bool) ([]model.Promotion, error) {
+	all, err := pr.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+	now := pr.now()
+	out := make([]model.Promotion, 0, len(all))
+	for _, p := range all {
+		if p.Active(now) && (includeHidden || !p.Hidden) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// Quote prices product with the best active discount and, when coupon is
+// set, the matching coupon.
+func (pr *Pricer) Quote(ctx context.Context, product model.Product, coupon string) (*PriceQuote, error) {
+	all, err := pr.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+	now := pr.now()
+
+	q := &PriceQuote{
+		ProductPrice: model.ProductPrice{
+			ProductID:     product.ID,
+			OriginalPrice: product.Price,
+			CurrentPrice:  product.Price,
+		},
+		Name:     product.Name,
+		Currency: "THB",
+		InStock:  product.InStock,
+	}
+
+	var (
+		best      *model.Promotion
+		bestCut   float64
+		validTill time.Time
+	)
+	for i := range all {
+		p := all[i]
+		if !p.Active(now) {
+			continue
+		}
+		switch p.Kind {
+		case model.PromotionDiscount:
+			if !p.AppliesTo(product) {
+				continue
+			}
+			if cut := p.DiscountOn(product.Price); cut > bestCut {
+				best, bestCut = &all[i], cut
+			}
+		case model.PromotionBundle:
+			if p.AppliesTo(product) || containsFold(p.BundleWith, product.ID) {
+				q.BundleOffers = append(q.BundleOffers, summarizePromotion(p))
+			}
+		}
+	}
+	if best != nil {
+		q.CurrentPrice -= bestCut
+		q.AppliedPromotions = append(q.AppliedPromotions, summarizePromotion(*best))
+		validTill = best.EndsAt
+	}
+
+	if code := strings.TrimSpace(coupon); code != "" {
+		q.Coupon = &CouponResult{Code: code, Status: CouponInvalid}
+		for _, p := range all {
+			if p.Kind != model.PromotionCoupon || !strings.EqualFold(p.CouponCode, code) {
+				continue
+			}
+			switch {
+			case !p.Active(now):
+				q.Coupon.Status = CouponNotActive
+			case !p.AppliesTo(product):
+				q.Coupon.Status = CouponNotApplicable
+			default:
+				cut := p.DiscountOn(q.CurrentPrice)
+				q.CurrentPrice -= cut
+				q.Coupon.Status = CouponApplied
+				q.Coupon.Discount = cut
+				q.AppliedPromotions = append(q.AppliedPromotions, summarizePromotion(p))
+				if !p.EndsAt.IsZero() && (validTill.IsZero() || p.EndsAt.Before(validTill)) {
+					validTill = p.EndsAt
+				}
+			}
+			break
+		}
+	}
+
+	q.CurrentPrice = math.Round(q.CurrentPrice*100) / 100
+	q.Discount = math.Round((q.OriginalPrice-q.CurrentPrice)*100) / 100
+	if q.OriginalPrice > 0 {
+		q.DiscountPercent = math.Round(q.Discount/q.OriginalPrice*1000) / 10
+	}
+	if !validTill.IsZero() {
+		q.ValidUntil = validTill.Format(time.RFC3339)
+	}
+	return q, nil
+}
+
+// CartLine is a cart item resolved against the catalog.
+type CartLine struct {
+	Product  model.Product
+	Quantity int
+}
+
+// CartQuote prices a whole cart; amounts are THB.
+type CartQuote struct {
+	Lines    []model.OrderLine `json:"lines"`
+	Subtotal float64           `json:"subtotal"` // before discounts
+	Discount float64           `json:"discount"`
+	Total    float64           `json:"total"`
+	Currency string            `json:"currency"`
+	Coupon   *CouponResult     `json:"coupon,omitempty"`
+}
+
+// QuoteCart prices lines with the best automatic discount per product, bundle
+// deals whose partner product is in the cart (for as many units as the
+// partner has) and, when coupon is set, the coupon across the lines it
+// applies to. Amount-off coupons apply once per cart.
+func (pr *Pricer) QuoteCart(ctx context.Context, lines []CartLine, coupon string) (*CartQuote, error) {
+	all, err := pr.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+	now := pr.now()
+
+	inCart := map[string]int{}
+	for _, l := range lines {
+		inCart[l.Product.ID] += l.Quantity
+	}
+
+	q := &CartQuote{Lines: make([]model.OrderLine, 0, len(lines)), Currency: "THB"}
+	for _, l := range lines {
+		p := l.Product
+		line := model.OrderLine{ProductID: p.ID, Name: p.Name, Quantity: l.Quantity, UnitPrice: p.Price}
+
+		var unitCut, bundleCut float64
+		var discountID, bundleID string
+		bundled := 0
+		for _, promo := range all {
+			if !promo.Active(now) || !promo.AppliesTo(p) {
+				continue
+			}
+			switch promo.Kind {
+			case model.PromotionDiscount:
+				if cut := promo.DiscountOn(p.Price); cut > unitCut {
+					unitCut, discountID = cut, promo.ID
+				}
+			case model.PromotionBundle:
+				partners := 0
+				for _, id := range promo.BundleWith {
+					partners += inCart[id]
+				}
+				if partners == 0 {
+					continue
+				}
+				if cut := promo.DiscountOn(p.Price); cut > bundleCut {
+					bundleCut, bundleID, bundled = cut, promo.ID, min(l.Quantity, partners)
+				}
+			}
+		}
+		if discountID != "" {
+			line.Promotions = append(line.Promotions, discountID)
+		}
+		if bundleID != "" {
+			// the bundle deal stacks on the automatic discount, never below zero
+			bundleCut = min(bundleCut, p.Price-unitCut)
+			line.Promotions = append(line.Promotions, bundleID)
+		}
+		line.Discount = roundBaht(unitCut*float64(l.Quantity) + bundleCut*float64(bundled))
+		line.LineTotal = roundBaht(p.Price*float64(l.Quantity) - line.Discount)
+		q.Lines = append(q.Lines, line)
+	}
+
+	if code := strings.TrimSpace(coupon); code != "" {
+		q.Coupon = &CouponResult{Code: code, Status: CouponInvalid}
+		for _, promo := range all {
+			if promo.Kind != model.PromotionCoupon || !strings.EqualFold(promo.CouponCode, code) {
+				continue
+			}
+			if !promo.Active(now) {
+				q.Coupon.Status = CouponNotActive
+				break
+			}
+			var eligible []int
+			base := 0.0
+			for i, l := range lines {
+				if promo.AppliesTo(l.Product) {
+					eligible = append(eligible, i)
+					base += q.Lines[i].LineTotal
+				}
+			}
+			if len(eligible) == 0 || base == 0 {
+				q.Coupon.Status = CouponNotApplicable
+				break
+			}
+			cut := promo.DiscountOn(base)
+			// spread the coupon over eligible lines in proportion to their totals
+			left := cut
+			for n, i := range eligible {
+				share := roundBaht(cut * q.Lines[i].LineTotal / base)
+				if n == len(eligible)-1 {
+					share = roundBaht(left)
+				}
+				left -= share
+				q.Lines[i].Discount = roundBaht(q.Lines[i].Discount + share)
+				q.Lines[i].LineTotal = roundBaht(q.Lines[i].LineTotal - share)
+				q.Lines[i].Promotions = append(q.Lines[i].Promotions, promo.ID)
+			}
+			q.Coupon.Status = CouponApplied
+			q.Coupon.Discount = cut
+			break
+		}
+	}
+
+	for _, l := range q.Lines {
+		q.Subtotal += l.UnitPrice * float64(l.Quantity)
+		q.Discount += l.Discount
+		q.Total += l.LineTotal
+	}
+	q.Subtotal, q.Discount, q.Total = roundBaht(q.Subtotal), roundBaht(q.Discount), roundBaht(q.Total)
+	return q, nil
+}
+
+// roundBaht rounds v to satang.
+func roundBaht(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// DefaultPromotions returns the demo promotions for the seed catalog. The
+// flash sale ends a week after now so the demo always has a time-bounded deal.
+func DefaultPromotions(now time.Time) []model.Promotion {
+	return []model.Promotion{
+		{
+			ID:          "promo-laptop-week",
+			Title:       "Laptop Week",
+			Description: "5% off every laptop",
+			Kind:        model.PromotionDiscount,
+			Categories:  []string{"laptops"},
+			PercentOff:  5,
+			EndsAt:      now.Add(7 * 24 * time.Hour).Truncate(time.Hour),
+		},
+		{
+			ID:          "promo-iphone-15-pro",
+			Title:       "iPhone 15 Pro price drop",
+			Description: "2,000 THB off iPhone 15 Pro",
+			Kind:        model.PromotionDiscount,
+			ProductIDs:  []string{"prod-001"},
+			AmountOff:   2000,
+		},
+		{
+			ID:          "bundle-iphone-airpods",
+			Title:       "iPhone + AirPods bundle",
+			Description: "1,500 THB off AirPods Pro when bought with an iPhone 15 Pro",
+			Kind:        model.PromotionBundle,
+			ProductIDs:  []string{"prod-004"},
+			BundleWith:  []string{"prod-001"},
+			AmountOff:   1500,
+		},
+		{
+			ID:          "coupon-welcome",
+			Title:       "Welcome coupon",
+			Description: "500 THB off for new customers",
+			Kind:        model.PromotionCoupon,
+			CouponCode:  "WELCOME500",
+			AmountOff:   500,
+		},
+		{
+			ID:          "coupon-vip",
+			Title:       "VIP coupon",
+			Description: "Extra 10% off for VIP members",
+			Kind:        model.PromotionCoupon,
+			CouponCode:  "VIP10",
+			PercentOff:  10,
+			Hidden:      true,
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ===================================
+// Pricing and Promotion Tools
+// ===================================
+
+type GetProductPriceInput struct {
+	ProductID  string `json:"product_id"`
+	CouponCode string `json:"coupon_code,omitempty"`
+}
+
+func init() {
+	Register(Registration{
+		Name:      ToolGetProductPrice,
+		Category:  CategoryQuery,
+		Order:     40,
+		Timeout:   3 * time.Second,
+		Owner:     "pricing",
+		CacheTTL:  time.Minute, // promotions start and end on the minute
+		LiveStock: true,
+		Guidance:  []string{"Quoting a price or the customer gives a coupon → call " + ToolGetProductPrice + " with the product_id (and coupon_code); quote current_price, and show original_price and the promotion when discounted"},
+		New:       func(d Dependencies) tool.BaseTool { return createGetProductPriceTool(d.Catalog, d.Pricer, d.Inventory) },
+	})
+	Register(Registration{
+		Name:     ToolListPromotions,
+		Category: CategoryQuery,
+		Order:    50,
+		Timeout:  3 * time.Second,
+		Owner:    "pricing",
+		CacheTTL: time.Minute, // promotions start and end on the minute
+		Output:   OutputShape{MaxItems: 10},
+		Guidance: []string{"Customer asks about promotions/discounts → call " + ToolListPromotions},
+		New:      func(d Dependencies) tool.BaseTool { return createListPromotionsTool(d.Catalog, d.Pricer) },
+	})
+}
+
+func createGetProductPriceTool(catalog ProductCatalog, pricer *Pricer, inventory Inventory) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolGetProductPrice,
+			Desc: "Get the current selling price of a product after active promotions, with original price, discount, applied promotions, bundle offers and promotion end date. Optionally validates a coupon code. Use this tool before quoting any price; the price in search results is the list price before discounts.",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"product_id": {
+					Type:     "string",
+					Desc:     "Product ID obtained from search_product results (e.g., prod-001). Must be exact ID from search results.",
+					Required: true,
+				},
+				"coupon_code": {
+					Type: "string",
+					Desc: "Coupon code given by the customer (e.g., WELCOME500). Omit when the customer has none.",
+				},
+			}),
+		},
+		func(ctx context.Context, in *GetProductPriceInput) (*PriceQuote, error) {
+			if in.ProductID == "" {
+				return nil, fmt.Errorf("%w: product_id is required", ErrInvalidRequest)
+			}
+			product, err := catalog.Get(ctx, in.ProductID)
+			if errors.Is(err, ErrProductNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrProductNotFound, in.ProductID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get product %s: %w", in.ProductID, err)
+			}
+			return liveQuote(ctx, pricer, inventory, *product, in.CouponCode)
+		},
+	)
+}
+
+type ListPromotionsInput struct {
+	ProductID string `json:"product_id,omitempty"`
+	Category  string `json:"category,omitempty"`
+}
+
+type ListPromotionsOutput struct {
+	Promotions []PromotionSummary `json:"promotions"`
+	Total      int                `json:"total"`
+}
+
+func createListPromotionsTool(catalog ProductCatalog, pricer *Pricer) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolListPromotions,
+			Desc: "List promotions running now: discounts, bundle deals and public coupon codes, with their end dates. Filter by product or category. Use this tool when customer asks about promotions, sales, discounts or coupons (โปรโมชั่น, ส่วนลด, ลดราคา, คูปอง).",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"product_id": {
+					Type: "string",
+					Desc: "Only promotions for this product ID (including bundles it is part of)",
+				},
+				"category": {
+					Type: "string",
+					Desc: "Only promotions for this category. Available categories: smartphones, laptops, tablets, audio, wearables",
+				},
+			}),
+		},
+		func(ctx context.Context, in *ListPromotionsInput) (*ListPromotionsOutput, error) {
+			active, err := pricer.Active(ctx, false)
+			if err != nil {
+				return nil, err
+			}
+
+			var target *model.Product
+			if in.ProductID != "" {
+				p, err := catalog.Get(ctx, in.ProductID)
+				if errors.Is(err, ErrProductNotFound) {
+					return nil, fmt.Errorf("%w: %s", ErrProductNotFound, in.ProductID)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("get product %s: %w", in.ProductID, err)
+				}
+				target = p
+			}
+
+			out := &ListPromotionsOutput{Promotions: []PromotionSummary{}}
+			for _, p := range active {
+				if target != nil && !p.AppliesTo(*target) && !containsFold(p.BundleWith, target.ID) {
+					continue
+				}
+				if in.Category != "" && !promotionCoversCategory(ctx, catalog, p, in.Category) {
+					continue
+				}
+				out.Promotions = append(out.Promotions, summarizePromotion(p))
+			}
+			out.Total = len(out.Promotions)
+			return out, nil
+		},
+	)
+}
+
+// promotionCoversCategory reports whether p is store-wide, names category or
+// targets a product (or bundle partner) of that category.
+func promotionCoversCategory(ctx context.Context, catalog ProductCatalog, p model.Promotion, category string) bool {
+	category = strings.TrimSpace(category)
+	if len(p.ProductIDs) == 0 && len(p.Categories) == 0 {
+		return true
+	}
+	if containsFold(p.Categories, category) {
+		return true
+	}
+	for _, id := range append(append([]string{}, p.ProductIDs...), p.BundleWith...) {
+		if product, err := catalog.Get(ctx, id); err == nil && strings.EqualFold(product.Category, category) {
+			return true
+		}
+	}
+	return false
+}
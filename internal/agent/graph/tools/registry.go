@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/knowledge"
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// ToolCategory groups tools so deployments can enable them together.
+type ToolCategory string
+
+const (
+	CategoryQuery   ToolCategory = "query"   // product discovery, pricing and stock
+	CategoryAction  ToolCategory = "action"  // carts and orders
+	CategoryUtility ToolCategory = "utility" // after-sales support and policy lookup
+)
+
+// categoryOrder is the order categories are listed in.
+var categoryOrder = map[ToolCategory]int{CategoryQuery: 0, CategoryAction: 1, CategoryUtility: 2}
+
+// Valid reports whether c is a known category.
+func (c ToolCategory) Valid() bool {
+	_, ok := categoryOrder[c]
+	return ok
+}
+
+// Dependencies are the backends registered tools are built from.
+type Dependencies struct {
+	Catalog    ProductCatalog
+	Promotions model.PromotionStore
+	Pricer     *Pricer
+	Inventory  Inventory
+	Orders     *OrderService
+	Carrier    CarrierTracker
+	Knowledge  *knowledge.Base
+}
+
+// Registration describes a tool: its metadata and how to build it.
+type Registration struct {
+	Name     string
+	Category ToolCategory
+	Order    int // position within the category in listings and the prompt
+	Risk     ToolRisk
+	Timeout  time.Duration // deadline for one call
+	Owner    string        // team that owns the tool and its backend
+	// CacheTTL is how long results of a read-only tool are reused for the
+	// same arguments (see ToolCache); 0 disables caching.
+	CacheTTL time.Duration
+	// CacheKey adds what else the result depends on in the running
+	// conversation (e.g. remembered slots) to the cache key.
+	CacheKey func(context.Context) string
+	// LiveStock marks tools whose results include live inventory; their
+	// cached results are dropped whenever stock is reserved or released.
+	LiveStock bool
+	// Output trims the tool's results before the model sees them (see
+	// ShapeOutputs).
+	Output OutputShape
+	// Guidance lines tell the response model when to call the tool; they are
+	// listed in the prompt only while the tool is enabled.
+	Guidance []string
+	New      func(Dependencies) tool.BaseTool
+
+	seq int // registration order, for stable listings
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName map[string]Registration
+}{byName: map[string]Registration{}}
+
+// Register adds a tool to the registry. Tools register themselves from init;
+// it panics on a duplicate name or missing metadata.
+func Register(r Registration) {
+	if r.Name == "" || r.New == nil {
+		panic("tools: registration needs a name and a constructor")
+	}
+	if !r.Category.Valid() {
+		panic(fmt.Sprintf("tools: %s has unknown category %q", r.Name, r.Category))
+	}
+	if r.Risk == "" {
+		r.Risk = RiskReadOnly
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.byName[r.Name]; dup {
+		panic(fmt.Sprintf("tools: %s registered twice", r.Name))
+	}
+	r.seq = len(registry.byName)
+	registry.byName[r.Name] = r
+}
+
+// Lookup returns the registration of the named tool.
+func Lookup(name string) (Registration, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	r, ok := registry.byName[name]
+	return r, ok
+}
+
+// LiveStockTools returns the names of the registered tools marked LiveStock.
+func LiveStockTools() []string {
+	var names []string
+	for _, r := range Registered() {
+		if r.LiveStock {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// Registered lists every registered tool by category and order.
+func Registered() []Registration {
+	registry.RLock()
+	out := make([]Registration, 0, len(registry.byName))
+	for _, r := range registry.byName {
+		out = append(out, r)
+	}
+	registry.RUnlock()
+	sortRegistrations(out)
+	return out
+}
+
+func sortRegistrations(rs []Registration) {
+	sort.Slice(rs, func(i, j int) bool {
+		if ci, cj := categoryOrder[rs[i].Category], categoryOrder[rs[j].Category]; ci != cj {
+			return ci < cj
+		}
+		if rs[i].Order != rs[j].Order {
+			return rs[i].Order < rs[j].Order
+		}
+		return rs[i].seq < rs[j].seq
+	})
+}
+
+// Select returns the registered tools enabled for a deployment. Entries of
+// enabled and disabled are tool names or categories, matched regardless of
+// case; an empty enabled list enables every tool, and disabled wins over
+// enabled.
+func Select(enabled, disabled []string) ([]Registration, error) {
+	all := Registered()
+	match := func(entries []string) (map[string]bool, error) {
+		set := map[string]bool{}
+		for _, e := range entries {
+			e = strings.TrimSpace(e)
+			if e == "" {
+				continue
+			}
+			found := false
+			for _, r := range all {
+				if strings.EqualFold(r.Name, e) || strings.EqualFold(string(r.Category), e) {
+					set[r.Name] = true
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown tool or category %q", e)
+			}
+		}
+		return set, nil
+	}
+
+	on, err := match(enabled)
+	if err != nil {
+		return nil, err
+	}
+	off, err := match(disabled)
+	if err != nil {
+		return nil, err
+	}
+	var out []Registration
+	for _, r := range all {
+		if (len(on) == 0 || on[r.Name]) && !off[r.Name] {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// BuildTools constructs the selected tools from deps and checks that each
+// declares the name it was registered under.
+func BuildTools(ctx context.Context, regs []Registration, deps Dependencies) ([]tool.BaseTool, error) {
+	out := make([]tool.BaseTool, 0, len(regs))
+	for _, r := range regs {
+		t := r.New(deps)
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s info: %w", r.Name, err)
+		}
+		if info.Name != r.Name {
+			return nil, fmt.Errorf("tool registered as %s declares name %s", r.Name, info.Name)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+)
+
+func TestSelectMatchesRegardlessOfCase(t *testing.T) {
+	Register(Registration{
+		Name:     "Test_LookupWarranty",
+		Category: CategoryUtility,
+		New:      func(Dependencies) tool.BaseTool { return nil },
+	})
+
+	names := func(regs []Registration) map[string]bool {
+		out := map[string]bool{}
+		for _, r := range regs {
+			out[r.Name] = true
+		}
+		return out
+	}
+	tests := []struct {
+		name              string
+		enabled, disabled []string
+		want, notWant     []string
+	}{
+		{"exact name", []string{"Test_LookupWarranty"}, nil, []string{"Test_LookupWarranty"}, []string{ToolSearchProduct}},
+		{"lowercased name", []string{" test_lookupwarranty "}, nil, []string{"Test_LookupWarranty"}, nil},
+		{"uppercased category", []string{"QUERY"}, nil, []string{ToolSearchProduct}, []string{"Test_LookupWarranty"}},
+		{"disabled in another case", []string{"query", "utility"}, []string{"Search_Product", "TEST_LOOKUPWARRANTY"}, []string{ToolCheckStock}, []string{ToolSearchProduct, "Test_LookupWarranty"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regs, err := Select(tt.enabled, tt.disabled)
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			got := names(regs)
+			for _, n := range tt.want {
+				if !got[n] {
+					t.Errorf("%s not selected", n)
+				}
+			}
+			for _, n := range tt.notWant {
+				if got[n] {
+					t.Errorf("%s selected", n)
+				}
+			}
+		})
+	}
+
+	if _, err := Select([]string{"lookup_warranty"}, nil); err == nil {
+		t.Error("Select accepted an unknown tool")
+	}
+}
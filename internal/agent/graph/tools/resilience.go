@@ -0,0 +1,375 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// ErrTransient marks failures worth retrying, such as 5xx responses of a
+// backend. Wrap it with %w.
+var ErrTransient = errors.New("transient failure")
+
+// Retryable reports whether a failed call may succeed when repeated:
+// transient backend errors, timeouts and dropped connections.
+func Retryable(err error) bool {
+	if errors.Is(err, ErrTransient) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// ErrAnswered marks failures where the backend answered and declined the
+// request, such as an error response of an MCP server. Wrap it with %w.
+var ErrAnswered = errors.New("backend answered")
+
+// ErrInvalidRequest marks calls a tool refuses itself before reaching its
+// backend: missing or invalid arguments, or no conversation to act on. Wrap
+// it with %w.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Answered reports whether a failed call is an answer rather than an outage:
+// the backend answered with an unknown product or order, too little stock, an
+// empty cart or another refusal, or the tool refused the request itself.
+// Only these failures leave a tool's circuit breaker closed.
+func Answered(err error) bool {
+	for _, target := range []error{ErrAnswered, ErrInvalidRequest, ErrProductNotFound, model.ErrOrderNotFound, ErrInsufficientStock, ErrEmptyCart, ErrShipmentNotFound} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resilience defaults, used for zero config values.
+const (
+	defaultToolTimeout      = 10 * time.Second
+	defaultRetryBackoff     = 200 * time.Millisecond
+	defaultRetryMaxBackoff  = 2 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ResilienceConfig bounds tool execution.
+type ResilienceConfig struct {
+	Timeout          time.Duration            // per attempt, for tools registered without a timeout
+	Timeouts         map[string]time.Duration // per-tool overrides of the registered timeout
+	Retries          int                      // extra attempts after a retryable failure (read-only tools only)
+	RetryBackoff     time.Duration            // delay before the first retry, doubled for each further one
+	RetryMaxBackoff  time.Duration            // cap of the retry delay
+	BreakerThreshold int                      // consecutive failed calls that open a tool's breaker
+	BreakerCooldown  time.Duration            // how long an open breaker rejects calls before a probe
+}
+
+func (c *ResilienceConfig) normalize() {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultToolTimeout
+	}
+	if c.Retries < 0 {
+		c.Retries = 0
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaultRetryBackoff
+	}
+	if c.RetryMaxBackoff < c.RetryBackoff {
+		c.RetryMaxBackoff = max(defaultRetryMaxBackoff, c.RetryBackoff)
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = defaultBreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = defaultBreakerCooldown
+	}
+}
+
+// BreakerState is the state of a tool's circuit breaker.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // calls run
+	BreakerOpen     BreakerState = "open"      // calls are rejected until the cooldown ends
+	BreakerHalfOpen BreakerState = "half_open" // one probe call runs; its outcome closes or reopens the breaker
+)
+
+// BreakerStatus reports a tool's breaker for health checks.
+type BreakerStatus struct {
+	Tool      string       `json:"tool"`
+	State     BreakerState `json:"state"`
+	Failures  int          `json:"consecutive_failures"`
+	LastError string       `json:"last_error,omitempty"`
+	OpenedAt  time.Time    `json:"opened_at,omitzero"`
+	RetryAt   time.Time    `json:"retry_at,omitzero"` // when an open breaker lets a probe through
+}
+
+type breaker struct {
+	state    BreakerState
+	failures int
+	lastErr  string
+	openedAt time.Time
+	probing  bool // a half-open probe is running
+}
+
+// Resilience wraps tools with per-attempt timeouts, retries with jittered
+// exponential backoff, and a circuit breaker per tool. Breakers count failed
+// calls; only errors the backend answered with (see Answered) show it is up
+// and reset the count, like successes. An open breaker answers calls with a
+// temporarily_unavailable result instead of running them, so the model can
+// tell the customer rather than the turn failing. Breakers are shared by all
+// conversations.
+type Resilience struct {
+	cfg ResilienceConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// NewResilience creates the wrapper; zero config values take defaults.
+func NewResilience(cfg ResilienceConfig) *Resilience {
+	cfg.normalize()
+	return &Resilience{cfg: cfg, now: time.Now, breakers: map[string]*breaker{}}
+}
+
+// Wrap applies timeouts, retries and breakers to the invokable tools of ts.
+// Timeouts come from the tool's registration unless overridden in the
+// config; only read-only tools are retried, since repeating a side effect
+// that may already have happened is not safe.
+func (r *Resilience) Wrap(ctx context.Context, ts []tool.BaseTool) ([]tool.BaseTool, error) {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		it, ok := t.(tool.InvokableTool)
+		if !ok {
+			out[i] = t
+			continue
+		}
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool info: %w", err)
+		}
+		rt := &resilientTool{InvokableTool: it, name: info.Name, timeout: r.cfg.Timeout, parent: r}
+		if reg, ok := Lookup(info.Name); ok {
+			if reg.Timeout > 0 {
+				rt.timeout = reg.Timeout
+			}
+			if reg.Risk == RiskReadOnly {
+				rt.retries = r.cfg.Retries
+			}
+		}
+		if d, ok := r.cfg.Timeouts[info.Name]; ok && d > 0 {
+			rt.timeout = d
+		}
+		r.mu.Lock()
+		if r.breakers[info.Name] == nil {
+			r.breakers[info.Name] = &breaker{state: BreakerClosed}
+		}
+		r.mu.Unlock()
+		out[i] = rt
+	}
+	return out, nil
+}
+
+// Health reports every tool's breaker, sorted by tool name.
+func (r *Resilience) Health() []BreakerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]BreakerStatus, 0, len(r.breakers))
+	for name, b := range r.breakers {
+		st := BreakerStatus{Tool: name, State: b.state, Failures: b.failures, LastError: b.lastErr}
+		if b.state != BreakerClosed {
+			st.OpenedAt = b.openedAt
+			st.RetryAt = b.openedAt.Add(r.cfg.BreakerCooldown)
+		}
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tool < out[j].Tool })
+	return out
+}
+
+// allow reports whether a call may run; when not, it returns how long until
+// the breaker lets a probe through.
+func (r *Resilience) allow(name string) (bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakers[name]
+	switch b.state {
+	case BreakerOpen:
+		wait := b.openedAt.Add(r.cfg.BreakerCooldown).Sub(r.now())
+		if wait > 0 {
+			return false, wait
+		}
+		b.state, b.probing = BreakerHalfOpen, true
+		logx.Info().Str("tool_name", name).Msg("Tool circuit breaker half-open; probing")
+		return true, 0
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, time.Second
+		}
+		b.probing = true
+	}
+	return true, 0
+}
+
+// record updates a breaker with the outcome of a call.
+func (r *Resilience) record(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := r.breakers[name]
+	b.probing = false
+	if err == nil {
+		if b.state != BreakerClosed {
+			logx.Info().Str("tool_name", name).Msg("Tool circuit breaker closed")
+		}
+		b.state, b.failures, b.lastErr = BreakerClosed, 0, ""
+		return
+	}
+	b.failures++
+	b.lastErr = err.Error()
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= r.cfg.BreakerThreshold) {
+		b.state, b.openedAt = BreakerOpen, r.now()
+		logx.Warn().
+			Str("tool_name", name).
+			Int("consecutive_failures", b.failures).
+			Dur("cooldown", r.cfg.BreakerCooldown).
+			Str("last_error", b.lastErr).
+			Msg("Tool circuit breaker opened")
+	}
+}
+
+// release ends a probe whose caller went away without an outcome.
+func (r *Resilience) release(name string) {
+	r.mu.Lock()
+	r.breakers[name].probing = false
+	r.mu.Unlock()
+}
+
+// backoff is the delay before retry n (0-based): exponential with equal
+// jitter, so concurrent callers do not retry in lockstep.
+func (r *Resilience) backoff(n int) time.Duration {
+	d := r.cfg.RetryBackoff << n
+	if d <= 0 || d > r.cfg.RetryMaxBackoff {
+		d = r.cfg.RetryMaxBackoff
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+type resilientTool struct {
+	tool.InvokableTool
+	name    string
+	timeout time.Duration
+	retries int
+	parent  *Resilience
+}
+
+func (t *resilientTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	r := t.parent
+	if ok, wait := r.allow(t.name); !ok {
+		logx.Warn().Str("tool_name", t.name).Dur("retry_after", wait).Msg("Tool circuit breaker open; call rejected")
+		return unavailableResult(t.name, wait), nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		out, err := t.attempt(ctx, arguments, opts...)
+		if err == nil {
+			r.record(t.name, nil)
+			return out, nil
+		}
+		if ctx.Err() != nil {
+			r.release(t.name)
+			return "", err
+		}
+		if !Retryable(err) {
+			if Answered(err) {
+				// the backend answered (e.g. product not found): not an outage
+				r.record(t.name, nil)
+			} else {
+				r.record(t.name, err)
+			}
+			return "", err
+		}
+		if attempt >= t.retries {
+			r.record(t.name, err)
+			return "", err
+		}
+		delay := r.backoff(attempt)
+		logx.Warn().
+			Err(err).
+			Str("tool_name", t.name).
+			Int("attempt", attempt+1).
+			Dur("backoff", delay).
+			Msg("Tool call failed; retrying")
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			r.release(t.name)
+			return "", err
+		}
+	}
+}
+
+// attempt runs one call under the tool's timeout. Tools that ignore their
+// context are abandoned when it expires; their late result is discarded.
+func (t *resilientTool) attempt(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	actx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	type result struct {
+		out string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- result{err: fmt.Errorf("%s panicked: %v", t.name, p)}
+			}
+		}()
+		out, err := t.InvokableTool.InvokableRun(actx, arguments, opts...)
+		done <- result{out, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil && ctx.Err() == nil && errors.Is(actx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("%s timed out after %s: %w", t.name, t.timeout, res.err)
+		}
+		return res.out, res.err
+	case <-actx.Done():
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%s timed out after %s: %w", t.name, t.timeout, context.DeadlineExceeded)
+	}
+}
+
+// unavailableResult is the tool result for a call rejected by an open breaker.
+func unavailableResult(name string, wait time.Duration) string {
+	b, err := json.Marshal(map[string]any{
+		"error":               "temporarily_unavailable",
+		"tool":                name,
+		"retry_after_seconds": int(math.Ceil(wait.Seconds())),
+		"hint":                "This tool is failing right now; do not call it again this turn. Tell the customer the information is temporarily unavailable and offer to help otherwise.",
+	})
+	if err != nil {
+		return fmt.Sprintf(`{"error":"temporarily_unavailable","tool":%q}`, name)
+	}
+	return string(b)
+}
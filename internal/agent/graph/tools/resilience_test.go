@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
+)
+
+// failingTool fails every call with err and counts the calls that ran.
+type failingTool struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (t *failingTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: t.name}, nil
+}
+
+func (t *failingTool) InvokableRun(context.Context, string, ...tool.Option) (string, error) {
+	t.calls++
+	if t.err != nil {
+		return "", t.err
+	}
+	return `{"ok":true}`, nil
+}
+
+func wrapForTest(t *testing.T, r *Resilience, bt tool.BaseTool) tool.InvokableTool {
+	t.Helper()
+	wrapped, err := r.Wrap(context.Background(), []tool.BaseTool{bt})
+	if err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+	return wrapped[0].(tool.InvokableTool)
+}
+
+func breakerOf(r *Resilience, name string) BreakerStatus {
+	for _, st := range r.Health() {
+		if st.Tool == name {
+			return st
+		}
+	}
+	return BreakerStatus{}
+}
+
+func TestResilienceBreakerOpensOnOutages(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close() // connections are refused from now on
+	refused := newTestHTTPTool(t, HTTPToolSpec{Name: "get_warranty_status", URL: down.URL})
+	_, transportErr := refused.InvokableRun(context.Background(), "")
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"5xx response", fmt.Errorf("status 503: %w", ErrTransient)},
+		{"refused connection", transportErr},
+		{"unclassified error", errors.New("catalog service returned 401: invalid api key")},
+		{"malformed response", fmt.Errorf("decode response: %w", errors.New("unexpected end of JSON input"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("no error to test with")
+			}
+			r := NewResilience(ResilienceConfig{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+			ft := &failingTool{name: "flaky", err: tt.err}
+			wt := wrapForTest(t, r, ft)
+
+			for i := 0; i < 2; i++ {
+				if _, err := wt.InvokableRun(context.Background(), "{}"); err == nil {
+					t.Fatalf("call %d succeeded", i+1)
+				}
+			}
+			if st := breakerOf(r, "flaky"); st.State != BreakerOpen || st.Failures != 2 {
+				t.Fatalf("breaker = %+v, want open after 2 failures", st)
+			}
+			out, err := wt.InvokableRun(context.Background(), "{}")
+			if err != nil || !strings.Contains(out, "temporarily_unavailable") {
+				t.Errorf("call on open breaker = %q, %v; want a temporarily_unavailable result", out, err)
+			}
+			if ft.calls != 2 {
+				t.Errorf("tool ran %d times, want 2", ft.calls)
+			}
+		})
+	}
+
+	if !errors.Is(transportErr, ErrTransient) {
+		t.Errorf("refused connection %v does not wrap ErrTransient", transportErr)
+	}
+}
+
+func TestResilienceBreakerIgnoresAnswers(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"product not found", fmt.Errorf("get p9: %w", ErrProductNotFound)},
+		{"insufficient stock", fmt.Errorf("%w: 1 of p1 available", ErrInsufficientStock)},
+		{"empty cart", ErrEmptyCart},
+		{"unknown shipment", ErrShipmentNotFound},
+		{"unknown order", fmt.Errorf("%w: ORD-1", model.ErrOrderNotFound)},
+		{"refused request", fmt.Errorf("%w: address is required for shipping", ErrInvalidRequest)},
+		{"declined request", fmt.Errorf("mcp error -32602: bad branch: %w", ErrAnswered)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResilience(ResilienceConfig{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+			ft := &failingTool{name: "lookup", err: tt.err}
+			wt := wrapForTest(t, r, ft)
+
+			for i := 0; i < 5; i++ {
+				if _, err := wt.InvokableRun(context.Background(), "{}"); !errors.Is(err, tt.err) {
+					t.Fatalf("call %d = %v, want %v", i+1, err, tt.err)
+				}
+			}
+			if st := breakerOf(r, "lookup"); st.State != BreakerClosed || st.Failures != 0 {
+				t.Errorf("breaker = %+v, want closed", st)
+			}
+			if ft.calls != 5 {
+				t.Errorf("tool ran %d times, want 5", ft.calls)
+			}
+		})
+	}
+}
+
+func TestResilienceBreakerIgnoresToolRefusals(t *testing.T) {
+	orders, inventory := newTestOrderService(repo.NewMemoryOrderStore())
+	catalog, pricer := orders.catalog, orders.pricer
+	tests := []struct {
+		name string
+		tool tool.BaseTool
+		args string
+	}{
+		{"unknown product stock", createCheckStockTool(catalog, inventory), `{"product_id":"prod-999"}`},
+		{"missing product_id", createCheckStockTool(catalog, inventory), `{}`},
+		{"unknown product details", createGetProductDetailsTool(catalog, pricer, inventory), `{"product_id":"prod-999"}`},
+		{"unknown product price", createGetProductPriceTool(catalog, pricer, inventory), `{"product_id":"prod-999"}`},
+		{"too few products to compare", createCompareProductsTool(catalog, pricer, inventory), `{"product_ids":["prod-a"]}`},
+		{"unknown products to compare", createCompareProductsTool(catalog, pricer, inventory), `{"product_ids":["prod-a","prod-999"]}`},
+		{"no conversation", createAddToCartTool(orders), `{"product_id":"prod-a"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResilience(ResilienceConfig{BreakerThreshold: 1, BreakerCooldown: time.Minute})
+			wt := wrapForTest(t, r, tt.tool)
+			for i := 0; i < 2; i++ {
+				out, err := wt.InvokableRun(context.Background(), tt.args)
+				if err == nil {
+					t.Fatalf("call %d = %q, want the tool's error", i+1, out)
+				}
+				if !Answered(err) {
+					t.Errorf("call %d error %q is not an answer", i+1, err)
+				}
+			}
+			if st := breakerOf(r, breakerName(t, tt.tool)); st.State != BreakerClosed || st.Failures != 0 {
+				t.Errorf("breaker = %+v, want closed", st)
+			}
+		})
+	}
+}
+
+func breakerName(t *testing.T, bt tool.BaseTool) string {
+	t.Helper()
+	info, err := bt.Info(context.Background())
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	return info.Name
+}
+
+func TestResilienceBreakerProbe(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := NewResilience(ResilienceConfig{BreakerThreshold: 1, BreakerCooldown: time.Minute})
+	r.now = func() time.Time { return now }
+	ft := &failingTool{name: "flaky", err: fmt.Errorf("status 502: %w", ErrTransient)}
+	wt := wrapForTest(t, r, ft)
+
+	_, _ = wt.InvokableRun(context.Background(), "{}")
+	if st := breakerOf(r, "flaky"); st.State != BreakerOpen {
+		t.Fatalf("breaker = %+v, want open", st)
+	}
+
+	// a failed probe reopens the breaker
+	now = now.Add(time.Minute)
+	_, _ = wt.InvokableRun(context.Background(), "{}")
+	if st := breakerOf(r, "flaky"); st.State != BreakerOpen || ft.calls != 2 {
+		t.Fatalf("after failed probe: breaker = %+v, calls = %d", st, ft.calls)
+	}
+
+	// a successful one closes it
+	now = now.Add(time.Minute)
+	ft.err = nil
+	if out, err := wt.InvokableRun(context.Background(), "{}"); err != nil || out != `{"ok":true}` {
+		t.Fatalf("probe = %q, %v", out, err)
+	}
+	if st := breakerOf(r, "flaky"); st.State != BreakerClosed || st.Failures != 0 {
+		t.Errorf("after successful probe: breaker = %+v, want closed", st)
+	}
+}
@@ -0,0 +1,29 @@
+package tools
+
+// ToolRisk classifies what a tool call can change.
+type ToolRisk string
+
+const (
+	RiskReadOnly   ToolRisk = "read_only"   // only reads catalog, pricing, stock or the customer's own data
+	RiskSideEffect ToolRisk = "side_effect" // changes carts or orders; needs the customer's confirmation
+)
+
+// Valid reports whether r is a known risk class.
+func (r ToolRisk) Valid() bool {
+	return r == RiskReadOnly || r == RiskSideEffect
+}
+
+// RiskOf returns the risk class of a registered tool. Unknown names are
+// read-only since the tools node never executes them (see UnknownToolsHandler).
+func RiskOf(name string) ToolRisk {
+	if r, ok := Lookup(name); ok {
+		return r.Risk
+	}
+	return RiskReadOnly
+}
+
+// NeedsConfirmation reports whether calls to the tool must be confirmed by the
+// customer before they run.
+func NeedsConfirmation(name string) bool {
+	return RiskOf(name) == RiskSideEffect
+}
@@ -0,0 +1,251 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Query term weights relative to a literal match.
+const (
+	synonymWeight = 0.8
+	fuzzyWeight1  = 0.7 // one edit away
+	fuzzyWeight2  = 0.5 // two edits away
+)
+
+// Field is one weighted text field of a document; Weight scales its term
+// frequencies (e.g., name 3, description 1).
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Document is an indexable item.
+type Document struct {
+	ID     string
+	Fields []Field
+}
+
+// Hit is a ranked search result.
+type Hit struct {
+	ID      string
+	Score   float64  // BM25 relevance scaled by query term coverage
+	Matched []string // query terms (after tokenization) that matched
+}
+
+// Options restricts a search.
+type Options struct {
+	Limit  int                  // maximum hits; <= 0 means no limit
+	Filter func(id string) bool // optional; hits must satisfy it
+}
+
+type posting struct {
+	doc int
+	tf  float64
+}
+
+// Index is an immutable in-memory BM25 index. Build a new one to change contents.
+type Index struct {
+	ids      []string
+	docLen   []float64
+	avgLen   float64
+	postings map[string][]posting
+	vocab    []string // sorted, for fuzzy lookups
+}
+
+// NewIndex tokenizes and indexes docs.
+func NewIndex(docs []Document) *Index {
+	ix := &Index{postings: map[string][]posting{}}
+	total := 0.0
+	for i, d := range docs {
+		tf := map[string]float64{}
+		length := 0.0
+		for _, f := range d.Fields {
+			w := f.Weight
+			if w <= 0 {
+				w = 1
+			}
+			for _, tok := range Tokenize(f.Text) {
+				tf[tok] += w
+				length += w
+			}
+		}
+		ix.ids = append(ix.ids, d.ID)
+		ix.docLen = append(ix.docLen, length)
+		total += length
+		for term, freq := range tf {
+			ix.postings[term] = append(ix.postings[term], posting{doc: i, tf: freq})
+		}
+	}
+	if len(docs) > 0 {
+		ix.avgLen = total / float64(len(docs))
+	}
+	for term := range ix.postings {
+		ix.vocab = append(ix.vocab, term)
+	}
+	sort.Strings(ix.vocab)
+	return ix
+}
+
+// Len returns the number of indexed documents.
+func (ix *Index) Len() int {
+	return len(ix.ids)
+}
+
+// Search ranks documents for query. Each query term is matched literally,
+// through its synonyms and, when it is not in the index vocabulary, through
+// fuzzy matches of up to two edits. Documents matching more of the query's
+// terms rank higher; documents matching none are omitted.
+func (ix *Index) Search(query string, opts Options) []Hit {
+	terms := dedupe(Tokenize(query))
+	if len(terms) == 0 || len(ix.ids) == 0 {
+		return nil
+	}
+
+	scores := map[int]float64{}
+	matched := map[int][]string{}
+	for _, term := range terms {
+		hitDocs := map[int]bool{}
+		for variant, weight := range ix.variants(term) {
+			plist := ix.postings[variant]
+			idf := ix.idf(len(plist))
+			for _, p := range plist {
+				if opts.Filter != nil && !opts.Filter(ix.ids[p.doc]) {
+					continue
+				}
+				norm := p.tf + bm25K1*(1-bm25B+bm25B*ix.docLen[p.doc]/ix.avgLen)
+				scores[p.doc] += weight * idf * p.tf * (bm25K1 + 1) / norm
+				hitDocs[p.doc] = true
+			}
+		}
+		for d := range hitDocs {
+			matched[d] = append(matched[d], term)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for d, s := range scores {
+		coverage := float64(len(matched[d])) / float64(len(terms))
+		hits = append(hits, Hit{
+			ID:      ix.ids[d],
+			Score:   math.Round(s*(0.5+0.5*coverage)*1000) / 1000,
+			Matched: matched[d],
+		})
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	return hits
+}
+
+// variants maps term to the index terms it should match, with weights.
+func (ix *Index) variants(term string) map[string]float64 {
+	out := map[string]float64{}
+	add := func(t string, w float64) {
+		if _, ok := ix.postings[t]; ok && w > out[t] {
+			out[t] = w
+		}
+	}
+	add(term, 1)
+	for _, syn := range Expand(term) {
+		add(syn, synonymWeight)
+	}
+	if len(out) == 0 {
+		for cand, d := range ix.fuzzy(term) {
+			w := fuzzyWeight1
+			if d > 1 {
+				w = fuzzyWeight2
+			}
+			add(cand, w)
+		}
+	}
+	return out
+}
+
+// fuzzy returns vocabulary terms within the allowed edit distance of term.
+func (ix *Index) fuzzy(term string) map[string]int {
+	n := utf8.RuneCountInString(term)
+	maxD := 0
+	switch {
+	case n >= 8:
+		maxD = 2
+	case n >= 4:
+		maxD = 1
+	}
+	if maxD == 0 {
+		return nil
+	}
+	out := map[string]int{}
+	for _, cand := range ix.vocab {
+		m := utf8.RuneCountInString(cand)
+		if m < n-maxD || m > n+maxD || m < 3 {
+			continue
+		}
+		if d := editDistance(term, cand, maxD); d <= maxD {
+			out[cand] = d
+		}
+	}
+	return out
+}
+
+func (ix *Index) idf(df int) float64 {
+	n := float64(len(ix.ids))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func dedupe(terms []string) []string {
+	seen := map[string]bool{}
+	out := terms[:0]
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// editDistance is the optimal string alignment (Damerau-Levenshtein with
+// adjacent transpositions) distance over runes. It returns maxD+1 as soon as
+// the distance is known to exceed maxD.
+func editDistance(a, b string, maxD int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > maxD {
+			return maxD + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
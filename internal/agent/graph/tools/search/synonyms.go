@@ -0,0 +1,129 @@
+package search
+
+// synonyms expands query terms to the vocabulary used in the catalog
+// (mostly English). Expansion is query-side only: "คอม" finds laptops, but
+// "laptop" does not turn into every Thai spelling. Keys are post-Tokenize
+// terms (lowercase, stemmed).
+var synonyms = map[string][]string{
+	// computers
+	"คอม":             {"laptop", "computer"},
+	"คอมพิวเตอร์":     {"computer", "laptop"},
+	"คอมพิวเตอร์พกพา": {"laptop", "notebook"},
+	"โน้ตบุ๊ค":        {"laptop", "notebook"},
+	"โน๊ตบุ๊ค":        {"laptop", "notebook"},
+	"โน้ตบุ๊ก":        {"laptop", "notebook"},
+	"โน๊ตบุ๊ก":        {"laptop", "notebook"},
+	"แล็ปท็อป":        {"laptop"},
+	"แลปท็อป":         {"laptop"},
+	"แล็ปทอป":         {"laptop"},
+	"อัลตร้าบุ๊ค":     {"ultrabook", "laptop"},
+	"notebook":        {"laptop"},
+	"computer":        {"laptop"},
+	"pc":              {"computer", "laptop"},
+	"แมคบุ๊ค":         {"macbook"},
+	"แมคบุ๊ก":         {"macbook"},
+
+	// phones
+	"มือถือ":         {"smartphone", "phone"},
+	"โทรศัพท์":       {"smartphone", "phone"},
+	"โทรศัพท์มือถือ": {"smartphone", "phone"},
+	"สมาร์ทโฟน":      {"smartphone"},
+	"สมาร์ตโฟน":      {"smartphone"},
+	"phone":          {"smartphone"},
+	"mobile":         {"smartphone"},
+	"ไอโฟน":          {"iphone"},
+
+	// tablets
+	"แท็บเล็ต": {"tablet"},
+	"แท็บเลต":  {"tablet"},
+	"แทบเล็ต":  {"tablet"},
+	"ไอแพด":    {"ipad", "tablet"},
+
+	// audio
+	"หูฟัง":         {"headphone", "earbud", "audio"},
+	"หูฟังไร้สาย":   {"wireless", "earbud", "headphone"},
+	"ไร้สาย":        {"wireless"},
+	"ลำโพง":         {"speaker", "audio"},
+	"ตัดเสียงรบกวน": {"noise", "cancellation"},
+	"earphone":      {"earbud", "headphone"},
+	"headset":       {"headphone"},
+
+	// wearables
+	"นาฬิกา":      {"watch", "smartwatch"},
+	"สมาร์ทวอช":   {"smartwatch", "watch"},
+	"สมาร์ทวอทช์": {"smartwatch", "watch"},
+	"smartwatch":  {"watch"},
+
+	// use cases and qualities
+	"เกม":       {"gaming"},
+	"เกมส์":     {"gaming"},
+	"เล่นเกม":   {"gaming"},
+	"เกมมิ่ง":   {"gaming"},
+	"game":      {"gaming"},
+	"gamer":     {"gaming"},
+	"ทำงาน":     {"work", "productivity"},
+	"ออฟฟิศ":    {"office", "work"},
+	"ทั่วไป":    {"everyday", "general"},
+	"เรียน":     {"student", "study"},
+	"นักเรียน":  {"student"},
+	"นักศึกษา":  {"student"},
+	"ราคาถูก":   {"budget", "affordable"},
+	"ประหยัด":   {"budget", "affordable"},
+	"คุ้มค่า":   {"budget", "value"},
+	"cheap":     {"budget", "affordable"},
+	"กล้อง":     {"camera"},
+	"ถ่ายรูป":   {"camera", "photo"},
+	"ถ่ายภาพ":   {"camera", "photo"},
+	"แบต":       {"battery"},
+	"แบตเตอรี่": {"battery"},
+	"จอ":        {"display"},
+	"หน้าจอ":    {"display"},
+	"การ์ดจอ":   {"graphic", "gpu"},
+	"แรม":       {"ram", "memory"},
+	"เบา":       {"lightweight", "light"},
+	"บาง":       {"thin"},
+	"พกพา":      {"portable", "lightweight"},
+	"กันน้ำ":    {"water", "resistant"},
+	"ตัดต่อ":    {"editing", "creative"},
+	"วิดีโอ":    {"video"},
+	"กราฟิก":    {"graphic", "design"},
+
+	// store policies (knowledge base)
+	"ประกัน":        {"warranty"},
+	"รับประกัน":     {"warranty"},
+	"เคลม":          {"claim", "warranty"},
+	"ซ่อม":          {"repair"},
+	"คืน":           {"return", "refund"},
+	"คืนสินค้า":     {"return"},
+	"คืนเงิน":       {"refund"},
+	"เปลี่ยนสินค้า": {"exchange", "return"},
+	"ชำรุด":         {"defective", "damaged"},
+	"เสีย":          {"defective"},
+	"ส่ง":           {"shipping", "delivery"},
+	"จัดส่ง":        {"shipping", "delivery"},
+	"ค่าส่ง":        {"shipping", "fee"},
+	"ค่าจัดส่ง":     {"shipping", "fee"},
+	"พัสดุ":         {"parcel", "shipping"},
+	"รับที่สาขา":    {"pickup", "branch"},
+	"ผ่อน":          {"installment"},
+	"ชำระ":          {"payment", "paid"},
+	"จ่ายเงิน":      {"payment"},
+	"ใบกำกับภาษี":   {"tax", "invoice"},
+
+	// brands
+	"ซัมซุง":   {"samsung"},
+	"แอปเปิ้ล": {"apple"},
+	"แอปเปิล":  {"apple"},
+	"เลอโนโว":  {"lenovo"},
+	"เอเซอร์":  {"acer"},
+	"เอซุส":    {"asus"},
+	"อัสซุส":   {"asus"},
+	"เดล":      {"dell"},
+	"โซนี่":    {"sony"},
+	"เอชพี":    {"hp"},
+}
+
+// Expand returns the synonym expansions of term (nil when none).
+func Expand(term string) []string {
+	return synonyms[term]
+}
@@ -0,0 +1,198 @@
+// Package search implements the offline product search index: Thai
+// dictionary segmentation, English tokenization, synonym expansion, BM25
+// ranking and fuzzy matching for typos.
+package search
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed thai_words.txt
+var thaiWordList string
+
+// thaiDict is the segmentation dictionary; maxThaiWord is its longest entry in runes.
+var thaiDict, maxThaiWord = loadDictionary(thaiWordList)
+
+func loadDictionary(list string) (map[string]bool, int) {
+	dict := map[string]bool{}
+	longest := 0
+	for _, line := range strings.Split(list, "\n") {
+		w := strings.TrimSpace(line)
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		dict[w] = true
+		if n := utf8.RuneCountInString(w); n > longest {
+			longest = n
+		}
+	}
+	return dict, longest
+}
+
+// stopWords carry no product meaning (Thai particles, English function words).
+var stopWords = map[string]bool{
+	"ครับ": true, "ค่ะ": true, "คะ": true, "นะ": true, "จ้า": true, "ที่": true, "และ": true,
+	"หรือ": true, "กับ": true, "ของ": true, "ให้": true, "ไหม": true, "มั้ย": true, "ขอ": true,
+	"อยาก": true, "อยากได้": true, "ได้": true, "หา": true, "มี": true, "สำหรับ": true,
+	"a": true, "an": true, "the": true, "and": true, "or": true, "for": true, "with": true,
+	"of": true, "to": true, "in": true, "on": true, "is": true, "i": true, "want": true,
+}
+
+// Tokenize lowercases text and splits it into search terms. Latin/digit runs
+// are split on non-alphanumerics and lightly stemmed; Thai runs are segmented
+// with the dictionary. Stop words are dropped.
+func Tokenize(text string) []string {
+	var out []string
+	emit := func(tok string) {
+		if tok != "" && !stopWords[tok] {
+			out = append(out, tok)
+		}
+	}
+	for _, run := range scriptRuns(strings.ToLower(text)) {
+		if run.thai {
+			for _, w := range SegmentThai(run.text) {
+				emit(w)
+			}
+			continue
+		}
+		emit(stemEnglish(run.text))
+	}
+	return out
+}
+
+type textRun struct {
+	text string
+	thai bool
+}
+
+// scriptRuns splits s into maximal runs of Thai characters or Latin/digit
+// characters, discarding everything else.
+func scriptRuns(s string) []textRun {
+	var runs []textRun
+	var cur strings.Builder
+	curThai := false
+	flush := func() {
+		if cur.Len() > 0 {
+			runs = append(runs, textRun{text: cur.String(), thai: curThai})
+			cur.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case isThai(r):
+			if !curThai {
+				flush()
+				curThai = true
+			}
+			cur.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if curThai {
+				flush()
+				curThai = false
+			}
+			cur.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return runs
+}
+
+func isThai(r rune) bool {
+	return r >= 0x0E00 && r <= 0x0E7F && r != 'ๆ' && r != '฿'
+}
+
+// SegmentThai splits a run of Thai text into words using the dictionary.
+// It minimizes the number of characters not covered by dictionary words, then
+// the number of words (preferring longer words); adjacent unknown characters
+// are kept together as one token.
+func SegmentThai(s string) []string {
+	runes := []rune(s)
+	n := len(runes)
+	if n == 0 {
+		return nil
+	}
+
+	type cost struct{ unknown, words int }
+	less := func(a, b cost) bool {
+		if a.unknown != b.unknown {
+			return a.unknown < b.unknown
+		}
+		return a.words < b.words
+	}
+	const inf = 1 << 30
+	best := make([]cost, n+1)
+	prev := make([]int, n+1)   // start of the last piece ending at i
+	known := make([]bool, n+1) // whether that piece is a dictionary word
+	for i := 1; i <= n; i++ {
+		best[i] = cost{inf, inf}
+	}
+
+	for i := 1; i <= n; i++ {
+		for l := 1; l <= maxThaiWord && l <= i; l++ {
+			j := i - l
+			if best[j].unknown == inf || !thaiDict[string(runes[j:i])] {
+				continue
+			}
+			if c := (cost{best[j].unknown, best[j].words + 1}); less(c, best[i]) {
+				best[i], prev[i], known[i] = c, j, true
+			}
+		}
+		// one unknown character; consecutive unknowns are merged below
+		j := i - 1
+		if best[j].unknown != inf {
+			if c := (cost{best[j].unknown + 1, best[j].words + 1}); less(c, best[i]) {
+				best[i], prev[i], known[i] = c, j, false
+			}
+		}
+	}
+
+	type piece struct {
+		text  string
+		known bool
+	}
+	var rev []piece
+	for i := n; i > 0; i = prev[i] {
+		rev = append(rev, piece{string(runes[prev[i]:i]), known[i]})
+	}
+
+	var pieces []string
+	var unknown []rune
+	for k := len(rev) - 1; k >= 0; k-- {
+		p := rev[k]
+		if p.known {
+			if len(unknown) > 0 {
+				pieces = append(pieces, string(unknown))
+				unknown = unknown[:0]
+			}
+			pieces = append(pieces, p.text)
+			continue
+		}
+		unknown = append(unknown, []rune(p.text)...)
+	}
+	if len(unknown) > 0 {
+		pieces = append(pieces, string(unknown))
+	}
+	return pieces
+}
+
+// stemEnglish strips a plural "s" from Latin words ("laptops" -> "laptop").
+// Tokens containing digits (model numbers, "8gb") are left alone.
+func stemEnglish(tok string) string {
+	if len(tok) <= 3 || strings.IndexFunc(tok, unicode.IsDigit) >= 0 {
+		return tok
+	}
+	switch {
+	case strings.HasSuffix(tok, "ss"), strings.HasSuffix(tok, "us"), strings.HasSuffix(tok, "is"):
+		return tok
+	case strings.HasSuffix(tok, "ies") && len(tok) > 4:
+		return tok[:len(tok)-3] + "y"
+	case strings.HasSuffix(tok, "s"):
+		return tok[:len(tok)-1]
+	}
+	return tok
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// search_product sort orders.
+const (
+	SortRelevance = "relevance"
+	SortPrice     = "price" // ascending
+	SortPriceDesc = "price_desc"
+)
+
+// Spec keys read by the spec filters, in lookup order.
+var (
+	ramSpecKeys     = []string{"ram", "memory"}
+	storageSpecKeys = []string{"storage", "ssd"}
+	gpuSpecKeys     = []string{"graphics", "gpu", "chip"}
+)
+
+// capacityPattern matches "8GB", "512 GB", "1TB".
+var capacityPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(gb|tb)\b`)
+
+// ParseCapacityGB returns the largest capacity mentioned in s, in GB
+// ("256GB, 512GB, 1TB SSD" -> 1024). Plain numbers are read as GB.
+func ParseCapacityGB(s string) (float64, bool) {
+	best := 0.0
+	found := false
+	for _, m := range capacityPattern.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(m[2], "tb") {
+			v *= 1024
+		}
+		if v > best {
+			best, found = v, true
+		}
+	}
+	if !found {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil && v > 0 {
+			return v, true
+		}
+	}
+	return best, found
+}
+
+// specValue returns the first non-empty specification among keys.
+func specValue(p model.Product, keys []string) string {
+	for _, k := range keys {
+		if v := strings.TrimSpace(p.Specifications[k]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func productRAMGB(p model.Product) (float64, bool) {
+	return ParseCapacityGB(specValue(p, ramSpecKeys))
+}
+
+func productStorageGB(p model.Product) (float64, bool) {
+	return ParseCapacityGB(specValue(p, storageSpecKeys))
+}
+
+// productFilter holds the validated structured filters of a search.
+type productFilter struct {
+	MinPrice     float64
+	MaxPrice     float64 // 0 = no upper bound
+	Brands       []string
+	InStockOnly  bool
+	MinRAMGB     float64
+	MinStorageGB float64
+	GPU          string // case-insensitive substring of the graphics spec
+}
+
+func (f productFilter) active() bool {
+	return f.MinPrice > 0 || f.MaxPrice > 0 || len(f.Brands) > 0 || f.InStockOnly ||
+		f.MinRAMGB > 0 || f.MinStorageGB > 0 || f.GPU != ""
+}
+
+// match reports whether p satisfies every filter. Products lacking a spec
+// that a spec filter needs do not match.
+func (f productFilter) match(p model.Product) bool {
+	if f.MinPrice > 0 && p.Price < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && p.Price > f.MaxPrice {
+		return false
+	}
+	if len(f.Brands) > 0 && !containsFold(f.Brands, p.Brand) {
+		return false
+	}
+	if f.InStockOnly && !p.InStock {
+		return false
+	}
+	if f.MinRAMGB > 0 {
+		if ram, ok := productRAMGB(p); !ok || ram < f.MinRAMGB {
+			return false
+		}
+	}
+	if f.MinStorageGB > 0 {
+		if st, ok := productStorageGB(p); !ok || st < f.MinStorageGB {
+			return false
+		}
+	}
+	if f.GPU != "" && !strings.Contains(strings.ToLower(specValue(p, gpuSpecKeys)), strings.ToLower(f.GPU)) {
+		return false
+	}
+	return true
+}
+
+// SearchFacets summarizes a result set so the assistant can suggest ways to
+// narrow it ("12 laptops: 5 under 30,000 THB, brands Acer 2, ASUS 1 ...").
+type SearchFacets struct {
+	Brands      map[string]int `json:"brands,omitempty"`
+	Categories  map[string]int `json:"categories,omitempty"`
+	PriceRanges []PriceBucket  `json:"price_ranges,omitempty"`
+	InStock     int            `json:"in_stock"`
+	OutOfStock  int            `json:"out_of_stock"`
+	RAM         map[string]int `json:"ram,omitempty"`
+	Storage     map[string]int `json:"storage,omitempty"`
+}
+
+// PriceBucket counts products with Min <= price < Max (Max 0 = open-ended).
+type PriceBucket struct {
+	Label string  `json:"label"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max,omitempty"`
+	Count int     `json:"count"`
+}
+
+// priceBucketBounds are the THB price band edges used for facets.
+var priceBucketBounds = []float64{10000, 20000, 30000, 40000}
+
+func buildFacets(hits []SearchHit) SearchFacets {
+	f := SearchFacets{
+		Brands:     map[string]int{},
+		Categories: map[string]int{},
+		RAM:        map[string]int{},
+		Storage:    map[string]int{},
+	}
+	buckets := make([]PriceBucket, len(priceBucketBounds)+1)
+	lo := 0.0
+	for i := range buckets {
+		buckets[i].Min = lo
+		if i < len(priceBucketBounds) {
+			buckets[i].Max = priceBucketBounds[i]
+			buckets[i].Label = fmt.Sprintf("%s-%s", formatBaht(lo), formatBaht(priceBucketBounds[i]))
+			lo = priceBucketBounds[i]
+		} else {
+			buckets[i].Label = formatBaht(lo) + "+"
+		}
+	}
+
+	for _, h := range hits {
+		p := h.Product
+		if p.Brand != "" {
+			f.Brands[p.Brand]++
+		}
+		if p.Category != "" {
+			f.Categories[p.Category]++
+		}
+		if p.InStock {
+			f.InStock++
+		} else {
+			f.OutOfStock++
+		}
+		if ram, ok := productRAMGB(p); ok {
+			f.RAM[formatCapacity(ram)]++
+		}
+		if st, ok := productStorageGB(p); ok {
+			f.Storage[formatCapacity(st)]++
+		}
+		for i := range buckets {
+			if p.Price >= buckets[i].Min && (buckets[i].Max == 0 || p.Price < buckets[i].Max) {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	for _, b := range buckets {
+		if b.Count > 0 {
+			f.PriceRanges = append(f.PriceRanges, b)
+		}
+	}
+	return f
+}
+
+// sortHits orders hits for sortBy; relevance keeps the catalog ranking.
+func sortHits(hits []SearchHit, sortBy string) {
+	switch sortBy {
+	case SortPrice:
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].Price < hits[j].Price })
+	case SortPriceDesc:
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].Price > hits[j].Price })
+	}
+}
+
+func formatBaht(v float64) string {
+	if v >= 1000 && v == float64(int(v/1000))*1000 {
+		return strconv.Itoa(int(v/1000)) + "k"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatCapacity(gb float64) string {
+	if gb >= 1024 && int(gb)%1024 == 0 {
+		return strconv.Itoa(int(gb)/1024) + "TB"
+	}
+	return strconv.FormatFloat(gb, 'f', -1, 64) + "GB"
+}
@@ -3,7 +3,9 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
 	"github.com/cloudwego/eino/components/tool"
@@ -16,175 +18,246 @@ import (
 // ===================================
 
 type SearchProductInput struct {
-	Query      string `json:"query"`
-	Category   string `json:"category,omitempty"`
-	MaxResults int    `json:"max_results,omitempty"`
+	Query        string   `json:"query,omitempty"`
+	Category     string   `json:"category,omitempty"`
+	MaxResults   int      `json:"max_results,omitempty"`
+	MinPrice     float64  `json:"min_price,omitempty"`
+	MaxPrice     float64  `json:"max_price,omitempty"`
+	Brands       []string `json:"brands,omitempty"`
+	InStockOnly  bool     `json:"in_stock_only,omitempty"`
+	SortBy       string   `json:"sort_by,omitempty"` // relevance (default), price, price_desc
+	MinRAMGB     float64  `json:"min_ram_gb,omitempty"`
+	MinStorageGB float64  `json:"min_storage_gb,omitempty"`
+	GPU          string   `json:"gpu,omitempty"`
 }
 
 type SearchProductOutput struct {
-	Products []model.Product `json:"products"`
-	Total    int             `json:"total"`
+	Products []SearchHit  `json:"products"` // ranked by relevance unless sort_by says otherwise; score is the BM25 relevance
+	Total    int          `json:"total"`    // matches before max_results truncation
+	Facets   SearchFacets `json:"facets"`   // counts over all matches, for suggesting narrower searches
+	// Set when the filters matched nothing: facets then describe the unfiltered
+	// matches so the assistant can propose relaxing a filter.
+	FiltersRelaxed bool `json:"filters_relaxed,omitempty"`
+	// In-stock alternatives (same category and price band) keyed by the ID of
+	// each returned product that is out of stock.
+	Alternatives map[string][]model.Product `json:"alternatives,omitempty"`
 }
 
-func createSearchProductTool() tool.BaseTool {
+func init() {
+	Register(Registration{
+		Name:      ToolSearchProduct,
+		Category:  CategoryQuery,
+		Order:     10,
+		Timeout:   5 * time.Second,
+		Owner:     "catalog",
+		CacheTTL:  2 * time.Minute,
+		LiveStock: true,
+		CacheKey:  searchCacheKey,
+		Output: OutputShape{
+			// alternatives only need enough to name and quote them
+			Keep: []string{
+				"products", "total", "facets", "filters_relaxed",
+				"alternatives.*.id", "alternatives.*.name", "alternatives.*.brand", "alternatives.*.price", "alternatives.*.in_stock",
+			},
+			MaxItems: 10,
+		},
+		Guidance: []string{"Any product mention → call " + ToolSearchProduct + " with user keywords (Thai/English)"},
+		New:      func(d Dependencies) tool.BaseTool { return createSearchProductTool(d.Catalog, d.Inventory) },
+	})
+}
+
+// searchCacheKey is what search results depend on besides the arguments: the
+// remembered product type and budget, and the preferred brands.
+func searchCacheKey(ctx context.Context) string {
+	lo, hi, _ := budgetDefault(ctx)
+	return fmt.Sprintf("%s|%g|%g|%s", slotDefault(ctx, model.SlotProductType), lo, hi, strings.Join(preferredBrands(ctx), ","))
+}
+
+func createSearchProductTool(catalog ProductCatalog, inventory Inventory) tool.BaseTool {
 	return utils.NewTool(
 		&schema.ToolInfo{
-			Name: "search_product",
-			Desc: "Search for products in inventory. Supports Thai/English keywords including: มือถือ, โทรศัพท์, smartphone, phone, คอมพิวเตอร์, laptop, computer, แล็ปท็อป, โน้ตบุ๊ค. Always returns structured product data with ID, name, price, and availability. Use this tool whenever customer mentions any product.",
-			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			Name: ToolSearchProduct,
+			Desc: "Search for products in inventory. Supports Thai/English keywords including: มือถือ, โทรศัพท์, smartphone, phone, คอมพิวเตอร์, laptop, computer, แล็ปท็อป, โน้ตบุ๊ค. Supports structured filters (price range, brands, stock, RAM, storage, GPU) and sorting by price. Always returns structured product data with ID, name, price, and live availability, in-stock alternatives for out-of-stock products, plus facet counts (brands, price ranges, RAM, storage) for suggesting narrower options. Use this tool whenever customer mentions any product or a budget.",
+			ParamsOneOf: newParams(map[string]*schema.ParameterInfo{
 				"query": {
-					Type:     "string",
-					Desc:     "Product search keywords in Thai or English. Examples: มือถือ, smartphone, คอม, laptop, iPhone, Samsung, MacBook. Can include brand names, product types, or model numbers.",
-					Required: true,
+					Type: "string",
+					Desc: "Product search keywords in Thai or English. Examples: มือถือ, smartphone, คอม, laptop, iPhone, Samsung, MacBook. Can include brand names, product types, or model numbers. May be empty when category or filters are given.",
 				},
 				"category": {
 					Type: "string",
 					Desc: "Optional category filter. Available categories: smartphones, laptops, tablets, audio, wearables",
 				},
 				"max_results": {
-					Type: "number",
+					Type: "integer",
 					Desc: "Maximum number of products to return (default: 10, max: 20)",
 				},
+				"min_price": {
+					Type: "number",
+					Desc: "Minimum price in THB",
+				},
+				"max_price": {
+					Type: "number",
+					Desc: "Maximum price in THB, e.g. 40000 for งบ 40,000 บาท. Defaults to the customer's remembered budget.",
+				},
+				"brands": {
+					Type:     "array",
+					ElemInfo: &schema.ParameterInfo{Type: "string"},
+					Desc:     "Only return these brands, e.g. [\"Apple\", \"Samsung\"]",
+				},
+				"in_stock_only": {
+					Type: "boolean",
+					Desc: "Only return products that are in stock",
+				},
+				"sort_by": {
+					Type: "string",
+					Enum: []string{SortRelevance, SortPrice, SortPriceDesc},
+					Desc: "Result order: relevance (default), price (cheapest first) or price_desc (most expensive first)",
+				},
+				"min_ram_gb": {
+					Type: "number",
+					Desc: "Minimum RAM/memory in GB, e.g. 16",
+				},
+				"min_storage_gb": {
+					Type: "number",
+					Desc: "Minimum storage in GB, e.g. 512 (1TB = 1024)",
+				},
+				"gpu": {
+					Type: "string",
+					Desc: "Graphics keyword the product must mention, e.g. NVIDIA, RTX, GTX 1650, Radeon",
+				},
+			}, map[string]paramLimits{
+				"max_results":    between(1, 20),
+				"min_price":      atLeast(0),
+				"max_price":      atLeast(0),
+				"min_ram_gb":     atLeast(0),
+				"min_storage_gb": atLeast(0),
 			}),
 		},
 		func(ctx context.Context, in *SearchProductInput) (*SearchProductOutput, error) {
-			if in.Query == "" {
-				return nil, fmt.Errorf("query is required")
+			filter := productFilter{
+				MinPrice:     in.MinPrice,
+				MaxPrice:     in.MaxPrice,
+				Brands:       in.Brands,
+				InStockOnly:  in.InStockOnly,
+				MinRAMGB:     in.MinRAMGB,
+				MinStorageGB: in.MinStorageGB,
+				GPU:          strings.TrimSpace(in.GPU),
+			}
+			if filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+				filter.MinPrice, filter.MaxPrice = filter.MaxPrice, filter.MinPrice
+			}
+			if in.Query == "" && in.Category == "" && !filter.active() {
+				return nil, fmt.Errorf("%w: query, category or a filter is required", ErrInvalidRequest)
 			}
 
 			if in.MaxResults == 0 {
 				in.MaxResults = 10
 			}
 
-			// Search through mock products
-			var matchedProducts []model.Product
-			queryLower := strings.ToLower(in.Query)
-
-			for _, product := range MockProducts {
-				// Simple search matching name, category, or description
-				if strings.Contains(strings.ToLower(product.Name), queryLower) ||
-					strings.Contains(strings.ToLower(product.Category), queryLower) ||
-					strings.Contains(strings.ToLower(product.Description), queryLower) {
+			// Default the category from the dialogue state when the model omits it
+			categoryDefaulted := false
+			if in.Category == "" {
+				if pt := slotDefault(ctx, model.SlotProductType); pt != "" {
+					in.Category = pt
+					categoryDefaulted = true
+				}
+			}
 
-					// Filter by category if specified
-					if in.Category != "" && !strings.EqualFold(product.Category, in.Category) {
-						continue
-					}
+			// Likewise default the price range from the remembered budget
+			budgetDefaulted := false
+			if filter.MinPrice == 0 && filter.MaxPrice == 0 {
+				if lo, hi, ok := budgetDefault(ctx); ok {
+					filter.MinPrice, filter.MaxPrice = lo, hi
+					budgetDefaulted = true
+				}
+			}
 
-					matchedProducts = append(matchedProducts, product)
+			matchedProducts, err := catalog.Search(ctx, CatalogQuery{Text: in.Query, Category: in.Category})
+			if err != nil {
+				return nil, fmt.Errorf("search catalog: %w", err)
+			}
+			if len(matchedProducts) == 0 && categoryDefaulted {
+				// the remembered product type may not apply to this query
+				matchedProducts, err = catalog.Search(ctx, CatalogQuery{Text: in.Query})
+				if err != nil {
+					return nil, fmt.Errorf("search catalog: %w", err)
 				}
 			}
 
+			if err := applyAvailability(ctx, inventory, matchedProducts); err != nil {
+				return nil, err
+			}
+
+			filtered := filterHits(matchedProducts, filter)
+			if len(filtered) == 0 && budgetDefaulted {
+				// the remembered budget may not apply to this product
+				filter.MinPrice, filter.MaxPrice = in.MinPrice, in.MaxPrice
+				filtered = filterHits(matchedProducts, filter)
+			}
+
+			result := &SearchProductOutput{}
+			if len(filtered) == 0 && filter.active() {
+				result.Facets = buildFacets(matchedProducts)
+				result.FiltersRelaxed = true
+			} else {
+				result.Facets = buildFacets(filtered)
+			}
+			matchedProducts = filtered
+
+			// Surface brands the customer mentioned (this turn or earlier) first
+			if brands := preferredBrands(ctx); len(brands) > 0 && len(filter.Brands) == 0 {
+				sort.SliceStable(matchedProducts, func(i, j int) bool {
+					return containsFold(brands, matchedProducts[i].Brand) && !containsFold(brands, matchedProducts[j].Brand)
+				})
+			}
+			sortHits(matchedProducts, in.SortBy)
+
+			result.Total = len(matchedProducts)
 			if len(matchedProducts) > in.MaxResults {
 				matchedProducts = matchedProducts[:in.MaxResults]
 			}
-
-			result := &SearchProductOutput{
-				Products: matchedProducts,
-				Total:    len(matchedProducts),
+			for i := range matchedProducts {
+				if !matchedProducts[i].InStock {
+					alts, err := findAlternatives(ctx, catalog, inventory, matchedProducts[i].Product)
+					if err != nil {
+						return nil, err
+					}
+					if len(alts) > 0 {
+						if result.Alternatives == nil {
+							result.Alternatives = map[string][]model.Product{}
+						}
+						result.Alternatives[matchedProducts[i].ID] = alts
+					}
+				}
+				matchedProducts[i].Product = productSummary(matchedProducts[i].Product)
 			}
+			result.Products = matchedProducts
 
 			return result, nil
 		},
 	)
 }
 
-var MockProducts = []model.Product{
-	{
-		ID:          "prod-001",
-		Name:        "iPhone 15 Pro",
-		Category:    "smartphones",
-		Price:       39900.00,
-		Description: "Latest iPhone with A17 Pro chip, titanium design, and advanced camera system โทรศัพท์ไอโฟน สมาร์ทโฟน",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-002",
-		Name:        "Samsung Galaxy S24 Ultra",
-		Category:    "smartphones",
-		Price:       42900.00,
-		Description: "Premium Android phone with S Pen, 200MP camera, and AI features โทรศัพท์แอนดรอยด์ สมาร์ทโฟน",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-003",
-		Name:        "MacBook Air M3",
-		Category:    "laptops",
-		Price:       42900.00,
-		Description: "Lightweight laptop with M3 chip, 13-inch Liquid Retina display โน้ตบุ๊ค แล็ปท็อป คอมพิวเตอร์พกพา งานทั่วไป",
-		InStock:     false,
-	},
-	{
-		ID:          "prod-004",
-		Name:        "AirPods Pro (3rd generation)",
-		Category:    "audio",
-		Price:       8900.00,
-		Description: "Wireless earbuds with active noise cancellation and spatial audio",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-005",
-		Name:        "iPad Pro 12.9-inch",
-		Category:    "tablets",
-		Price:       35900.00,
-		Description: "Professional tablet with M2 chip and Liquid Retina XDR display",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-006",
-		Name:        "Sony WH-1000XM5",
-		Category:    "audio",
-		Price:       12900.00,
-		Description: "Premium wireless headphones with industry-leading noise cancellation",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-007",
-		Name:        "Dell XPS 13",
-		Category:    "laptops",
-		Price:       35900.00,
-		Description: "Premium ultrabook with Intel 13th Gen processors and InfinityEdge display โน้ตบุ๊ค แล็ปท็อป อัลตร้าบุ๊ค งานทั่วไป",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-008",
-		Name:        "Apple Watch Ultra 2",
-		Category:    "wearables",
-		Price:       29900.00,
-		Description: "Rugged smartwatch for outdoor adventures with precise GPS นาฬิกาอัจฉริยะ สมาร์ทวอช",
-		InStock:     false,
-	},
-	// เพิ่มโน้ตบุ๊คสำหรับงบประมาณ 30,000 บาท
-	{
-		ID:          "prod-009",
-		Name:        "Acer Aspire 5 A515-58",
-		Category:    "laptops",
-		Price:       28900.00,
-		Description: "Budget laptop Intel Core i5, 8GB RAM, 512GB SSD สำหรับงานทั่วไป โน้ตบุ๊ค แล็ปท็อป คอมพิวเตอร์พกพา ราคาประหยัด งานทั่วไป เล่นเกม",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-010",
-		Name:        "Lenovo IdeaPad 3 Gaming",
-		Category:    "laptops",
-		Price:       29500.00,
-		Description: "Gaming laptop AMD Ryzen 5, 8GB RAM, GTX 1650 สำหรับเล่นเกม โน้ตบุ๊ค แล็ปท็อป เกมมิ่ง งานทั่วไป เล่นเกม",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-011",
-		Name:        "HP Pavilion 15-eh3000",
-		Category:    "laptops",
-		Price:       27900.00,
-		Description: "All-purpose laptop AMD Ryzen 5, 8GB RAM, 256GB SSD สำหรับงานทั่วไปและเล่นเกมเบาๆ โน้ตบุ๊ค แล็ปท็อป คอมพิวเตอร์พกพา งานทั่วไป เล่นเกม",
-		InStock:     true,
-	},
-	{
-		ID:          "prod-012",
-		Name:        "ASUS VivoBook 15 X1502ZA",
-		Category:    "laptops",
-		Price:       24900.00,
-		Description: "Affordable laptop Intel Core i3, 8GB RAM, 512GB SSD สำหรับงานเบาๆ โน้ตบุ๊ค แล็ปท็อป ราคาประหยัด งานทั่วไป",
-		InStock:     true,
-	},
+// filterHits returns the hits matching f, keeping their order.
+func filterHits(hits []SearchHit, f productFilter) []SearchHit {
+	if !f.active() {
+		return hits
+	}
+	out := make([]SearchHit, 0, len(hits))
+	for _, h := range hits {
+		if f.match(h.Product) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// OutputShape trims a tool's JSON result before the model sees it.
+type OutputShape struct {
+	// Keep lists the fields to keep as dotted paths. Arrays are passed
+	// through (products.name keeps the name of every product) and * matches
+	// any key. Empty keeps every field.
+	Keep []string
+	// MaxItems caps every array of the result; the dropped elements are
+	// replaced by an "N more results omitted" marker. 0 keeps all.
+	MaxItems int
+}
+
+// EstimateTokens is a rough, conservative token count of s: four ASCII
+// bytes or one other character (e.g. Thai) per token.
+func EstimateTokens(s string) int {
+	ascii, other := 0, 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// ShapeOutputs wraps the invokable tools of ts so their results are shaped
+// for the model: fields are projected and arrays capped by the registered
+// OutputShape, then results above maxTokens (0 = no cap) have their longest
+// arrays halved and, as a last resort, their text cut. Error results are
+// left alone. The full output of a shaped call is logged and reaches the
+// tool callbacks as the full_output note, so audits still see everything.
+func ShapeOutputs(ctx context.Context, ts []tool.BaseTool, maxTokens int) ([]tool.BaseTool, error) {
+	out := make([]tool.BaseTool, len(ts))
+	for i, t := range ts {
+		out[i] = t
+		it, ok := t.(tool.InvokableTool)
+		if !ok {
+			continue
+		}
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool info: %w", err)
+		}
+		reg, _ := Lookup(info.Name)
+		if len(reg.Output.Keep) == 0 && reg.Output.MaxItems <= 0 && maxTokens <= 0 {
+			continue
+		}
+		out[i] = &shapedTool{
+			InvokableTool: it,
+			name:          info.Name,
+			shaper:        newShaper(reg.Output, maxTokens),
+		}
+	}
+	return out, nil
+}
+
+type shapedTool struct {
+	tool.InvokableTool
+	name   string
+	shaper *shaper
+}
+
+func (t *shapedTool) InvokableRun(ctx context.Context, arguments string, opts ...tool.Option) (string, error) {
+	out, err := t.InvokableTool.InvokableRun(ctx, arguments, opts...)
+	if err != nil || IsErrorResult(out) {
+		return out, err
+	}
+	shaped, omitted := t.shaper.shape(out)
+	if shaped == out {
+		return out, nil
+	}
+	annotate(ctx, "full_output", out)
+	annotate(ctx, "omitted_items", omitted)
+	logx.Debug().
+		Str("tool_name", t.name).
+		Int("tokens", EstimateTokens(out)).
+		Int("shaped_tokens", EstimateTokens(shaped)).
+		Int("omitted_items", omitted).
+		Str("full_output", out).
+		Msg("Tool output shaped")
+	return shaped, nil
+}
+
+// keepTree is the parsed form of OutputShape.Keep; a nil subtree keeps the
+// whole value.
+type keepTree map[string]keepTree
+
+func parseKeep(paths []string) keepTree {
+	if len(paths) == 0 {
+		return nil
+	}
+	root := keepTree{}
+	for _, p := range paths {
+		cur := root
+		segs := strings.Split(strings.TrimSpace(p), ".")
+		for i, seg := range segs {
+			child, seen := cur[seg]
+			if seen && child == nil {
+				break // an ancestor is kept whole
+			}
+			if i == len(segs)-1 {
+				cur[seg] = nil
+				break
+			}
+			if !seen {
+				child = keepTree{}
+				cur[seg] = child
+			}
+			cur = child
+		}
+	}
+	return root
+}
+
+// omittedMarker stands for the dropped tail of a capped array.
+type omittedMarker int
+
+func (m omittedMarker) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d more results omitted", int(m)))
+}
+
+type shaper struct {
+	keep      keepTree
+	maxItems  int
+	maxTokens int
+}
+
+func newShaper(s OutputShape, maxTokens int) *shaper {
+	return &shaper{keep: parseKeep(s.Keep), maxItems: s.MaxItems, maxTokens: maxTokens}
+}
+
+// shape returns the result as the model should see it, and how many array
+// elements were dropped. An unchanged result is returned as is.
+func (s *shaper) shape(out string) (string, int) {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(out))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return s.cut(out), 0
+	}
+
+	changed := false
+	if s.keep != nil {
+		v, changed = project(v, s.keep), true
+	}
+	box := []any{v} // lets a top-level array be capped like a nested one
+	omitted := 0
+	if s.maxItems > 0 {
+		omitted = capArrays(box, s.maxItems)
+	}
+	if !changed && omitted == 0 && (s.maxTokens <= 0 || EstimateTokens(out) <= s.maxTokens) {
+		return out, 0
+	}
+
+	shaped, err := encodeJSON(box[0])
+	if err != nil {
+		return s.cut(out), 0
+	}
+	for s.maxTokens > 0 && EstimateTokens(shaped) > s.maxTokens {
+		n := halveLongest(box)
+		if n == 0 {
+			break
+		}
+		omitted += n
+		if shaped, err = encodeJSON(box[0]); err != nil {
+			return s.cut(out), 0
+		}
+	}
+	return s.cut(shaped), omitted
+}
+
+// cut truncates text above the token cap, saying how much was left out.
+func (s *shaper) cut(out string) string {
+	if s.maxTokens <= 0 || EstimateTokens(out) <= s.maxTokens {
+		return out
+	}
+	runes := []rune(out)
+	budget := s.maxTokens * 4 // ASCII characters
+	keep := 0
+	for tokens := 0; keep < len(runes); keep++ {
+		if runes[keep] < utf8.RuneSelf {
+			tokens++
+		} else {
+			tokens += 4
+		}
+		if tokens > budget {
+			break
+		}
+	}
+	return fmt.Sprintf("%s… [output truncated: %d more characters omitted]", string(runes[:keep]), len(runes)-keep)
+}
+
+// project keeps the fields of v selected by keep.
+func project(v any, keep keepTree) any {
+	if keep == nil {
+		return v
+	}
+	switch vv := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(keep))
+		for k, e := range vv {
+			child, ok := keep[k]
+			if !ok {
+				if child, ok = keep["*"]; !ok {
+					continue
+				}
+			}
+			out[k] = project(e, child)
+		}
+		return out
+	case []any:
+		for i, e := range vv {
+			vv[i] = project(e, keep)
+		}
+		return vv
+	default:
+		return v
+	}
+}
+
+// capArrays cuts the arrays in v to n elements plus an omitted marker, in
+// place, and returns how many elements were dropped.
+func capArrays(v any, n int) int {
+	dropped := 0
+	walkArrays(v, func(a []any) []any {
+		if len(a) <= n {
+			return a
+		}
+		dropped += len(a) - n
+		return append(a[:n:n], omittedMarker(len(a)-n))
+	})
+	return dropped
+}
+
+// halveLongest halves the array of v with the most elements and returns how
+// many were dropped; 0 when no array has more than one.
+func halveLongest(v any) int {
+	longest := 1
+	walkArrays(v, func(a []any) []any {
+		longest = max(longest, kept(a))
+		return a
+	})
+	if longest <= 1 {
+		return 0
+	}
+	dropped, done := 0, false
+	walkArrays(v, func(a []any) []any {
+		k := kept(a)
+		if done || k != longest {
+			return a
+		}
+		done = true
+		dropped = k - k/2
+		marker := omittedMarker(dropped)
+		if m, ok := a[len(a)-1].(omittedMarker); ok {
+			marker += m
+		}
+		return append(a[:k/2:k/2], marker)
+	})
+	return dropped
+}
+
+// kept is the number of elements of a before its omitted marker.
+func kept(a []any) int {
+	if len(a) > 0 {
+		if _, ok := a[len(a)-1].(omittedMarker); ok {
+			return len(a) - 1
+		}
+	}
+	return len(a)
+}
+
+// walkArrays calls fn on every array in v, innermost first, and stores the
+// array it returns in place of the original.
+func walkArrays(v any, fn func([]any) []any) {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, e := range vv {
+			walkArrays(e, fn)
+			if a, ok := e.([]any); ok {
+				vv[k] = fn(a)
+			}
+		}
+	case []any:
+		for i, e := range vv {
+			walkArrays(e, fn)
+			if a, ok := e.([]any); ok {
+				vv[i] = fn(a)
+			}
+		}
+	}
+}
+
+// encodeJSON marshals v compactly, leaving <, > and & unescaped.
+func encodeJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrShipmentNotFound is returned by CarrierTracker.Track for unknown tracking numbers.
+var ErrShipmentNotFound = errors.New("shipment not found")
+
+// Shipment statuses reported by carriers.
+const (
+	ShipmentLabelCreated   = "label_created"
+	ShipmentInTransit      = "in_transit"
+	ShipmentOutForDelivery = "out_for_delivery"
+	ShipmentDelivered      = "delivered"
+	ShipmentException      = "exception" // failed delivery attempt, damage or loss
+)
+
+// TrackingEvent is one scan in a shipment's history.
+type TrackingEvent struct {
+	Time        time.Time `json:"time"`
+	Status      string    `json:"status"`
+	Location    string    `json:"location,omitempty"`
+	Description string    `json:"description"`
+}
+
+// ShipmentTracking is a carrier's view of a parcel. Events are newest first.
+type ShipmentTracking struct {
+	Carrier           string          `json:"carrier"`
+	TrackingNumber    string          `json:"tracking_number"`
+	Status            string          `json:"status"`
+	EstimatedDelivery *time.Time      `json:"estimated_delivery,omitempty"`
+	DeliveredAt       *time.Time      `json:"delivered_at,omitempty"`
+	Events            []TrackingEvent `json:"events"`
+}
+
+// CarrierTracker looks parcels up with a shipping carrier.
+type CarrierTracker interface {
+	// Track returns the parcel's tracking, or ErrShipmentNotFound
+	Track(ctx context.Context, carrier, trackingNumber string) (*ShipmentTracking, error)
+}
+
+// FakeCarrier is an in-memory CarrierTracker for local runs and demos.
+// Parcels are keyed by tracking number regardless of carrier.
+type FakeCarrier struct {
+	mu        sync.RWMutex
+	shipments map[string]ShipmentTracking
+}
+
+func NewFakeCarrier(shipments []ShipmentTracking) *FakeCarrier {
+	c := &FakeCarrier{shipments: map[string]ShipmentTracking{}}
+	for _, s := range shipments {
+		c.Put(s)
+	}
+	return c
+}
+
+// Put adds or replaces a parcel.
+func (c *FakeCarrier) Put(s ShipmentTracking) {
+	s.Events = append([]TrackingEvent(nil), s.Events...)
+	sort.SliceStable(s.Events, func(i, j int) bool { return s.Events[i].Time.After(s.Events[j].Time) })
+	c.mu.Lock()
+	c.shipments[strings.ToUpper(s.TrackingNumber)] = s
+	c.mu.Unlock()
+}
+
+func (c *FakeCarrier) Track(ctx context.Context, carrier, trackingNumber string) (*ShipmentTracking, error) {
+	c.mu.RLock()
+	s, ok := c.shipments[strings.ToUpper(strings.TrimSpace(trackingNumber))]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, ErrShipmentNotFound
+	}
+	s.Events = append([]TrackingEvent(nil), s.Events...)
+	return &s, nil
+}
+
+var _ CarrierTracker = (*FakeCarrier)(nil)
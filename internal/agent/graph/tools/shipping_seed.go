@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// DemoCustomerID owns the demo orders; send it as customer_id to try the
+// order status and tracking tools without placing an order first.
+const DemoCustomerID = "demo-customer"
+
+// DefaultShipments returns demo parcels for DemoOrders: one delivered, one in
+// transit on time and one stuck past its promised date. Times are relative
+// to now.
+func DefaultShipments(now time.Time) []ShipmentTracking {
+	day := 24 * time.Hour
+	at := func(days float64) time.Time { return now.Add(time.Duration(days * float64(day))) }
+	eta := at(1)
+	delivered := at(-6)
+	return []ShipmentTracking{
+		{
+			Carrier:        "Kerry Express",
+			TrackingNumber: "TH100001",
+			Status:         ShipmentDelivered,
+			DeliveredAt:    &delivered,
+			Events: []TrackingEvent{
+				{Time: at(-9), Status: ShipmentLabelCreated, Location: "Bangna Warehouse", Description: "Shipping label created"},
+				{Time: at(-8), Status: ShipmentInTransit, Location: "Bangkok Hub", Description: "Parcel picked up by carrier"},
+				{Time: at(-6.2), Status: ShipmentOutForDelivery, Location: "Bang Kapi", Description: "Out for delivery"},
+				{Time: delivered, Status: ShipmentDelivered, Location: "Bang Kapi", Description: "Delivered, signed by recipient"},
+			},
+		},
+		{
+			Carrier:           "Flash Express",
+			TrackingNumber:    "TH100002",
+			Status:            ShipmentInTransit,
+			EstimatedDelivery: &eta,
+			Events: []TrackingEvent{
+				{Time: at(-1), Status: ShipmentLabelCreated, Location: "Bangna Warehouse", Description: "Shipping label created"},
+				{Time: at(-0.5), Status: ShipmentInTransit, Location: "Chiang Mai Hub", Description: "Arrived at destination hub"},
+			},
+		},
+		{
+			Carrier:        "Thailand Post",
+			TrackingNumber: "TH100003",
+			Status:         ShipmentInTransit,
+			Events: []TrackingEvent{
+				{Time: at(-7), Status: ShipmentLabelCreated, Location: "Bangna Warehouse", Description: "Shipping label created"},
+				{Time: at(-6), Status: ShipmentInTransit, Location: "Lak Si Sorting Center", Description: "Delayed at sorting center"},
+			},
+		},
+	}
+}
+
+// DemoOrders returns DemoCustomerID's orders matching DefaultShipments.
+func DemoOrders(now time.Time) []*model.Order {
+	day := 24 * time.Hour
+	order := func(id string, placedDaysAgo int, line model.OrderLine, status, carrier, tracking string) *model.Order {
+		placed := now.Add(-time.Duration(placedDaysAgo) * day)
+		line.LineTotal = line.UnitPrice*float64(line.Quantity) - line.Discount
+		return &model.Order{
+			ID:          id,
+			OwnerID:     DemoCustomerID,
+			Status:      status,
+			Lines:       []model.OrderLine{line},
+			Subtotal:    line.LineTotal,
+			Total:       line.LineTotal,
+			Currency:    "THB",
+			Fulfillment: model.FulfillmentShipping,
+			Address:     "99/1 Sukhumvit Rd, Khlong Toei, Bangkok 10110",
+			PromisedBy:  placed.Add(shippingLeadTime),
+			Shipment:    &model.Shipment{Carrier: carrier, TrackingNumber: tracking, ShippedAt: placed.Add(day)},
+			CreatedAt:   placed,
+			UpdatedAt:   placed.Add(day),
+		}
+	}
+	return []*model.Order{
+		order("ORD-DEMO-000001", 10, model.OrderLine{ProductID: "prod-004", Name: "AirPods Pro (3rd generation)", Quantity: 1, UnitPrice: 8900}, model.OrderDelivered, "Kerry Express", "TH100001"),
+		order("ORD-DEMO-000002", 2, model.OrderLine{ProductID: "prod-001", Name: "iPhone 15 Pro", Quantity: 1, UnitPrice: 39900}, model.OrderShipped, "Flash Express", "TH100002"),
+		order("ORD-DEMO-000003", 8, model.OrderLine{ProductID: "prod-006", Name: "Sony WH-1000XM5", Quantity: 1, UnitPrice: 12900}, model.OrderShipped, "Thailand Post", "TH100003"),
+	}
+}
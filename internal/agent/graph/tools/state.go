@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// analysisFromContext returns the NLU analysis of the current turn when the tool
+// runs inside the agent graph. Outside a graph run (no local state) it reports false.
+func analysisFromContext(ctx context.Context) (*model.NLUResponse, bool) {
+	var nlu *model.NLUResponse
+	err := compose.ProcessState(ctx, func(_ context.Context, s *model.AppState) error {
+		if s.NLUAnalysis != nil {
+			cp := *s.NLUAnalysis
+			nlu = &cp
+		}
+		return nil
+	})
+	if err != nil || nlu == nil {
+		return nil, false
+	}
+	return nlu, true
+}
+
+// dialogueFromContext returns the conversation's slot state when the tool runs
+// inside the agent graph and dialogue tracking is enabled.
+func dialogueFromContext(ctx context.Context) (*model.DialogueState, bool) {
+	var ds *model.DialogueState
+	err := compose.ProcessState(ctx, func(_ context.Context, s *model.AppState) error {
+		ds = s.DialogueState
+		return nil
+	})
+	if err != nil || ds == nil {
+		return nil, false
+	}
+	return ds, true
+}
+
+// ownerFromContext returns who owns carts and orders in the running
+// conversation (the customer ID when known, else the conversation ID) and the
+// conversation ID. It reports false outside a graph run.
+func ownerFromContext(ctx context.Context) (owner, conversationID string, ok bool) {
+	err := compose.ProcessState(ctx, func(_ context.Context, s *model.AppState) error {
+		owner, conversationID = s.CustomerID, s.ConversationID
+		return nil
+	})
+	if err != nil || conversationID == "" {
+		return "", "", false
+	}
+	if owner == "" {
+		owner = conversationID
+	}
+	return owner, conversationID, true
+}
+
+// raiseEscalation records e in the running query's state so the final answer
+// carries it, and logs it for staff. Repeats within a run are dropped.
+// Outside a graph run it is only logged.
+func raiseEscalation(ctx context.Context, e model.Escalation) {
+	var conversationID string
+	repeated := false
+	_ = compose.ProcessState(ctx, func(_ context.Context, s *model.AppState) error {
+		conversationID = s.ConversationID
+		for _, prev := range s.Escalations {
+			if prev.Reason == e.Reason && prev.OrderID == e.OrderID {
+				repeated = true
+				return nil
+			}
+		}
+		s.Escalations = append(s.Escalations, e)
+		return nil
+	})
+	if repeated {
+		return
+	}
+	logx.Warn().
+		Str("conversation_id", conversationID).
+		Str("order_id", e.OrderID).
+		Str("reason", e.Reason).
+		Str("severity", e.Severity).
+		Int("overdue_hours", e.OverdueHours).
+		Msg("Escalation raised")
+}
+
+// slotDefault returns the canonical text of a filled dialogue slot, or "".
+func slotDefault(ctx context.Context, slot string) string {
+	ds, ok := dialogueFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	v, ok := ds.Slot(slot)
+	if !ok {
+		return ""
+	}
+	if v.Normalized != nil && v.Normalized.Text != "" {
+		return v.Normalized.Text
+	}
+	return v.Value
+}
+
+// preferredBrands returns the canonical brands normalized from the current
+// message, falling back to the brand remembered in the dialogue state.
+func preferredBrands(ctx context.Context) []string {
+	var brands []string
+	if nlu, ok := analysisFromContext(ctx); ok {
+		for _, e := range nlu.NormalizedEntities("brand") {
+			if nv, ok := e.Normalized(); ok && nv.Text != "" {
+				brands = append(brands, nv.Text)
+			}
+		}
+	}
+	if len(brands) == 0 {
+		if b := slotDefault(ctx, model.SlotBrand); b != "" {
+			brands = append(brands, b)
+		}
+	}
+	return brands
+}
+
+// budgetApproxMargin widens an approximate budget ("around 30,000") upward.
+const budgetApproxMargin = 0.1
+
+// budgetDefault returns the price range implied by the remembered budget slot.
+// max is 0 when the budget only sets a lower bound.
+func budgetDefault(ctx context.Context) (min, max float64, ok bool) {
+	ds, found := dialogueFromContext(ctx)
+	if !found {
+		return 0, 0, false
+	}
+	v, found := ds.Slot(model.SlotBudget)
+	if !found || v.Normalized == nil || v.Normalized.Kind != "money" || v.Normalized.Amount <= 0 {
+		return 0, 0, false
+	}
+	n := v.Normalized
+	switch n.Bound {
+	case "min":
+		return n.Amount, 0, true
+	case "range":
+		return n.MinAmount, n.Amount, true
+	case "approx":
+		return 0, n.Amount * (1 + budgetApproxMargin), true
+	default: // exact, max
+		return 0, n.Amount, true
+	}
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// ===================================
+// Order Status and Shipment Tracking Tools
+// ===================================
+
+// overdueHighAfter is how late an order may run before the escalation is high.
+const overdueHighAfter = 48 * time.Hour
+
+type GetOrderStatusInput struct {
+	OrderID string `json:"order_id,omitempty"`
+}
+
+// OrderStatusView is an order with its delivery health.
+type OrderStatusView struct {
+	*model.Order
+	CarrierStatus string            `json:"carrier_status,omitempty"` // latest status from the carrier for shipped orders
+	Overdue       bool              `json:"overdue,omitempty"`
+	Escalation    *model.Escalation `json:"escalation,omitempty"`
+}
+
+type GetOrderStatusOutput struct {
+	Orders []OrderStatusView `json:"orders"`
+	Total  int               `json:"total"`
+}
+
+type TrackShipmentInput struct {
+	OrderID string `json:"order_id,omitempty"`
+}
+
+type TrackShipmentOutput struct {
+	OrderID     string            `json:"order_id"`
+	OrderStatus string            `json:"order_status"`
+	Fulfillment string            `json:"fulfillment"`
+	PromisedBy  *time.Time        `json:"promised_by,omitempty"`
+	Tracking    *ShipmentTracking `json:"tracking,omitempty"`
+	Overdue     bool              `json:"overdue,omitempty"`
+	Escalation  *model.Escalation `json:"escalation,omitempty"`
+	Message     string            `json:"message,omitempty"` // why there is no tracking, when there is none
+}
+
+// escalationGuidance tells the model how to present a raised escalation.
+const escalationGuidance = "A tool result with an escalation (late or problem delivery) → apologize, give the facts from the tool (promised date, latest carrier status) and say our staff has been notified and will follow up; never promise a new delivery date yourself"
+
+func init() {
+	Register(Registration{
+		Name:     ToolGetOrderStatus,
+		Category: CategoryUtility,
+		Order:    10,
+		Timeout:  8 * time.Second,
+		Owner:    "fulfillment",
+		Guidance: []string{"Customer asks about an existing order → call " + ToolGetOrderStatus, escalationGuidance},
+		New:      func(d Dependencies) tool.BaseTool { return createGetOrderStatusTool(d.Orders, d.Carrier) },
+	})
+	Register(Registration{
+		Name:     ToolTrackShipment,
+		Category: CategoryUtility,
+		Order:    20,
+		Timeout:  8 * time.Second,
+		Owner:    "fulfillment",
+		Guidance: []string{"Customer asks where a parcel is or when it arrives → call " + ToolTrackShipment, escalationGuidance},
+		New:      func(d Dependencies) tool.BaseTool { return createTrackShipmentTool(d.Orders, d.Carrier) },
+	})
+}
+
+func createGetOrderStatusTool(orders *OrderService, carrier CarrierTracker) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolGetOrderStatus,
+			Desc: "Get the status, items, totals and delivery promise of the customer's orders, flagging late ones. Without order_id returns all of the customer's orders, newest first. Use this tool when customer asks about an order (ออเดอร์, คำสั่งซื้อ, สถานะ).",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"order_id": {
+					Type: "string",
+					Desc: "Order ID (e.g., ORD-261018-3F9A1C); omit to list the customer's orders",
+				},
+			}),
+		},
+		func(ctx context.Context, in *GetOrderStatusInput) (*GetOrderStatusOutput, error) {
+			owner, _, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			list, err := orders.Orders(ctx, owner, in.OrderID)
+			if errors.Is(err, model.ErrOrderNotFound) {
+				return nil, fmt.Errorf("%w: %s", model.ErrOrderNotFound, in.OrderID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get orders: %w", err)
+			}
+
+			now := orders.now()
+			out := &GetOrderStatusOutput{Orders: make([]OrderStatusView, 0, len(list)), Total: len(list)}
+			for _, o := range list {
+				view := OrderStatusView{Order: o}
+				tracking := trackOrder(ctx, carrier, o)
+				if tracking != nil {
+					view.CarrierStatus = tracking.Status
+				}
+				if e := orderEscalation(o, tracking, now); e != nil {
+					view.Overdue = e.Reason != model.EscalationShipmentException
+					view.Escalation = e
+					raiseEscalation(ctx, *e)
+				}
+				out.Orders = append(out.Orders, view)
+			}
+			return out, nil
+		},
+	)
+}
+
+func createTrackShipmentTool(orders *OrderService, carrier CarrierTracker) tool.BaseTool {
+	return utils.NewTool(
+		&schema.ToolInfo{
+			Name: ToolTrackShipment,
+			Desc: "Track the delivery of one of the customer's orders with the carrier: current status, scan history and estimated delivery. Without order_id tracks the newest shipped order. Use this tool when customer asks where an order is (ของถึงไหน, พัสดุ, ยังไม่ได้ของ, เลขพัสดุ).",
+			ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"order_id": {
+					Type: "string",
+					Desc: "Order ID (e.g., ORD-261018-3F9A1C); omit to track the newest shipped order",
+				},
+			}),
+		},
+		func(ctx context.Context, in *TrackShipmentInput) (*TrackShipmentOutput, error) {
+			owner, _, ok := ownerFromContext(ctx)
+			if !ok {
+				return nil, errNoConversation
+			}
+			list, err := orders.Orders(ctx, owner, in.OrderID)
+			if errors.Is(err, model.ErrOrderNotFound) {
+				return nil, fmt.Errorf("%w: %s", model.ErrOrderNotFound, in.OrderID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get orders: %w", err)
+			}
+			o := newestShipped(list)
+			if o == nil {
+				return nil, fmt.Errorf("%w: no orders to track", model.ErrOrderNotFound)
+			}
+
+			out := &TrackShipmentOutput{OrderID: o.ID, OrderStatus: o.Status, Fulfillment: o.Fulfillment}
+			if !o.PromisedBy.IsZero() {
+				promised := o.PromisedBy
+				out.PromisedBy = &promised
+			}
+			switch {
+			case o.Fulfillment == model.FulfillmentPickup:
+				out.Message = fmt.Sprintf("pickup order at branch %s; nothing to track", o.PickupBranch)
+			case o.Shipment == nil:
+				out.Message = "not handed to a carrier yet"
+			default:
+				out.Tracking = trackOrder(ctx, carrier, o)
+				if out.Tracking == nil {
+					out.Message = fmt.Sprintf("%s has no record of tracking number %s yet", o.Shipment.Carrier, o.Shipment.TrackingNumber)
+				}
+			}
+			if e := orderEscalation(o, out.Tracking, orders.now()); e != nil {
+				out.Overdue = e.Reason != model.EscalationShipmentException
+				out.Escalation = e
+				raiseEscalation(ctx, *e)
+			}
+			return out, nil
+		},
+	)
+}
+
+// trackOrder asks the carrier about a shipped order. Lookup failures are
+// logged and reported as no tracking so the order status still answers.
+func trackOrder(ctx context.Context, carrier CarrierTracker, o *model.Order) *ShipmentTracking {
+	if carrier == nil || o.Shipment == nil {
+		return nil
+	}
+	t, err := carrier.Track(ctx, o.Shipment.Carrier, o.Shipment.TrackingNumber)
+	if err != nil {
+		if !errors.Is(err, ErrShipmentNotFound) {
+			logx.Warn().Err(err).Str("order_id", o.ID).Str("carrier", o.Shipment.Carrier).Msg("Shipment tracking failed")
+		}
+		return nil
+	}
+	return t
+}
+
+// newestShipped returns the newest order with a shipment, else the newest
+// order. list is newest first.
+func newestShipped(list []*model.Order) *model.Order {
+	for _, o := range list {
+		if o.Shipment != nil {
+			return o
+		}
+	}
+	if len(list) > 0 {
+		return list[0]
+	}
+	return nil
+}
+
+// orderEscalation returns the escalation an order needs at now, or nil.
+// Carrier exceptions always escalate; otherwise an order escalates once it
+// is past its promised date without being delivered (or, for pickup, made
+// ready). Unpaid and cancelled orders carry no promise.
+func orderEscalation(o *model.Order, tracking *ShipmentTracking, now time.Time) *model.Escalation {
+	switch o.Status {
+	case model.OrderPendingPayment, model.OrderCancelled, model.OrderDelivered, model.OrderReadyForPickup:
+		return nil
+	}
+	if tracking != nil {
+		switch tracking.Status {
+		case ShipmentDelivered:
+			return nil
+		case ShipmentException:
+			e := &model.Escalation{
+				Reason:   model.EscalationShipmentException,
+				Severity: model.SeverityHigh,
+				OrderID:  o.ID,
+				RaisedAt: now,
+			}
+			if len(tracking.Events) > 0 {
+				e.Detail = tracking.Events[0].Description
+			}
+			return e
+		}
+	}
+	if o.PromisedBy.IsZero() || !now.After(o.PromisedBy) {
+		return nil
+	}
+
+	late := now.Sub(o.PromisedBy)
+	e := &model.Escalation{
+		Reason:       model.EscalationShipmentOverdue,
+		Severity:     model.SeverityMedium,
+		OrderID:      o.ID,
+		OverdueHours: int(late.Hours()),
+		Detail:       "promised by " + o.PromisedBy.Format("2006-01-02"),
+		RaisedAt:     now,
+	}
+	if late >= overdueHighAfter {
+		e.Severity = model.SeverityHigh
+	}
+	if o.Fulfillment == model.FulfillmentShipping && o.Shipment == nil {
+		e.Reason = model.EscalationNotShipped
+	}
+	return e
+}
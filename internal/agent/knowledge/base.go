@@ -0,0 +1,183 @@
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"sort"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools/search"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// Hybrid ranking parameters.
+const (
+	rrfK          = 60   // reciprocal rank fusion damping
+	minSimilarity = 0.12 // cosine below this is not a vector match
+	candidatesPer = 4    // candidates taken from each ranker per requested result
+	minCandidates = 20
+)
+
+// Result is a ranked chunk.
+type Result struct {
+	Chunk
+	Score   float64  `json:"score"`             // fused relevance in (0, 1]
+	Matched []string `json:"matched,omitempty"` // query terms that matched literally, by synonym or fuzzily
+}
+
+// Base searches an index with BM25 and embeddings, fusing the two rankings.
+// It is immutable; open a new one to pick up document changes.
+type Base struct {
+	index    *Index
+	bm25     *search.Index
+	embedder Embedder
+	byID     map[string]int
+}
+
+// NewBase serves ix. A nil ix is an empty knowledge base.
+func NewBase(ix *Index, emb Embedder) *Base {
+	if ix == nil {
+		ix = &Index{Version: indexVersion}
+	}
+	docs := make([]search.Document, len(ix.Entries))
+	byID := make(map[string]int, len(ix.Entries))
+	for i, e := range ix.Entries {
+		docs[i] = search.Document{ID: e.ID, Fields: []search.Field{
+			{Text: e.Title, Weight: 2},
+			{Text: e.Section, Weight: 2},
+			{Text: e.Text, Weight: 1},
+		}}
+		byID[e.ID] = i
+	}
+	return &Base{index: ix, bm25: search.NewIndex(docs), embedder: emb, byID: byID}
+}
+
+// Config locates the knowledge base on disk.
+type Config struct {
+	Dir       string // documents (.md, .markdown, .txt)
+	IndexPath string // persisted index; rebuilt in memory when missing or stale
+	Chunks    ChunkOptions
+	Embedder  Embedder // nil uses the local HashEmbedder
+}
+
+func (c Config) embedder() Embedder {
+	if c.Embedder != nil {
+		return c.Embedder
+	}
+	return NewHashEmbedder(DefaultEmbeddingDim)
+}
+
+// Open loads the documents under cfg.Dir and serves the index at
+// cfg.IndexPath when it matches them; otherwise the index is rebuilt in
+// memory (persist it with Rebuild).
+func Open(ctx context.Context, cfg Config) (*Base, error) {
+	emb := cfg.embedder()
+	docs, err := LoadDir(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := Fingerprint(docs, cfg.Chunks, emb)
+	if cfg.IndexPath != "" {
+		ix, err := LoadIndex(cfg.IndexPath, fingerprint)
+		if err == nil {
+			logx.Info().Str("index", cfg.IndexPath).Int("chunks", len(ix.Entries)).Msg("Knowledge index loaded")
+			return NewBase(ix, emb), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, ErrStaleIndex) {
+			return nil, err
+		}
+		logx.Warn().Err(err).Str("index", cfg.IndexPath).Msg("Knowledge index missing or stale; building in memory")
+	}
+	ix, err := BuildIndex(ctx, docs, cfg.Chunks, emb)
+	if err != nil {
+		return nil, err
+	}
+	return NewBase(ix, emb), nil
+}
+
+// Rebuild indexes the documents under cfg.Dir and writes the index to
+// cfg.IndexPath.
+func Rebuild(ctx context.Context, cfg Config) (*Index, error) {
+	if cfg.IndexPath == "" {
+		return nil, fmt.Errorf("knowledge index path is empty")
+	}
+	docs, err := LoadDir(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	ix, err := BuildIndex(ctx, docs, cfg.Chunks, cfg.embedder())
+	if err != nil {
+		return nil, err
+	}
+	if err := ix.Save(cfg.IndexPath); err != nil {
+		return nil, err
+	}
+	return ix, nil
+}
+
+// Len returns the number of indexed chunks.
+func (b *Base) Len() int {
+	return len(b.index.Entries)
+}
+
+// Search returns up to limit chunks for query. BM25 and embedding rankings
+// are fused with reciprocal rank fusion, so a chunk found by both ranks above
+// one found by either alone.
+func (b *Base) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	if limit <= 0 || b.Len() == 0 {
+		return nil, nil
+	}
+	candidates := max(limit*candidatesPer, minCandidates)
+
+	fused := map[int]float64{}
+	matched := map[int][]string{}
+	for rank, hit := range b.bm25.Search(query, search.Options{Limit: candidates}) {
+		i := b.byID[hit.ID]
+		fused[i] += 1 / float64(rrfK+rank+1)
+		matched[i] = hit.Matched
+	}
+
+	vecs, err := b.embedder.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query: %w", err)
+	}
+	if len(vecs) == 1 {
+		type sim struct {
+			i int
+			s float64
+		}
+		var sims []sim
+		for i, e := range b.index.Entries {
+			if s := cosine(e.Vector, vecs[0]); s >= minSimilarity {
+				sims = append(sims, sim{i, s})
+			}
+		}
+		sort.SliceStable(sims, func(x, y int) bool { return sims[x].s > sims[y].s })
+		for rank, s := range sims[:min(len(sims), candidates)] {
+			fused[s.i] += 1 / float64(rrfK+rank+1)
+		}
+	}
+
+	// scale so a chunk ranked first by both rankers scores 1
+	best := 2.0 / float64(rrfK+1)
+	results := make([]Result, 0, len(fused))
+	for i, score := range fused {
+		results = append(results, Result{
+			Chunk:   b.index.Entries[i].Chunk,
+			Score:   math.Round(score/best*1000) / 1000,
+			Matched: matched[i],
+		})
+	}
+	sort.SliceStable(results, func(x, y int) bool {
+		if results[x].Score != results[y].Score {
+			return results[x].Score > results[y].Score
+		}
+		return results[x].ID < results[y].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
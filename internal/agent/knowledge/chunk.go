@@ -0,0 +1,231 @@
+package knowledge
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Chunking defaults, in runes.
+const (
+	DefaultChunkSize    = 800
+	DefaultChunkOverlap = 120
+)
+
+// Chunk is a retrievable passage of a document.
+type Chunk struct {
+	ID      string `json:"id"` // source#n
+	Source  string `json:"source"`
+	Title   string `json:"title"`
+	Section string `json:"section,omitempty"` // Markdown heading path, e.g. "Returns > Conditions"
+	Page    int    `json:"page,omitempty"`    // 1-based page of PDF-extracted text
+	Text    string `json:"text"`
+}
+
+// ChunkOptions sizes chunks. Zero values use the defaults.
+type ChunkOptions struct {
+	Size    int // maximum runes per chunk
+	Overlap int // runes repeated between the pieces of a paragraph longer than Size
+}
+
+func (o ChunkOptions) withDefaults() ChunkOptions {
+	if o.Size <= 0 {
+		o.Size = DefaultChunkSize
+	}
+	if o.Overlap <= 0 || o.Overlap >= o.Size/2 {
+		o.Overlap = min(DefaultChunkOverlap, o.Size/4)
+	}
+	return o
+}
+
+// block is a run of paragraphs sharing a section and page.
+type block struct {
+	section string
+	page    int
+	paras   []string
+}
+
+// ChunkDocument splits doc into chunks. Paragraphs are packed into chunks up
+// to opts.Size without crossing Markdown sections or pages; a paragraph
+// longer than that is cut at word or sentence boundaries with opts.Overlap
+// runes of overlap.
+func ChunkDocument(doc Document, opts ChunkOptions) []Chunk {
+	opts = opts.withDefaults()
+	var blocks []block
+	if doc.Format == FormatMarkdown {
+		blocks = markdownBlocks(doc.Text)
+	} else {
+		blocks = textBlocks(doc.Text)
+	}
+
+	var chunks []Chunk
+	emit := func(b block, text string) {
+		chunks = append(chunks, Chunk{
+			ID:      fmt.Sprintf("%s#%d", doc.Source, len(chunks)+1),
+			Source:  doc.Source,
+			Title:   doc.Title,
+			Section: b.section,
+			Page:    b.page,
+			Text:    text,
+		})
+	}
+	for _, b := range blocks {
+		var cur []string
+		curLen := 0
+		flush := func() {
+			if len(cur) > 0 {
+				emit(b, strings.Join(cur, "\n\n"))
+				cur, curLen = nil, 0
+			}
+		}
+		for _, p := range b.paras {
+			n := utf8.RuneCountInString(p)
+			if n > opts.Size {
+				flush()
+				for _, piece := range splitLong(p, opts.Size, opts.Overlap) {
+					emit(b, piece)
+				}
+				continue
+			}
+			if curLen > 0 && curLen+2+n > opts.Size {
+				flush()
+			}
+			cur = append(cur, p)
+			curLen += n + 2
+		}
+		flush()
+	}
+	return chunks
+}
+
+// markdownBlocks groups paragraphs by heading path. Headings themselves are
+// not chunk text; they are carried in the section.
+func markdownBlocks(text string) []block {
+	var (
+		blocks   []block
+		headings [6]string
+		section  string
+		para     []string
+		inFence  bool
+	)
+	endPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		p := strings.TrimSpace(strings.Join(para, "\n"))
+		para = nil
+		if p == "" {
+			return
+		}
+		if len(blocks) == 0 || blocks[len(blocks)-1].section != section {
+			blocks = append(blocks, block{section: section})
+		}
+		blocks[len(blocks)-1].paras = append(blocks[len(blocks)-1].paras, p)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			para = append(para, line)
+			continue
+		}
+		if !inFence {
+			if level, heading := parseHeading(line); level > 0 {
+				endPara()
+				headings[level-1] = heading
+				for i := level; i < len(headings); i++ {
+					headings[i] = ""
+				}
+				section = sectionPath(headings[:])
+				continue
+			}
+			if strings.TrimSpace(line) == "" {
+				endPara()
+				continue
+			}
+		}
+		para = append(para, line)
+	}
+	endPara()
+	return blocks
+}
+
+// sectionPath joins the headings below the document title (H1).
+func sectionPath(headings []string) string {
+	var parts []string
+	for _, h := range headings[1:] {
+		if h != "" {
+			parts = append(parts, h)
+		}
+	}
+	return strings.Join(parts, " > ")
+}
+
+// textBlocks splits PDF-extracted text into pages (form feeds) of paragraphs
+// (blank lines). Pages are numbered only when the text has more than one.
+func textBlocks(text string) []block {
+	pages := strings.Split(text, "\f")
+	var blocks []block
+	for i, page := range pages {
+		b := block{}
+		if len(pages) > 1 {
+			b.page = i + 1
+		}
+		for _, p := range strings.Split(page, "\n\n") {
+			// extracted text wraps lines inside paragraphs
+			if p = strings.Join(strings.Fields(p), " "); p != "" {
+				b.paras = append(b.paras, p)
+			}
+		}
+		if len(b.paras) > 0 {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// splitLong cuts text into pieces of at most size runes that overlap by
+// about overlap runes, preferring to cut after a sentence end or a space.
+func splitLong(text string, size, overlap int) []string {
+	runes := []rune(text)
+	var pieces []string
+	for start := 0; start < len(runes); {
+		end := min(start+size, len(runes))
+		if end < len(runes) {
+			end = cutPoint(runes, start+size/2, end)
+		}
+		pieces = append(pieces, strings.TrimSpace(string(runes[start:end])))
+		if end == len(runes) {
+			break
+		}
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		// start the overlap on a word
+		for next < end && runes[next] != ' ' && runes[next-1] != ' ' {
+			next++
+		}
+		start = next
+	}
+	return pieces
+}
+
+// cutPoint returns the best cut in runes[lo:hi]: after the last sentence
+// end, else after the last space, else hi.
+func cutPoint(runes []rune, lo, hi int) int {
+	space := -1
+	for i := hi - 1; i >= lo; i-- {
+		switch runes[i] {
+		case '.', '!', '?', '\n':
+			return i + 1
+		case ' ':
+			if space < 0 {
+				space = i + 1
+			}
+		}
+	}
+	if space > 0 {
+		return space
+	}
+	return hi
+}
@@ -0,0 +1,103 @@
+// Package knowledge implements the store policy knowledge base: loading
+// Markdown and PDF-extracted text documents, chunking them, and a hybrid
+// BM25 + embedding index over the chunks with cited search results.
+package knowledge
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Document is one source file of the knowledge base.
+type Document struct {
+	Source string // path relative to the knowledge directory, used in citations
+	Title  string // first Markdown H1, else derived from the file name
+	Format string // markdown, text
+	Text   string
+}
+
+// Document formats, by file extension.
+const (
+	FormatMarkdown = "markdown" // .md, .markdown
+	FormatText     = "text"     // .txt; PDF-extracted text with form feeds between pages
+)
+
+// LoadDir reads all documents under dir, sorted by source. Files with other
+// extensions (including the index itself) are skipped.
+func LoadDir(dir string) ([]Document, error) {
+	var docs []Document
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		format := formatOf(path)
+		if format == "" {
+			return nil
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = filepath.Base(path)
+		}
+		text := strings.ReplaceAll(string(raw), "\r\n", "\n")
+		docs = append(docs, Document{
+			Source: filepath.ToSlash(rel),
+			Title:  titleOf(text, format, path),
+			Format: format,
+			Text:   text,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load knowledge documents: %w", err)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Source < docs[j].Source })
+	return docs, nil
+}
+
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return FormatMarkdown
+	case ".txt":
+		return FormatText
+	}
+	return ""
+}
+
+// titleOf returns the first H1 of a Markdown document, else the file name
+// without extension and with dashes and underscores as spaces.
+func titleOf(text, format, path string) string {
+	if format == FormatMarkdown {
+		for _, line := range strings.Split(text, "\n") {
+			if level, heading := parseHeading(line); level == 1 {
+				return heading
+			}
+		}
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.NewReplacer("-", " ", "_", " ").Replace(name)
+}
+
+// parseHeading returns the level and text of a Markdown ATX heading line, or 0.
+func parseHeading(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(trimmed) || trimmed[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.TrimRight(trimmed[level:], "# "))
+}
@@ -0,0 +1,113 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools/search"
+)
+
+// DefaultEmbeddingDim is the vector size of the local embedder.
+const DefaultEmbeddingDim = 512
+
+// Feature weights of the local embedder relative to a search term.
+const (
+	synonymFeatureWeight = 0.6
+	trigramFeatureWeight = 0.3
+)
+
+// Embedder is an Eino embedding model with a stable name; the index records
+// the name so vectors from different models are never compared.
+type Embedder interface {
+	embedding.Embedder
+	Name() string
+}
+
+// HashEmbedder is a local embedding model that needs no network: it hashes
+// search terms (Thai segmented), their synonym expansions and character
+// trigrams into a fixed-size, L2-normalized vector. It captures lexical and
+// cross-language (synonym) similarity, not meaning; swap in a hosted
+// embedder for that.
+type HashEmbedder struct {
+	dim int
+}
+
+func NewHashEmbedder(dim int) *HashEmbedder {
+	if dim <= 0 {
+		dim = DefaultEmbeddingDim
+	}
+	return &HashEmbedder{dim: dim}
+}
+
+func (e *HashEmbedder) Name() string {
+	return fmt.Sprintf("hash-%d", e.dim)
+}
+
+func (e *HashEmbedder) EmbedStrings(ctx context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		out[i] = e.embed(text)
+	}
+	return out, nil
+}
+
+func (e *HashEmbedder) embed(text string) []float64 {
+	counts := map[int]float64{}
+	add := func(feature string, w float64) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		sign := 1.0
+		if sum&(1<<63) != 0 {
+			sign = -1
+		}
+		counts[int(sum%uint64(e.dim))] += sign * w
+	}
+	for _, term := range search.Tokenize(text) {
+		add("t:"+term, 1)
+		for _, syn := range search.Expand(term) {
+			add("t:"+syn, synonymFeatureWeight)
+		}
+		runes := []rune("^" + term + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			add("g:"+string(runes[i:i+3]), trigramFeatureWeight)
+		}
+	}
+
+	vec := make([]float64, e.dim)
+	norm := 0.0
+	for i, c := range counts {
+		// sublinear term frequency, keeping the hash sign
+		v := math.Copysign(1+math.Log(1+math.Abs(c)), c)
+		if c == 0 {
+			v = 0
+		}
+		vec[i] = v
+		norm += v * v
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vec {
+			vec[i] /= norm
+		}
+	}
+	return vec
+}
+
+// cosine is the dot product of two L2-normalized vectors.
+func cosine(a []float32, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	s := 0.0
+	for i := range a {
+		s += float64(a[i]) * b[i]
+	}
+	return s
+}
+
+var _ Embedder = (*HashEmbedder)(nil)
@@ -0,0 +1,144 @@
+package knowledge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// indexVersion changes whenever the file layout or chunking changes.
+const indexVersion = 1
+
+// embedBatchSize is how many chunks are embedded per call.
+const embedBatchSize = 64
+
+// ErrStaleIndex is returned by LoadIndex when the index was built from other
+// documents, options or embedder than the ones given.
+var ErrStaleIndex = errors.New("knowledge index is stale")
+
+// Index is the persisted form of the knowledge base: chunks and their
+// embeddings. The BM25 side is rebuilt from the chunk text when loaded.
+type Index struct {
+	Version     int       `json:"version"`
+	Embedder    string    `json:"embedder"`
+	Fingerprint string    `json:"fingerprint"` // of the documents, chunk options and embedder
+	BuiltAt     time.Time `json:"built_at"`
+	Documents   int       `json:"documents"`
+	Entries     []Entry   `json:"entries"`
+}
+
+// Entry is an indexed chunk.
+type Entry struct {
+	Chunk
+	Vector []float32 `json:"vector"`
+}
+
+// BuildIndex chunks and embeds docs.
+func BuildIndex(ctx context.Context, docs []Document, opts ChunkOptions, emb Embedder) (*Index, error) {
+	var chunks []Chunk
+	for _, d := range docs {
+		chunks = append(chunks, ChunkDocument(d, opts)...)
+	}
+	ix := &Index{
+		Version:     indexVersion,
+		Embedder:    emb.Name(),
+		Fingerprint: Fingerprint(docs, opts, emb),
+		BuiltAt:     time.Now().UTC(),
+		Documents:   len(docs),
+		Entries:     make([]Entry, 0, len(chunks)),
+	}
+	for start := 0; start < len(chunks); start += embedBatchSize {
+		batch := chunks[start:min(start+embedBatchSize, len(chunks))]
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = embeddingText(c)
+		}
+		vecs, err := emb.EmbedStrings(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("embed chunks: %w", err)
+		}
+		if len(vecs) != len(batch) {
+			return nil, fmt.Errorf("embed chunks: got %d vectors for %d chunks", len(vecs), len(batch))
+		}
+		for i, c := range batch {
+			ix.Entries = append(ix.Entries, Entry{Chunk: c, Vector: toFloat32(vecs[i])})
+		}
+	}
+	return ix, nil
+}
+
+// embeddingText is what gets embedded for a chunk: its headings give short
+// passages their topic.
+func embeddingText(c Chunk) string {
+	return c.Title + "\n" + c.Section + "\n" + c.Text
+}
+
+// Fingerprint identifies the inputs of an index build.
+func Fingerprint(docs []Document, opts ChunkOptions, emb Embedder) string {
+	opts = opts.withDefaults()
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(strconv.Itoa(len(s))))
+		h.Write([]byte{':'})
+		h.Write([]byte(s))
+	}
+	write(strconv.Itoa(indexVersion))
+	write(emb.Name())
+	write(strconv.Itoa(opts.Size) + "/" + strconv.Itoa(opts.Overlap))
+	for _, d := range docs {
+		write(d.Source)
+		write(d.Text)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Save writes the index to path atomically.
+func (ix *Index) Save(path string) error {
+	b, err := json.Marshal(ix)
+	if err != nil {
+		return fmt.Errorf("marshal knowledge index: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create index dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("write knowledge index: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write knowledge index: %w", err)
+	}
+	return nil
+}
+
+// LoadIndex reads the index at path. With a non-empty fingerprint it returns
+// ErrStaleIndex when the index was built from different inputs.
+func LoadIndex(path, fingerprint string) (*Index, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read knowledge index: %w", err)
+	}
+	var ix Index
+	if err := json.Unmarshal(b, &ix); err != nil {
+		return nil, fmt.Errorf("decode knowledge index %s: %w", path, err)
+	}
+	if ix.Version != indexVersion || (fingerprint != "" && ix.Fingerprint != fingerprint) {
+		return nil, ErrStaleIndex
+	}
+	return &ix, nil
+}
+
+func toFloat32(v []float64) []float32 {
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(x)
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// transport carries JSON-RPC messages to one server.
+type transport interface {
+	// call sends a request and waits for its response.
+	call(ctx context.Context, req *message) (*message, error)
+	// notify sends a notification.
+	notify(ctx context.Context, msg *message) error
+	close() error
+}
+
+// Client is an initialized session with one MCP server. It is safe for
+// concurrent use.
+type Client struct {
+	name   string
+	t      transport
+	nextID atomic.Int64
+	server initializeResult
+}
+
+// clientInfo identifies this client to servers.
+var clientInfo = Implementation{Name: "chative-agent", Version: "1.0.0"}
+
+// newClient runs the initialize handshake over t.
+func newClient(ctx context.Context, name string, t transport) (*Client, error) {
+	c := &Client{name: name, t: t}
+	var res initializeResult
+	err := c.request(ctx, "initialize", initializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      clientInfo,
+	}, &res)
+	if err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("mcp %s initialize: %w", name, err)
+	}
+	c.server = res
+	if ht, ok := t.(*httpTransport); ok {
+		ht.setProtocolVersion(res.ProtocolVersion)
+	}
+	if err := t.notify(ctx, &message{JSONRPC: jsonRPCVersion, Method: "notifications/initialized"}); err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("mcp %s initialized: %w", name, err)
+	}
+	logx.Info().
+		Str("mcp_server", name).
+		Str("server_name", res.ServerInfo.Name).
+		Str("protocol_version", res.ProtocolVersion).
+		Msg("MCP session initialized")
+	return c, nil
+}
+
+// Name is the configured server name.
+func (c *Client) Name() string { return c.name }
+
+// ListTools returns every tool of the server, following pagination.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var (
+		all    []Tool
+		cursor string
+	)
+	for {
+		var res listToolsResult
+		if err := c.request(ctx, "tools/list", listToolsParams{Cursor: cursor}, &res); err != nil {
+			return nil, fmt.Errorf("mcp %s tools/list: %w", c.name, err)
+		}
+		all = append(all, res.Tools...)
+		if res.NextCursor == "" || res.NextCursor == cursor {
+			return all, nil
+		}
+		cursor = res.NextCursor
+	}
+}
+
+// CallTool invokes a tool with JSON object arguments.
+func (c *Client) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*CallToolResult, error) {
+	var res CallToolResult
+	if err := c.request(ctx, "tools/call", callToolParams{Name: name, Arguments: arguments}, &res); err != nil {
+		return nil, fmt.Errorf("mcp %s tools/call %s: %w", c.name, name, err)
+	}
+	return &res, nil
+}
+
+// Close ends the session and releases the transport.
+func (c *Client) Close() error {
+	return c.t.close()
+}
+
+func (c *Client) request(ctx context.Context, method string, params, result any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	id := c.nextID.Add(1)
+	resp, err := c.t.call(ctx, &message{JSONRPC: jsonRPCVersion, ID: &id, Method: method, Params: raw})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+)
+
+// Transports (ServerConfig.Transport).
+const (
+	TransportStdio = "stdio" // child process speaking JSON-RPC over stdin/stdout
+	TransportHTTP  = "http"  // streamable HTTP endpoint
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ServerConfig is one MCP server whose tools are imported. String values may
+// reference environment variables as ${VAR}.
+//
+//	servers:
+//	  - name: stub
+//	    transport: stdio
+//	    command: go
+//	    args: [run, ./cmd/mcpstub]
+//	    allow: [get_store_hours]
+//	  - name: crm
+//	    transport: http
+//	    url: https://crm.internal/mcp
+//	    headers: {Authorization: Bearer ${CRM_TOKEN}}
+//	    allow: ["*"]
+//	    prefix: crm_
+type ServerConfig struct {
+	Name      string `yaml:"name"`
+	Transport string `yaml:"transport"`
+
+	// stdio
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"`
+	Dir     string            `yaml:"dir"`
+
+	// http
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+
+	Allow    []string           `yaml:"allow"`     // server tool names to import; "*" imports all
+	Prefix   string             `yaml:"prefix"`    // prepended to the imported tool names
+	Timeout  time.Duration      `yaml:"timeout"`   // per request, including the handshake; default 10s
+	CacheTTL time.Duration      `yaml:"cache_ttl"` // reuse results of read-only tools this long; default off
+	MaxItems int                `yaml:"max_items"` // longest array passed to the model; default all
+	Category tools.ToolCategory `yaml:"category"`  // default utility
+	Risk     tools.ToolRisk     `yaml:"risk"`      // for tools not annotated read-only; default side_effect
+	Owner    string             `yaml:"owner"`     // default the server name
+}
+
+// LoadServers reads the server list of a YAML file.
+func LoadServers(path string) ([]ServerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mcp servers %s: %w", path, err)
+	}
+	var file struct {
+		Servers []ServerConfig `yaml:"servers"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse mcp servers %s: %w", path, err)
+	}
+	for i := range file.Servers {
+		if err := file.Servers[i].normalize(); err != nil {
+			return nil, fmt.Errorf("mcp server %q: %w", file.Servers[i].Name, err)
+		}
+	}
+	return file.Servers, nil
+}
+
+// normalize expands environment variables, fills defaults and checks the config.
+func (c *ServerConfig) normalize() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(c.Allow) == 0 {
+		return errors.New(`allow is required (list tool names, or "*" for all)`)
+	}
+	c.Command, c.URL, c.Dir = os.ExpandEnv(c.Command), os.ExpandEnv(c.URL), os.ExpandEnv(c.Dir)
+	for i, a := range c.Args {
+		c.Args[i] = os.ExpandEnv(a)
+	}
+	for k, v := range c.Env {
+		c.Env[k] = os.ExpandEnv(v)
+	}
+	for k, v := range c.Headers {
+		c.Headers[k] = os.ExpandEnv(v)
+	}
+	switch c.Transport {
+	case TransportStdio:
+		if c.Command == "" {
+			return errors.New("command is required for stdio")
+		}
+	case TransportHTTP:
+		if c.URL == "" {
+			return errors.New("url is required for http")
+		}
+	default:
+		return fmt.Errorf("unknown transport %q (expected stdio|http)", c.Transport)
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Category == "" {
+		c.Category = tools.CategoryUtility
+	}
+	if !c.Category.Valid() {
+		return fmt.Errorf("unknown category %q (expected query|action|utility)", c.Category)
+	}
+	if c.Risk == "" {
+		c.Risk = tools.RiskSideEffect
+	}
+	if !c.Risk.Valid() {
+		return fmt.Errorf("unknown risk %q (expected read_only|side_effect)", c.Risk)
+	}
+	if c.Owner == "" {
+		c.Owner = c.Name
+	}
+	return nil
+}
+
+// Connect starts or reaches the server and initializes a session.
+func Connect(ctx context.Context, cfg ServerConfig) (*Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var t transport
+	switch cfg.Transport {
+	case TransportStdio:
+		env := make([]string, 0, len(cfg.Env))
+		for k, v := range cfg.Env {
+			env = append(env, k+"="+v)
+		}
+		st, err := startStdio(cfg.Name, cfg.Command, cfg.Args, env, cfg.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("mcp %s: %w", cfg.Name, err)
+		}
+		t = st
+	case TransportHTTP:
+		t = newHTTPTransport(cfg.Name, cfg.URL, cfg.Headers, &http.Client{Timeout: cfg.Timeout})
+	default:
+		return nil, fmt.Errorf("mcp %s: unknown transport %q", cfg.Name, cfg.Transport)
+	}
+	return newClient(ctx, cfg.Name, t)
+}
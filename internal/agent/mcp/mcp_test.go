@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+)
+
+// buildStub compiles cmd/mcpstub and returns the path of the binary.
+func buildStub(t *testing.T) string {
+	t.Helper()
+	goBin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if _, err := os.Stat(goBin); err != nil {
+		t.Skipf("go command not found: %v", err)
+	}
+	bin := filepath.Join(t.TempDir(), "mcpstub")
+	cmd := exec.Command(goBin, "build", "-o", bin, "../../../cmd/mcpstub")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build mcpstub: %v\n%s", err, out)
+	}
+	return bin
+}
+
+func TestImportFromStub(t *testing.T) {
+	ctx := context.Background()
+	cfg := ServerConfig{
+		Name:      "stub",
+		Transport: TransportStdio,
+		Command:   buildStub(t),
+		Allow:     []string{"get_store_hours", "echo", "missing"},
+		Prefix:    "Stub_",
+	}
+	if err := cfg.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	clients, err := Import(ctx, []ServerConfig{cfg})
+	for _, c := range clients {
+		defer c.Close()
+	}
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	hours, ok := tools.Lookup("Stub_get_store_hours")
+	if !ok {
+		t.Fatal("Stub_get_store_hours not registered")
+	}
+	if hours.Risk != tools.RiskReadOnly || hours.Category != tools.CategoryUtility || hours.Owner != "stub" {
+		t.Errorf("Stub_get_store_hours = %s/%s owned by %s, want read-only utility owned by stub", hours.Risk, hours.Category, hours.Owner)
+	}
+	if echo, ok := tools.Lookup("Stub_echo"); !ok || echo.Risk != tools.RiskSideEffect {
+		t.Errorf("Stub_echo registered %v with risk %s, want side_effect", ok, echo.Risk)
+	}
+	if _, ok := tools.Lookup("Stub_fail"); ok {
+		t.Error("Stub_fail was imported but is not allowed")
+	}
+
+	// Mixed-case names are enabled as configured, whatever the case.
+	for _, entry := range []string{"Stub_get_store_hours", "stub_get_store_hours"} {
+		regs, err := tools.Select([]string{entry}, nil)
+		if err != nil {
+			t.Fatalf("Select(%s): %v", entry, err)
+		}
+		if len(regs) != 1 || regs[0].Name != "Stub_get_store_hours" {
+			t.Errorf("Select(%s) = %v", entry, regs)
+		}
+	}
+
+	built, err := tools.BuildTools(ctx, []tools.Registration{hours}, tools.Dependencies{})
+	if err != nil {
+		t.Fatalf("BuildTools: %v", err)
+	}
+	it := built[0].(tool.InvokableTool)
+	out, err := it.InvokableRun(ctx, `{"branch":"bkk-siam"}`)
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if out != `{"branch":"bkk-siam","hours":"10:00-22:00"}` {
+		t.Errorf("result = %s, want the structured content", out)
+	}
+	out, err = it.InvokableRun(ctx, `{"branch":"nowhere"}`)
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	var res map[string]string
+	if err := json.Unmarshal([]byte(out), &res); err != nil || res["error"] != "tool_error" || !strings.Contains(res["detail"], "nowhere") {
+		t.Errorf("isError result = %s, want a tool_error naming the branch", out)
+	}
+
+	// An error response shows the server is up; a dead transport does not.
+	client := clients[0]
+	if err := client.request(ctx, "resources/list", struct{}{}, nil); !errors.Is(err, tools.ErrAnswered) || errors.Is(err, tools.ErrTransient) {
+		t.Errorf("error response = %v, want tools.ErrAnswered", err)
+	}
+	_ = client.Close()
+	if _, err := it.InvokableRun(ctx, `{"branch":"bkk-siam"}`); !errors.Is(err, tools.ErrTransient) {
+		t.Errorf("call after close = %v, want tools.ErrTransient", err)
+	}
+}
+
+func TestHTTPTransportClassifiesFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		transient bool
+	}{
+		{"unavailable", http.StatusServiceUnavailable, true},
+		{"bad gateway", http.StatusBadGateway, true},
+		{"unknown session", http.StatusNotFound, false},
+		{"unauthorized", http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, http.StatusText(tt.status), tt.status)
+			}))
+			defer srv.Close()
+			tr := newHTTPTransport("crm", srv.URL, nil, srv.Client())
+			id := int64(1)
+			_, err := tr.call(context.Background(), &message{JSONRPC: jsonRPCVersion, ID: &id, Method: "tools/list"})
+			if err == nil {
+				t.Fatal("call succeeded")
+			}
+			if errors.Is(err, tools.ErrTransient) != tt.transient {
+				t.Errorf("errors.Is(%v, ErrTransient) = %v, want %v", err, !tt.transient, tt.transient)
+			}
+		})
+	}
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	tr := newHTTPTransport("crm", srv.URL, nil, &http.Client{})
+	id := int64(1)
+	if _, err := tr.call(context.Background(), &message{JSONRPC: jsonRPCVersion, ID: &id, Method: "tools/list"}); !errors.Is(err, tools.ErrTransient) {
+		t.Errorf("refused connection = %v, want tools.ErrTransient", err)
+	}
+}
+
+func TestServerConfigRejectsInvalid(t *testing.T) {
+	valid := func() ServerConfig {
+		return ServerConfig{Name: "stub", Transport: TransportStdio, Command: "mcpstub", Allow: []string{"echo"}}
+	}
+	tests := []struct {
+		name   string
+		mutate func(*ServerConfig)
+	}{
+		{"unknown category", func(c *ServerConfig) { c.Category = "querry" }},
+		{"unknown risk", func(c *ServerConfig) { c.Risk = "readonly" }},
+		{"unknown transport", func(c *ServerConfig) { c.Transport = "grpc" }},
+		{"no allow list", func(c *ServerConfig) { c.Allow = nil }},
+		{"stdio without command", func(c *ServerConfig) { c.Command = "" }},
+		{"http without url", func(c *ServerConfig) { c.Transport = TransportHTTP }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+			if err := cfg.normalize(); err == nil {
+				t.Error("normalize accepted the config")
+			}
+		})
+	}
+
+	cfg := valid()
+	if err := cfg.normalize(); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if cfg.Category != tools.CategoryUtility || cfg.Risk != tools.RiskSideEffect || cfg.Owner != "stub" || cfg.Timeout != defaultTimeout {
+		t.Errorf("defaults = %+v", cfg)
+	}
+}
+
+func TestLoadServersRejectsUnknownCategory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.yaml")
+	content := "servers:\n  - name: stub\n    transport: stdio\n    command: mcpstub\n    allow: [echo]\n    category: querry\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadServers(path); err == nil || !strings.Contains(err.Error(), "querry") {
+		t.Errorf("LoadServers = %v, want an unknown category error", err)
+	}
+}
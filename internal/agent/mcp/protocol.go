@@ -0,0 +1,114 @@
+// Package mcp is a Model Context Protocol client. It lists the tools of MCP
+// servers reached over stdio or streamable HTTP and adapts them to Eino
+// tools, so they can be bound to the response model with the built-in ones.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+)
+
+// ProtocolVersion is the MCP revision the client speaks.
+const ProtocolVersion = "2025-06-18"
+
+const jsonRPCVersion = "2.0"
+
+// message is a JSON-RPC 2.0 request, notification or response.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"` // nil for notifications
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+func (m *message) isResponse() bool { return m.Method == "" && m.ID != nil }
+
+// RPCError is an error response from a server.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+// Is makes error responses answers of a running server (tools.ErrAnswered),
+// so they do not open the tool's circuit breaker.
+func (e *RPCError) Is(target error) bool {
+	return target == tools.ErrAnswered
+}
+
+// JSON-RPC error codes used by the client.
+const (
+	codeMethodNotFound = -32601
+)
+
+// Implementation names a client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type initializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      Implementation `json:"clientInfo"`
+}
+
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      Implementation `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+// Tool is a tool advertised by a server.
+type Tool struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	InputSchema json.RawMessage  `json:"inputSchema"`
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are the server's hints about a tool's behaviour.
+type ToolAnnotations struct {
+	ReadOnlyHint    *bool `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+}
+
+type listToolsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type listToolsResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the outcome of a tools/call.
+type CallToolResult struct {
+	Content           []Content       `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// Content is one block of a tool result. Only text is passed to the model;
+// other kinds are summarized by type.
+type Content struct {
+	Type     string `json:"type"` // text, image, audio, resource_link, resource
+	Text     string `json:"text,omitempty"`
+	MIMEType string `json:"mimeType,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
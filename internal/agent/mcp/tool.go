@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+// remoteTool adapts a server tool to an Eino tool. Calls that the server reports
+// as failed (isError) are returned to the model as a tool_error result;
+// transport and protocol failures are errors.
+type remoteTool struct {
+	client  *Client
+	remote  string // name on the server
+	info    *schema.ToolInfo
+	timeout time.Duration
+}
+
+// NewTool adapts server tool t of client, exposed to the model as name.
+func NewTool(client *Client, t Tool, name string, timeout time.Duration) (tool.InvokableTool, error) {
+	params, err := paramsOf(t.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("mcp %s tool %s input schema: %w", client.Name(), t.Name, err)
+	}
+	desc := t.Description
+	if desc == "" {
+		desc = t.Title
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &remoteTool{
+		client:  client,
+		remote:  t.Name,
+		info:    &schema.ToolInfo{Name: name, Desc: desc, ParamsOneOf: params},
+		timeout: timeout,
+	}, nil
+}
+
+// paramsOf converts an MCP input schema (a JSON Schema object).
+func paramsOf(raw json.RawMessage) (*schema.ParamsOneOf, error) {
+	js := &jsonschema.Schema{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, js); err != nil {
+			return nil, err
+		}
+	}
+	if js.Type == "" {
+		js.Type = string(schema.Object)
+	}
+	return schema.NewParamsOneOfByJSONSchema(js), nil
+}
+
+func (t *remoteTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+func (t *remoteTool) InvokableRun(ctx context.Context, arguments string, _ ...tool.Option) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	args := json.RawMessage(strings.TrimSpace(arguments))
+	if len(args) == 0 {
+		args = json.RawMessage(`{}`)
+	}
+	res, err := t.client.CallTool(ctx, t.remote, args)
+	if err != nil {
+		return "", err
+	}
+	text := resultText(res)
+	if res.IsError {
+		b, err := json.Marshal(map[string]any{"error": "tool_error", "detail": text})
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	if len(res.StructuredContent) > 0 && string(res.StructuredContent) != "null" {
+		return string(res.StructuredContent), nil
+	}
+	return text, nil
+}
+
+// resultText joins the text blocks of a result; other blocks are named by type.
+func resultText(res *CallToolResult) string {
+	parts := make([]string, 0, len(res.Content))
+	for _, c := range res.Content {
+		switch {
+		case c.Type == "text":
+			parts = append(parts, c.Text)
+		case c.URI != "":
+			parts = append(parts, fmt.Sprintf("[%s %s]", c.Type, c.URI))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s %s]", c.Type, c.MIMEType))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Import connects to each server and registers its allowed tools with the
+// tool registry, so the graph binds them like built-in tools. The returned
+// clients must be closed on shutdown, also when an error is returned.
+func Import(ctx context.Context, servers []ServerConfig) ([]*Client, error) {
+	var clients []*Client
+	for _, cfg := range servers {
+		client, err := Connect(ctx, cfg)
+		if err != nil {
+			return clients, err
+		}
+		clients = append(clients, client)
+		if err := register(ctx, client, cfg); err != nil {
+			return clients, err
+		}
+	}
+	return clients, nil
+}
+
+func register(ctx context.Context, client *Client, cfg ServerConfig) error {
+	listCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	remote, err := client.ListTools(listCtx)
+	if err != nil {
+		return err
+	}
+
+	allowAll := false
+	allowed := map[string]bool{}
+	for _, name := range cfg.Allow {
+		if name == "*" {
+			allowAll = true
+		}
+		allowed[name] = true
+	}
+	var imported []string
+	for _, rt := range remote {
+		if !allowAll && !allowed[rt.Name] {
+			continue
+		}
+		delete(allowed, rt.Name)
+		name := cfg.Prefix + rt.Name
+		if _, dup := tools.Lookup(name); dup {
+			return fmt.Errorf("mcp %s tool %s: a tool named %s is already registered; set a prefix", cfg.Name, rt.Name, name)
+		}
+		t, err := NewTool(client, rt, name, cfg.Timeout)
+		if err != nil {
+			return err
+		}
+		risk := cfg.Risk
+		if rt.Annotations != nil && rt.Annotations.ReadOnlyHint != nil && *rt.Annotations.ReadOnlyHint {
+			risk = tools.RiskReadOnly
+		}
+		tools.Register(tools.Registration{
+			Name:     name,
+			Category: cfg.Category,
+			Order:    200, // after built-in and HTTP tools
+			Risk:     risk,
+			Timeout:  cfg.Timeout,
+			Owner:    cfg.Owner,
+			CacheTTL: cfg.CacheTTL,
+			Output:   tools.OutputShape{MaxItems: cfg.MaxItems},
+			New:      func(tools.Dependencies) tool.BaseTool { return t },
+		})
+		imported = append(imported, name)
+	}
+	delete(allowed, "*")
+	for name := range allowed {
+		logx.Warn().Str("mcp_server", cfg.Name).Str("tool_name", name).Msg("Allowed MCP tool not offered by the server")
+	}
+	logx.Info().Str("mcp_server", cfg.Name).Strs("tools", imported).Msg("Imported MCP tools")
+	return nil
+}
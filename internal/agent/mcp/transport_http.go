@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+const (
+	headerSessionID       = "Mcp-Session-Id"
+	headerProtocolVersion = "MCP-Protocol-Version"
+	maxHTTPErrorBytes     = 512 // body excerpt kept in errors
+)
+
+// httpTransport speaks the streamable HTTP transport: each message is POSTed
+// to one endpoint and the response arrives as JSON or as an SSE stream.
+type httpTransport struct {
+	name     string
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+}
+
+func newHTTPTransport(name, endpoint string, headers map[string]string, client *http.Client) *httpTransport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpTransport{name: name, endpoint: endpoint, headers: headers, client: client}
+}
+
+func (t *httpTransport) setProtocolVersion(v string) {
+	t.mu.Lock()
+	t.protocolVersion = v
+	t.mu.Unlock()
+}
+
+func (t *httpTransport) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set(headerSessionID, t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set(headerProtocolVersion, t.protocolVersion)
+	}
+	t.mu.Unlock()
+	return req, nil
+}
+
+func (t *httpTransport) post(ctx context.Context, msg *message) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := t.newRequest(ctx, http.MethodPost, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("mcp http %s: %w: %w", msg.Method, tools.ErrTransient, err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxHTTPErrorBytes))
+		err := fmt.Errorf("mcp http %s: status %d: %s", msg.Method, resp.StatusCode, strings.TrimSpace(string(excerpt)))
+		if resp.StatusCode >= 500 {
+			err = fmt.Errorf("%w: %w", err, tools.ErrTransient)
+		}
+		return nil, err
+	}
+	if id := resp.Header.Get(headerSessionID); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+func (t *httpTransport) call(ctx context.Context, req *message) (*message, error) {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return t.readStream(ctx, resp.Body, *req.ID)
+	}
+	var msg message
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("mcp http %s: decode response: %w", req.Method, err)
+	}
+	return &msg, nil
+}
+
+// readStream reads SSE events until the response to id arrives. Server
+// requests on the stream are answered; notifications are ignored.
+func (t *httpTransport) readStream(ctx context.Context, body io.Reader, id int64) (*message, error) {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 64*1024), maxStdioMessageBytes)
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		if after, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(after, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue // event, id and retry fields, comments
+		}
+		var msg message
+		err := json.Unmarshal([]byte(data.String()), &msg)
+		data.Reset()
+		if err != nil {
+			logx.Warn().Err(err).Str("mcp_server", t.name).Msg("Ignoring malformed MCP event")
+			continue
+		}
+		switch {
+		case msg.isResponse() && *msg.ID == id:
+			return &msg, nil
+		case msg.ID != nil && msg.Method != "":
+			t.answer(ctx, &msg)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("mcp http stream: %w: %w", tools.ErrTransient, err)
+	}
+	return nil, fmt.Errorf("mcp http stream ended without a response to request %d: %w", id, tools.ErrTransient)
+}
+
+// answer replies to server-initiated requests like stdioTransport.answer.
+func (t *httpTransport) answer(ctx context.Context, req *message) {
+	resp := &message{JSONRPC: jsonRPCVersion, ID: req.ID}
+	if req.Method == "ping" {
+		resp.Result = json.RawMessage(`{}`)
+	} else {
+		resp.Error = &RPCError{Code: codeMethodNotFound, Message: "client does not support " + req.Method}
+	}
+	if err := t.notify(ctx, resp); err != nil {
+		logx.Warn().Err(err).Str("mcp_server", t.name).Str("method", req.Method).Msg("Failed to answer MCP server request")
+	}
+}
+
+// notify posts a notification or response; the server acknowledges with 202.
+func (t *httpTransport) notify(ctx context.Context, msg *message) error {
+	resp, err := t.post(ctx, msg)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// close ends the session on the server, when it issued one.
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	session := t.sessionID
+	t.mu.Unlock()
+	if session == "" {
+		return nil
+	}
+	req, err := t.newRequest(context.Background(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
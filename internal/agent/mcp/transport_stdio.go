@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+)
+
+const (
+	maxStdioMessageBytes = 8 << 20         // longest JSON-RPC line accepted from a server
+	stdioStopGrace       = 2 * time.Second // wait after closing stdin before killing the server
+)
+
+// errTransportClosed is returned for calls on a closed or exited transport.
+var errTransportClosed = fmt.Errorf("mcp transport closed: %w", tools.ErrTransient)
+
+// stdioTransport runs a server as a child process and exchanges
+// newline-delimited JSON-RPC messages over its stdin and stdout.
+type stdioTransport struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	pending map[int64]chan *message
+	done    chan struct{} // closed when stdout ends
+	err     error         // why stdout ended
+	closed  sync.Once
+}
+
+func startStdio(name, command string, args, env []string, dir string) (*stdioTransport, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Dir = dir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start %s: %w", command, err)
+	}
+
+	t := &stdioTransport{
+		name:    name,
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: map[int64]chan *message{},
+		done:    make(chan struct{}),
+	}
+	go t.readLoop(stdout)
+	go func() {
+		sc := bufio.NewScanner(stderr)
+		for sc.Scan() {
+			logx.Debug().Str("mcp_server", name).Str("stderr", sc.Text()).Msg("MCP server log")
+		}
+	}()
+	return t, nil
+}
+
+func (t *stdioTransport) readLoop(stdout io.Reader) {
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 64*1024), maxStdioMessageBytes)
+	for sc.Scan() {
+		var msg message
+		if err := json.Unmarshal(sc.Bytes(), &msg); err != nil {
+			logx.Warn().Err(err).Str("mcp_server", t.name).Msg("Ignoring malformed MCP message")
+			continue
+		}
+		switch {
+		case msg.isResponse():
+			t.mu.Lock()
+			ch := t.pending[*msg.ID]
+			delete(t.pending, *msg.ID)
+			t.mu.Unlock()
+			if ch != nil {
+				ch <- &msg
+			}
+		case msg.ID != nil:
+			t.answer(&msg)
+		}
+	}
+	err := sc.Err()
+	if err == nil {
+		err = io.EOF
+	}
+	t.mu.Lock()
+	t.err = fmt.Errorf("%w: server output ended: %v", errTransportClosed, err)
+	t.pending = nil
+	t.mu.Unlock()
+	close(t.done)
+}
+
+// answer replies to server-initiated requests: ping succeeds, anything else
+// (sampling, elicitation, roots) is not supported.
+func (t *stdioTransport) answer(req *message) {
+	resp := &message{JSONRPC: jsonRPCVersion, ID: req.ID}
+	if req.Method == "ping" {
+		resp.Result = json.RawMessage(`{}`)
+	} else {
+		resp.Error = &RPCError{Code: codeMethodNotFound, Message: "client does not support " + req.Method}
+	}
+	if err := t.write(resp); err != nil {
+		logx.Warn().Err(err).Str("mcp_server", t.name).Str("method", req.Method).Msg("Failed to answer MCP server request")
+	}
+}
+
+func (t *stdioTransport) write(msg *message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err = t.stdin.Write(append(b, '\n'))
+	return err
+}
+
+func (t *stdioTransport) call(ctx context.Context, req *message) (*message, error) {
+	ch := make(chan *message, 1)
+	t.mu.Lock()
+	if t.pending == nil {
+		err := t.err
+		t.mu.Unlock()
+		return nil, err
+	}
+	t.pending[*req.ID] = ch
+	t.mu.Unlock()
+	forget := func() {
+		t.mu.Lock()
+		if t.pending != nil {
+			delete(t.pending, *req.ID)
+		}
+		t.mu.Unlock()
+	}
+
+	if err := t.write(req); err != nil {
+		forget()
+		return nil, fmt.Errorf("mcp write: %w: %w", tools.ErrTransient, err)
+	}
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-t.done:
+		t.mu.Lock()
+		err := t.err
+		t.mu.Unlock()
+		return nil, err
+	case <-ctx.Done():
+		forget()
+		t.cancel(*req.ID, ctx.Err())
+		return nil, ctx.Err()
+	}
+}
+
+// cancel tells the server to stop working on an abandoned request.
+func (t *stdioTransport) cancel(id int64, reason error) {
+	params, _ := json.Marshal(map[string]any{"requestId": id, "reason": reason.Error()})
+	_ = t.write(&message{JSONRPC: jsonRPCVersion, Method: "notifications/cancelled", Params: params})
+}
+
+func (t *stdioTransport) notify(_ context.Context, msg *message) error {
+	return t.write(msg)
+}
+
+// close closes the server's stdin and kills it if it does not exit in time.
+func (t *stdioTransport) close() error {
+	t.closed.Do(func() {
+		_ = t.stdin.Close()
+		// Wait closes stdout, so give the server time to finish writing first
+		select {
+		case <-t.done:
+		case <-time.After(stdioStopGrace):
+			_ = t.cmd.Process.Kill()
+			select {
+			case <-t.done:
+			case <-time.After(stdioStopGrace): // grandchildren may hold stdout open
+			}
+		}
+		_ = t.cmd.Wait()
+	})
+	return nil
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// PendingToolCall is a side-effecting tool call waiting for the customer's
+// confirmation.
+type PendingToolCall struct {
+	ID        string `json:"id"`
+	Key       string `json:"key"` // tool name and canonical arguments; approval is granted per key
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+	Summary   string `json:"summary"` // human-readable description shown in the confirmation prompt
+}
+
+// PendingAction is a paused graph run. The run's checkpoint is stored under
+// the conversation ID and resumes only when the customer confirms.
+type PendingAction struct {
+	ConversationID string            `json:"conversation_id"`
+	ToolCalls      []PendingToolCall `json:"tool_calls"`
+	Prompt         string            `json:"prompt"` // confirmation question sent to the customer
+	CreatedAt      time.Time         `json:"created_at"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+}
+
+// Expired reports whether the confirmation window has passed at t.
+func (p *PendingAction) Expired(t time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !t.Before(p.ExpiresAt)
+}
+
+// Keys returns the keys of the tool calls awaiting confirmation, one per
+// call. Provider call IDs are not unique (Gemini reuses the tool name), so
+// approval never goes by ID.
+func (p *PendingAction) Keys() []string {
+	keys := make([]string, len(p.ToolCalls))
+	for i, c := range p.ToolCalls {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+type PendingActionRepository interface {
+	// Load returns the pending action of a conversation, or nil when none is waiting
+	Load(ctx context.Context, conversationID string) (*PendingAction, error)
+
+	// Save persists the pending action until its ExpiresAt
+	Save(ctx context.Context, action *PendingAction) error
+
+	// Clear removes the pending action of a conversation
+	Clear(ctx context.Context, conversationID string) error
+}
+
+// CheckPointStore persists interrupted graph runs. Get and Set match
+// compose.CheckPointStore; Delete drops a checkpoint once it is resumed or
+// abandoned.
+type CheckPointStore interface {
+	Get(ctx context.Context, checkPointID string) ([]byte, bool, error)
+	Set(ctx context.Context, checkPointID string, checkPoint []byte) error
+	Delete(ctx context.Context, checkPointID string) error
+}
@@ -7,20 +7,53 @@ type ConversationConfig struct {
         MaxTurns int `envconfig:"CONVERSATION_NLU_MAX_TURNS" default:"5"`
     }
     Tools struct {
-        MaxCalls int `envconfig:"CONVERSATION_TOOL_MAX_CALLS" default:"10"`
+        MaxCalls        int            `envconfig:"CONVERSATION_TOOL_MAX_CALLS" default:"10"`         // tool rounds (model messages with tool calls) per query
+        MaxCallsPerTool int            `envconfig:"CONVERSATION_TOOL_MAX_CALLS_PER_TOOL" default:"4"` // runs of one tool per query; 0 = unlimited
+        MaxCallsByTool  map[string]int `envconfig:"CONVERSATION_TOOL_MAX_CALLS_BY_TOOL"`              // per-tool overrides, e.g. search_product:3,create_order:1
+        TurnBudget      string         `envconfig:"CONVERSATION_TOOL_TURN_BUDGET" default:"45s"`      // wall-clock time of one query's tool loop; 0 = unlimited
+        Confirm         bool           `envconfig:"CONVERSATION_TOOL_CONFIRM" default:"true"`         // ask before side-effecting tool calls run
+        ConfirmTTL      string         `envconfig:"CONVERSATION_TOOL_CONFIRM_TTL" default:"10m"`      // unanswered confirmations expire
+    }
+    Dialogue struct {
+        FillConfidence      float64 `envconfig:"DIALOGUE_FILL_CONFIDENCE" default:"0.5"`
+        OverwriteConfidence float64 `envconfig:"DIALOGUE_OVERWRITE_CONFIDENCE" default:"0.8"`
+        ConfirmConfidence   float64 `envconfig:"DIALOGUE_CONFIRM_CONFIDENCE" default:"0.9"`
     }
 }
 
+// NLU output modes (NLU_MODE).
+const (
+	NLUModeTuple = "tuple" // delimiter-based tuple protocol parsed by parsers.ParseNLUResponse
+	NLUModeJSON  = "json"  // response-schema constrained JSON parsed by parsers.ParseNLUJSONResponse
+)
+
 type NLUModelConfig struct {
     Model               string   `envconfig:"NLU_MODEL" default:"openai/gpt-3.5-turbo"`
+    Mode                string   `envconfig:"NLU_MODE" default:"tuple"`
     MaxTokens           int      `envconfig:"NLU_MAX_TOKENS" default:"2000"`
     Temperature         float32  `envconfig:"NLU_TEMPERATURE" default:"0.1"`
     DefaultIntent       string   `envconfig:"NLU_DEFAULT_INTENT" default:"greet:0.1, purchase_intent:0.8, inquiry_intent:0.7, support_intent:0.6, complain_intent:0.6"`
     AdditionalIntent    string   `envconfig:"NLU_ADDITIONAL_INTENT" default:"complaint:0.5, cancel_order:0.4, ask_price:0.6, compare_product:0.5, delivery_issue:0.7"`
     DefaultEntity       string   `envconfig:"NLU_DEFAULT_ENTITY" default:"product, quantity, brand, price"`
-    AdditionalEntity    string   `envconfig:"NLU_ADDITIONAL_ENTITY" default:"color, model, spec, budget, warranty, delivery"`
+    AdditionalEntity    string   `envconfig:"NLU_ADDITIONAL_ENTITY" default:"color, model, spec, budget, warranty, delivery, use_case"`
+    FastPath            bool     `envconfig:"NLU_FAST_PATH" default:"true"`
+    FastPathConfidence  float64  `envconfig:"NLU_FAST_PATH_MIN_CONFIDENCE" default:"0.9"`
+    RepairAttempts      int      `envconfig:"NLU_REPAIR_ATTEMPTS" default:"1"` // re-prompts on unparseable output before the rule fallback
+    Cache struct {
+        Backend         string `envconfig:"NLU_CACHE_BACKEND" default:"memory"` // memory|redis|off
+        TTL             string `envconfig:"NLU_CACHE_TTL" default:"30m"`
+        Size            int    `envconfig:"NLU_CACHE_SIZE" default:"1000"` // max entries (memory backend)
+        ContextMessages int    `envconfig:"NLU_CACHE_CONTEXT_MESSAGES" default:"1"`
+    }
 }
 
+// NLU cache backends (NLU_CACHE_BACKEND).
+const (
+	NLUCacheMemory = "memory"
+	NLUCacheRedis  = "redis"
+	NLUCacheOff    = "off"
+)
+
 type ResponseModelConfig struct {
 	Model       string  `envconfig:"RESPONSE_MODEL" default:"openai/gpt-3.5-turbo"`
 	MaxTokens   int     `envconfig:"RESPONSE_MAX_TOKENS" default:"2000"`
@@ -31,3 +64,95 @@ type ResponsePromptConfig struct {
 	BusinessType string `envconfig:"PROMPT_BUSINESS_TYPE" default:"electronics store"`
 	BusinessName string `envconfig:"PROMPT_BUSINESS_NAME" default:"TechHub"`
 }
+
+// Product catalog backends (CATALOG_BACKEND).
+const (
+	CatalogMemory = "memory" // built-in demo products
+	CatalogFile   = "file"   // JSON/CSV file at CATALOG_PATH, reloaded on change
+	CatalogHTTP   = "http"   // inventory service at CATALOG_URL
+)
+
+type CatalogConfig struct {
+	Backend        string `envconfig:"CATALOG_BACKEND" default:"memory"`
+	Path           string `envconfig:"CATALOG_PATH" default:"data/products.json"`
+	ReloadInterval string `envconfig:"CATALOG_RELOAD_INTERVAL" default:"10s"` // 0 disables reload-on-change
+	URL            string `envconfig:"CATALOG_URL"`
+	APIKey         string `envconfig:"CATALOG_API_KEY"`
+	Timeout        string `envconfig:"CATALOG_TIMEOUT" default:"5s"`
+}
+
+// Promotion store backends (PROMOTIONS_BACKEND).
+const (
+	PromotionsMemory = "memory" // built-in demo promotions
+	PromotionsFile   = "file"   // JSON file at PROMOTIONS_PATH, loaded at startup
+	PromotionsRedis  = "redis"  // Redis hash "promotions", editable at runtime
+)
+
+type PromotionsConfig struct {
+	Backend string `envconfig:"PROMOTIONS_BACKEND" default:"memory"`
+	Path    string `envconfig:"PROMOTIONS_PATH" default:"data/promotions.json"`
+}
+
+// Cart/order store backends (ORDERS_BACKEND).
+const (
+	OrdersMemory = "memory"
+	OrdersRedis  = "redis"
+)
+
+type OrdersConfig struct {
+	Backend        string `envconfig:"ORDERS_BACKEND" default:"memory"`
+	CartTTL        string `envconfig:"ORDER_CART_TTL" default:"168h"`        // idle carts expire (redis backend)
+	ReservationTTL string `envconfig:"ORDER_RESERVATION_TTL" default:"30m"` // stock hold for unpaid orders
+}
+
+// KnowledgeConfig locates the policy knowledge base. The index is rebuilt
+// with `go run ./cmd/kbindex`; a missing or stale index is rebuilt in memory
+// at startup.
+type KnowledgeConfig struct {
+	Dir          string `envconfig:"KNOWLEDGE_DIR" default:"data/knowledge"` // .md and PDF-extracted .txt documents
+	IndexPath    string `envconfig:"KNOWLEDGE_INDEX_PATH" default:"data/knowledge/index.json"`
+	ChunkSize    int    `envconfig:"KNOWLEDGE_CHUNK_SIZE" default:"800"`    // runes per chunk
+	ChunkOverlap int    `envconfig:"KNOWLEDGE_CHUNK_OVERLAP" default:"120"` // runes shared by pieces of long paragraphs
+	EmbeddingDim int    `envconfig:"KNOWLEDGE_EMBEDDING_DIM" default:"512"` // local hash embedder size
+}
+
+// ToolsConfig selects the tools bound to the response model in this
+// deployment. Entries are tool names or categories (query, action, utility).
+type ToolsConfig struct {
+	Enabled  []string `envconfig:"TOOLS_ENABLED"`  // empty enables every registered tool
+	Disabled []string `envconfig:"TOOLS_DISABLED"` // removed after Enabled is applied
+	// HTTPSpecs are YAML files of REST-backed tools (tools.LoadHTTPToolSpecs)
+	HTTPSpecs []string `envconfig:"TOOLS_HTTP_SPECS"`
+	// MCPServers is a YAML file of MCP servers whose tools are imported (mcp.LoadServers)
+	MCPServers string `envconfig:"TOOLS_MCP_SERVERS"`
+
+	// Execution limits (tools.ResilienceConfig); durations are Go duration strings
+	Timeout          string            `envconfig:"TOOLS_TIMEOUT" default:"10s"`          // per attempt, for tools registered without one
+	Timeouts         map[string]string `envconfig:"TOOLS_TIMEOUTS"`                       // per-tool overrides, e.g. search_product:3s,track_shipment:8s
+	Retries          int               `envconfig:"TOOLS_RETRIES" default:"2"`            // extra attempts for read-only tools after transient failures
+	RetryBackoff     string            `envconfig:"TOOLS_RETRY_BACKOFF" default:"200ms"`  // first retry delay, doubled per retry, jittered
+	RetryMaxBackoff  string            `envconfig:"TOOLS_RETRY_MAX_BACKOFF" default:"2s"` // retry delay cap
+	BreakerThreshold int               `envconfig:"TOOLS_BREAKER_THRESHOLD" default:"5"`  // consecutive failed calls that open a tool's breaker
+	BreakerCooldown  string            `envconfig:"TOOLS_BREAKER_COOLDOWN" default:"30s"` // open breakers reject calls this long
+
+	// Parallel runs the tool calls of one model message concurrently, at most
+	// MaxParallel at a time; otherwise they run one after another
+	Parallel    bool `envconfig:"TOOLS_PARALLEL" default:"true"`
+	MaxParallel int  `envconfig:"TOOLS_MAX_PARALLEL" default:"4"`
+
+	// Results of read-only tools with a registered CacheTTL are reused
+	CacheBackend string `envconfig:"TOOLS_CACHE_BACKEND" default:"memory"` // memory|redis|off
+	CacheSize    int    `envconfig:"TOOLS_CACHE_SIZE" default:"2000"`      // max entries (memory backend)
+
+	// Results are shaped before the model sees them (tools.ShapeOutputs);
+	// token counts are estimates and 0 disables a limit
+	ResultMaxTokens   int `envconfig:"TOOLS_RESULT_MAX_TOKENS" default:"1500"`   // one result
+	ResultTokenBudget int `envconfig:"TOOLS_RESULT_TOKEN_BUDGET" default:"6000"` // all results of a query; older ones are dropped first
+}
+
+// Tool result cache backends (TOOLS_CACHE_BACKEND).
+const (
+	ToolCacheMemory = "memory"
+	ToolCacheRedis  = "redis"
+	ToolCacheOff    = "off"
+)
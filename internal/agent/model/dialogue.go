@@ -0,0 +1,58 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Slot names tracked across turns by the dialogue state tracker.
+const (
+	SlotProductType = "product_type"
+	SlotBudget      = "budget"
+	SlotBrand       = "brand"
+	SlotUseCase     = "use_case"
+	SlotQuantity    = "quantity"
+)
+
+// DialogueSlots lists the tracked slots in display order.
+var DialogueSlots = []string{SlotProductType, SlotBudget, SlotBrand, SlotUseCase, SlotQuantity}
+
+// SlotValue is the current value of a dialogue slot.
+type SlotValue struct {
+	Value       string           `json:"value"`                // canonical display value
+	Normalized  *NormalizedValue `json:"normalized,omitempty"` // typed value when the source entity was normalized
+	Confidence  float64          `json:"confidence"`
+	Confirmed   bool             `json:"confirmed"` // repeated by the user or extracted with high confidence
+	UpdatedTurn int              `json:"updated_turn"`
+}
+
+// DialogueState is the persisted per-conversation slot state.
+type DialogueState struct {
+	ConversationID string               `json:"conversation_id"`
+	Turn           int                  `json:"turn"`
+	Slots          map[string]SlotValue `json:"slots"`
+	// Pending holds low-confidence values that conflict with a filled slot and
+	// need the customer's confirmation before they overwrite it.
+	Pending   map[string]SlotValue `json:"pending,omitempty"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// Slot returns the value of a filled slot.
+func (d *DialogueState) Slot(name string) (SlotValue, bool) {
+	if d == nil || d.Slots == nil {
+		return SlotValue{}, false
+	}
+	v, ok := d.Slots[name]
+	return v, ok
+}
+
+type DialogueStateRepository interface {
+	// Load returns the dialogue state for a conversation (empty state when none exists)
+	Load(ctx context.Context, conversationID string) (*DialogueState, error)
+
+	// Save persists the dialogue state for its conversation
+	Save(ctx context.Context, state *DialogueState) error
+
+	// Clear removes the dialogue state for a conversation
+	Clear(ctx context.Context, conversationID string) error
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Escalation reasons raised by support tools.
+const (
+	EscalationShipmentOverdue   = "shipment_overdue"   // not delivered by the promised date
+	EscalationShipmentException = "shipment_exception" // carrier reported a failed delivery, damage or loss
+	EscalationNotShipped        = "order_not_shipped"  // paid but not handed to a carrier by the promised date
+)
+
+// Escalation severities.
+const (
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+// Escalation signals that a case needs staff attention. Tools return it to
+// the model and record it in AppState; the final answer carries it in Extra.
+type Escalation struct {
+	Reason       string    `json:"reason"`
+	Severity     string    `json:"severity"`
+	OrderID      string    `json:"order_id,omitempty"`
+	OverdueHours int       `json:"overdue_hours,omitempty"`
+	Detail       string    `json:"detail,omitempty"`
+	RaisedAt     time.Time `json:"raised_at"`
+}
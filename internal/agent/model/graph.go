@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/cloudwego/eino/schema"
 )
 
@@ -15,24 +17,53 @@ import (
 //     use repositories/services (e.g., MessagesManager).
 type AppState struct {
     ConversationID       string
+    CustomerID           string            // authenticated customer, when known; owns carts and orders
+    CurrentQuery         string            // raw user message of this query, set by input converter pre-handler
+    PrecomputedNLU       *NLUResponse      // NLU result produced without the NLU model (rule fast path or cache); nil otherwise
+    NLUCacheKey          string            // cache key of this query's NLU result; empty when caching is disabled
+    NLUInput             []*schema.Message // messages sent to the NLU model, kept for the parser's repair re-prompt
+    Degraded             bool              // NLU fell back to local rules; set by parser post-handler
+    DegradedReason       string            // why the run is degraded (e.g., nlu_unparseable)
     History              []*schema.Message // mutated only inside Eino state handlers
     NLUAnalysis          *NLUResponse      // set by parser post-handler, read by assembler
+    DialogueState        *DialogueState    // slot state after merging this turn, set by parser post-handler
     ToolCallCount        int               // maintained in handlers (reset/increment)
-    ToolCallLimitReached bool              // set when tool call limit is exceeded
+    ToolCallLimitReached bool              // set when the tool loop is cut (see ToolLoopCutReason)
+    ToolLoopCutReason    string            // why the tool loop was cut (ToolLoop* constants); empty when it ended on its own
+    ToolLoopStartedAt    time.Time         // first tool round of the query; the turn budget runs from here
+    ToolCallsByTool      map[string]int    // calls run per tool this query, against the per-tool caps
+    ToolCallKeys         []string          // tools.CallKey of each call of the running message by position, empty for refused calls; until the results arrive
+    ToolResultsByKey     map[string]string // successful results of this query's calls by ToolCallKeys value, replayed for repeats
+    ToolCallOverrides    map[string][]string // tools.OverrideKey -> stand-in results of refused calls (repeat, over cap, out of time), taken in order
+    StalledToolRounds    int               // tool rounds in which every call was refused
     ToolCallIDSeq        int               // local sequence to synthesize tool_call_id when provider omits
+    ApprovedToolCalls    []string          // tools.CallKey of side-effecting calls confirmed by the customer, one use each; set on resume
+    PendingToolMessage   *schema.Message   // tool-call message held by ToolApproval while the run is paused
+    Escalations          []Escalation      // raised by support tools during this query; surfaced on the final answer
+    ToolResultsDropped   int               // older tool results replaced in History to stay within the token budget
 
     // Accumulated total LLM cost (USD) across model invocations for this query
     TotalCostUSD float64
 }
 
+// Reasons the tool loop of a query was cut (AppState.ToolLoopCutReason).
+const (
+	ToolLoopMaxCalls   = "max_tool_calls" // CONVERSATION_TOOL_MAX_CALLS tool rounds used
+	ToolLoopTurnBudget = "turn_budget"    // CONVERSATION_TOOL_TURN_BUDGET ran out
+	ToolLoopNoProgress = "no_progress"    // the model kept making calls that were refused
+)
+
 // QueryInput represents the input for processing user queries.
 type QueryInput struct {
 	ConversationID string `json:"conversation_id"`
+	CustomerID     string `json:"customer_id,omitempty"` // optional; carts and orders fall back to the conversation
 	Query          string `json:"query"`
 }
 
 // ResponseData holds the data for the response.
 type ResponseData struct {
-	Analysis       NLUResponse // NLU analysis result
-	ConversationID string      // Conversation identifier from state
+	Analysis       NLUResponse    // NLU analysis result
+	Degraded       bool           // NLU analysis came from the rule fallback
+	Dialogue       *DialogueState // Slot state across turns (nil when tracking is disabled)
+	ConversationID string         // Conversation identifier from state
 }
@@ -1,6 +1,12 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
 
 // Intent represents a detected user intent
 type Intent struct {
@@ -47,3 +53,87 @@ type NLUResponse struct {
 	ParsingMetadata map[string]any `json:"parsing_metadata"`
 	Timestamp       time.Time      `json:"timestamp"`
 }
+
+// NormalizedValue is the canonical, typed form of an entity value produced by
+// the entity normalization stage that runs after NLU parsing.
+type NormalizedValue struct {
+	Kind      string  `json:"kind"`                 // money, quantity, color, brand, product_type, use_case
+	Amount    float64 `json:"amount,omitempty"`     // money amount or item count
+	MinAmount float64 `json:"min_amount,omitempty"` // lower end of a money range
+	Currency  string  `json:"currency,omitempty"`   // ISO 4217 (money only)
+	Bound     string  `json:"bound,omitempty"`      // exact, max, min, approx, range (money only)
+	Unit      string  `json:"unit,omitempty"`       // quantity unit as written (e.g. เครื่อง, pcs)
+	Text      string  `json:"text,omitempty"`       // canonical text for colors, brands, product types and use cases
+}
+
+// String renders the value in a compact, prompt-friendly form.
+func (v NormalizedValue) String() string {
+	switch v.Kind {
+	case "money":
+		amount := strconv.FormatFloat(v.Amount, 'f', -1, 64)
+		switch v.Bound {
+		case "range":
+			return fmt.Sprintf("%s-%s %s", strconv.FormatFloat(v.MinAmount, 'f', -1, 64), amount, v.Currency)
+		case "", "exact":
+			return fmt.Sprintf("%s %s", amount, v.Currency)
+		default:
+			return fmt.Sprintf("%s %s (%s)", amount, v.Currency, v.Bound)
+		}
+	case "quantity":
+		s := strconv.FormatFloat(v.Amount, 'f', -1, 64)
+		if v.Unit != "" {
+			s += " " + v.Unit
+		}
+		return s
+	default:
+		return v.Text
+	}
+}
+
+// Normalized returns the canonical value attached to the entity, if any.
+// Metadata may hold either the typed value or its JSON-decoded map form
+// (e.g. after a cache round trip), so both are accepted.
+func (e Entity) Normalized() (NormalizedValue, bool) {
+	if e.Metadata == nil {
+		return NormalizedValue{}, false
+	}
+	switch v := e.Metadata[NormalizedMetaKey].(type) {
+	case NormalizedValue:
+		return v, true
+	case *NormalizedValue:
+		if v == nil {
+			return NormalizedValue{}, false
+		}
+		return *v, true
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return NormalizedValue{}, false
+		}
+		var nv NormalizedValue
+		if err := json.Unmarshal(b, &nv); err != nil || nv.Kind == "" {
+			return NormalizedValue{}, false
+		}
+		return nv, true
+	}
+	return NormalizedValue{}, false
+}
+
+// NormalizedMetaKey is the Entity.Metadata key holding the NormalizedValue.
+const NormalizedMetaKey = "normalized"
+
+// NormalizedEntities returns the entities whose normalized kind matches one of
+// kinds, or every normalized entity when kinds is empty.
+func (r NLUResponse) NormalizedEntities(kinds ...string) []Entity {
+	var out []Entity
+	for _, e := range r.Entities {
+		nv, ok := e.Normalized()
+		if !ok {
+			continue
+		}
+		if len(kinds) == 0 || slices.Contains(kinds, nv.Kind) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
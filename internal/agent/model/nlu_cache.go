@@ -0,0 +1,23 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// NLUCache stores NLU results keyed by a hash of the normalized message,
+// recent context and intent catalog version.
+type NLUCache interface {
+	// Get returns the cached result for key, or nil when absent or expired
+	Get(ctx context.Context, key string) (*NLUResponse, error)
+
+	// Set stores resp under key for ttl
+	Set(ctx context.Context, key string, resp *NLUResponse, ttl time.Duration) error
+
+	// SetCatalogVersion records the current intent/entity catalog version and
+	// drops all entries when it differs from the previously recorded one.
+	SetCatalogVersion(ctx context.Context, version string) (changed bool, err error)
+
+	// Purge removes all cached entries
+	Purge(ctx context.Context) error
+}
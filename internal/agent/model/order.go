@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrOrderNotFound is returned by OrderStore.GetOrder for unknown IDs.
+var ErrOrderNotFound = errors.New("order not found")
+
+// Order statuses.
+const (
+	OrderPendingPayment = "pending_payment"
+	OrderPaid           = "paid"
+	OrderShipped        = "shipped"
+	OrderDelivered      = "delivered"
+	OrderReadyForPickup = "ready_for_pickup"
+	OrderCancelled      = "cancelled"
+)
+
+// Fulfillment methods.
+const (
+	FulfillmentShipping = "shipping"
+	FulfillmentPickup   = "pickup"
+)
+
+// CartItem is a product and quantity in a cart. Prices are never stored;
+// they are quoted from the catalog and promotions whenever the cart is shown.
+type CartItem struct {
+	ProductID string    `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// Cart belongs to an owner: the customer ID when known, else the conversation ID.
+type Cart struct {
+	OwnerID   string     `json:"owner_id"`
+	Items     []CartItem `json:"items"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// OrderLine is a priced order item; amounts are THB.
+type OrderLine struct {
+	ProductID  string   `json:"product_id"`
+	Name       string   `json:"name"`
+	Quantity   int      `json:"quantity"`
+	UnitPrice  float64  `json:"unit_price"` // catalog price
+	Discount   float64  `json:"discount"`   // total discount on the line
+	LineTotal  float64  `json:"line_total"`
+	Promotions []string `json:"promotions,omitempty"` // applied promotion IDs
+	BranchID   string   `json:"branch_id,omitempty"`  // branch the units are reserved at
+}
+
+// Order is a placed order with totals fixed at creation.
+type Order struct {
+	ID             string      `json:"id"`
+	OwnerID        string      `json:"owner_id"`
+	ConversationID string      `json:"conversation_id"`
+	Status         string      `json:"status"`
+	Lines          []OrderLine `json:"lines"`
+	Subtotal       float64     `json:"subtotal"`
+	Discount       float64     `json:"discount"`
+	Total          float64     `json:"total"`
+	Currency       string      `json:"currency"`
+	CouponCode     string      `json:"coupon_code,omitempty"`
+	Fulfillment    string      `json:"fulfillment"`              // shipping, pickup
+	Address        string      `json:"address,omitempty"`        // shipping address
+	PickupBranch   string      `json:"pickup_branch,omitempty"`  // branch ID for pickup
+	ReservedUntil  time.Time   `json:"reserved_until,omitempty"` // stock hold expiry while unpaid
+	PromisedBy     time.Time   `json:"promised_by,omitempty"`    // delivery (or pickup readiness) promised at checkout
+	Shipment       *Shipment   `json:"shipment,omitempty"`       // set once the order is handed to a carrier
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// Shipment is the carrier handover of a shipped order.
+type Shipment struct {
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"tracking_number"`
+	ShippedAt      time.Time `json:"shipped_at"`
+}
+
+// OrderStore persists carts and orders.
+type OrderStore interface {
+	// LoadCart returns the owner's cart (empty cart when none exists)
+	LoadCart(ctx context.Context, ownerID string) (*Cart, error)
+
+	// SaveCart persists the cart for its owner
+	SaveCart(ctx context.Context, cart *Cart) error
+
+	// ClearCart removes the owner's cart
+	ClearCart(ctx context.Context, ownerID string) error
+
+	// SaveOrder creates or updates an order
+	SaveOrder(ctx context.Context, order *Order) error
+
+	// GetOrder returns one order, or ErrOrderNotFound
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+
+	// ListOrders returns the owner's orders, newest first
+	ListOrders(ctx context.Context, ownerID string) ([]*Order, error)
+}
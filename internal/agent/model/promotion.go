@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// Promotion kinds.
+const (
+	PromotionDiscount = "discount" // applied automatically to matching products
+	PromotionBundle   = "bundle"   // applies when bought together with one of BundleWith
+	PromotionCoupon   = "coupon"   // applies only when the customer gives CouponCode
+)
+
+// Promotion is a time-bounded price reduction. PercentOff is applied before
+// AmountOff; the discount never exceeds the price.
+type Promotion struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Kind        string    `json:"kind"`
+	ProductIDs  []string  `json:"product_ids,omitempty"` // with Categories empty too, applies to every product
+	Categories  []string  `json:"categories,omitempty"`
+	PercentOff  float64   `json:"percent_off,omitempty"`
+	AmountOff   float64   `json:"amount_off,omitempty"` // THB
+	BundleWith  []string  `json:"bundle_with,omitempty"`
+	CouponCode  string    `json:"coupon_code,omitempty"`
+	Hidden      bool      `json:"hidden,omitempty"`    // redeemable but never listed (private coupons)
+	StartsAt    time.Time `json:"starts_at,omitempty"` // zero = already started
+	EndsAt      time.Time `json:"ends_at,omitempty"`   // zero = no end
+}
+
+// Active reports whether the promotion runs at t.
+func (p Promotion) Active(t time.Time) bool {
+	if !p.StartsAt.IsZero() && t.Before(p.StartsAt) {
+		return false
+	}
+	if !p.EndsAt.IsZero() && !t.Before(p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo reports whether the promotion targets product.
+func (p Promotion) AppliesTo(product Product) bool {
+	if len(p.ProductIDs) == 0 && len(p.Categories) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == product.ID {
+			return true
+		}
+	}
+	for _, c := range p.Categories {
+		if strings.EqualFold(c, product.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscountOn returns the THB reduction of price, rounded to satang.
+func (p Promotion) DiscountOn(price float64) float64 {
+	d := price*p.PercentOff/100 + p.AmountOff
+	if d > price {
+		d = price
+	}
+	if d < 0 {
+		d = 0
+	}
+	return math.Round(d*100) / 100
+}
+
+// PromotionStore holds the promotions managed by the business.
+type PromotionStore interface {
+	// List returns all promotions, including inactive ones
+	List(ctx context.Context) ([]Promotion, error)
+
+	// Put creates or replaces the promotion with p.ID
+	Put(ctx context.Context, p Promotion) error
+
+	// Delete removes the promotion; deleting an unknown ID is not an error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// ToolResultCache stores results of read-only tool calls keyed by tool name
+// and a hash of the call's canonical arguments.
+type ToolResultCache interface {
+	// Get returns the cached result, or ok=false when absent or expired
+	Get(ctx context.Context, tool, key string) (result string, ok bool, err error)
+
+	// Set stores result under tool and key for ttl
+	Set(ctx context.Context, tool, key, result string, ttl time.Duration) error
+
+	// Invalidate removes all cached results of tool
+	Invalidate(ctx context.Context, tool string) error
+
+	// Purge removes all cached entries
+	Purge(ctx context.Context) error
+}
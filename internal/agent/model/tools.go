@@ -3,10 +3,15 @@ package model
 type Product struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
+	Brand       string  `json:"brand,omitempty"`
 	Category    string  `json:"category"`
 	Price       float64 `json:"price"`
 	Description string  `json:"description"`
 	InStock     bool    `json:"in_stock"`
+
+	// Catalog-only fields returned by get_product_details (omitted from search results)
+	Details        string            `json:"details,omitempty"`
+	Specifications map[string]string `json:"specifications,omitempty"`
 }
 
 type ProductPrice struct {
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisPendingActionRepository keeps tool calls awaiting confirmation. Keys
+// expire with the action, so an unanswered confirmation simply disappears.
+type RedisPendingActionRepository struct {
+	rdb redis.Cmdable
+}
+
+func NewRedisPendingActionRepository(rdb redis.Cmdable) *RedisPendingActionRepository {
+	return &RedisPendingActionRepository{rdb: rdb}
+}
+
+func (r *RedisPendingActionRepository) actionKey(conversationID string) string {
+	return fmt.Sprintf("conversation:%s:pending_action", conversationID)
+}
+
+func (r *RedisPendingActionRepository) Load(ctx context.Context, conversationID string) (*model.PendingAction, error) {
+	key := r.actionKey(conversationID)
+	raw, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		logx.Error().Err(err).Str("key", key).Msg("failed to load pending action from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	var action model.PendingAction
+	if err := json.Unmarshal([]byte(raw), &action); err != nil {
+		logx.Error().Err(err).Str("conversationID", conversationID).Msg("failed to unmarshal pending action")
+		return nil, fmt.Errorf("unmarshal pending action: %w", err)
+	}
+	return &action, nil
+}
+
+func (r *RedisPendingActionRepository) Save(ctx context.Context, action *model.PendingAction) error {
+	if action == nil {
+		return fmt.Errorf("pending action is nil")
+	}
+	b, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("marshal pending action: %w", err)
+	}
+	var ttl time.Duration
+	if !action.ExpiresAt.IsZero() {
+		if ttl = time.Until(action.ExpiresAt); ttl <= 0 {
+			return nil
+		}
+	}
+	key := r.actionKey(action.ConversationID)
+	if err := r.rdb.Set(ctx, key, b, ttl).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to save pending action to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (r *RedisPendingActionRepository) Clear(ctx context.Context, conversationID string) error {
+	key := r.actionKey(conversationID)
+	if err := r.rdb.Del(ctx, key).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to delete pending action from redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+// RedisCheckPointStore stores interrupted graph runs. Checkpoint IDs are
+// conversation IDs; ttl should be at least the confirmation window.
+type RedisCheckPointStore struct {
+	rdb redis.Cmdable
+	ttl time.Duration
+}
+
+func NewRedisCheckPointStore(rdb redis.Cmdable, ttl time.Duration) *RedisCheckPointStore {
+	return &RedisCheckPointStore{rdb: rdb, ttl: ttl}
+}
+
+func (s *RedisCheckPointStore) checkPointKey(checkPointID string) string {
+	return fmt.Sprintf("conversation:%s:checkpoint", checkPointID)
+}
+
+func (s *RedisCheckPointStore) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	key := s.checkPointKey(checkPointID)
+	raw, err := s.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		logx.Error().Err(err).Str("key", key).Msg("failed to load checkpoint from redis")
+		return nil, false, errx.WrapRedis(err)
+	}
+	return raw, true, nil
+}
+
+func (s *RedisCheckPointStore) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	key := s.checkPointKey(checkPointID)
+	if err := s.rdb.Set(ctx, key, checkPoint, s.ttl).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to save checkpoint to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (s *RedisCheckPointStore) Delete(ctx context.Context, checkPointID string) error {
+	key := s.checkPointKey(checkPointID)
+	if err := s.rdb.Del(ctx, key).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to delete checkpoint from redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+var (
+	_ model.PendingActionRepository = (*RedisPendingActionRepository)(nil)
+	_ model.CheckPointStore         = (*RedisCheckPointStore)(nil)
+)
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// MemoryPendingActionRepository keeps pending actions in process memory.
+// Expired actions are dropped on Load.
+type MemoryPendingActionRepository struct {
+	mu      sync.Mutex
+	actions map[string][]byte
+	now     func() time.Time
+}
+
+func NewMemoryPendingActionRepository() *MemoryPendingActionRepository {
+	return &MemoryPendingActionRepository{actions: map[string][]byte{}, now: time.Now}
+}
+
+func (r *MemoryPendingActionRepository) Load(ctx context.Context, conversationID string) (*model.PendingAction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	raw, ok := r.actions[conversationID]
+	if !ok {
+		return nil, nil
+	}
+	var action model.PendingAction
+	if err := json.Unmarshal(raw, &action); err != nil {
+		return nil, fmt.Errorf("unmarshal pending action: %w", err)
+	}
+	if action.Expired(r.now()) {
+		delete(r.actions, conversationID)
+		return nil, nil
+	}
+	return &action, nil
+}
+
+func (r *MemoryPendingActionRepository) Save(ctx context.Context, action *model.PendingAction) error {
+	if action == nil {
+		return fmt.Errorf("pending action is nil")
+	}
+	b, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("marshal pending action: %w", err)
+	}
+	r.mu.Lock()
+	r.actions[action.ConversationID] = b
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *MemoryPendingActionRepository) Clear(ctx context.Context, conversationID string) error {
+	r.mu.Lock()
+	delete(r.actions, conversationID)
+	r.mu.Unlock()
+	return nil
+}
+
+// MemoryCheckPointStore keeps graph checkpoints in process memory. Entries
+// live until deleted; the pending action decides whether one is still valid.
+type MemoryCheckPointStore struct {
+	mu          sync.RWMutex
+	checkPoints map[string][]byte
+}
+
+func NewMemoryCheckPointStore() *MemoryCheckPointStore {
+	return &MemoryCheckPointStore{checkPoints: map[string][]byte{}}
+}
+
+func (s *MemoryCheckPointStore) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cp, ok := s.checkPoints[checkPointID]
+	return cp, ok, nil
+}
+
+func (s *MemoryCheckPointStore) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	s.mu.Lock()
+	s.checkPoints[checkPointID] = append([]byte(nil), checkPoint...)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryCheckPointStore) Delete(ctx context.Context, checkPointID string) error {
+	s.mu.Lock()
+	delete(s.checkPoints, checkPointID)
+	s.mu.Unlock()
+	return nil
+}
+
+var (
+	_ model.PendingActionRepository = (*MemoryPendingActionRepository)(nil)
+	_ model.CheckPointStore         = (*MemoryCheckPointStore)(nil)
+)
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisDialogueStateRepository struct {
+	rdb redis.Cmdable
+	ttl time.Duration
+}
+
+func NewRedisDialogueStateRepository(rdb redis.Cmdable, ttl time.Duration) *RedisDialogueStateRepository {
+	return &RedisDialogueStateRepository{rdb: rdb, ttl: ttl}
+}
+
+func (r *RedisDialogueStateRepository) stateKey(conversationID string) string {
+	return fmt.Sprintf("conversation:%s:dialogue_state", conversationID)
+}
+
+func (r *RedisDialogueStateRepository) Load(ctx context.Context, conversationID string) (*model.DialogueState, error) {
+	key := r.stateKey(conversationID)
+	raw, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.DialogueState{ConversationID: conversationID, Slots: map[string]model.SlotValue{}}, nil
+		}
+		logx.Error().Err(err).Str("key", key).Msg("failed to load dialogue state from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	var st model.DialogueState
+	if err := json.Unmarshal([]byte(raw), &st); err != nil {
+		logx.Error().Err(err).Str("conversationID", conversationID).Msg("failed to unmarshal dialogue state")
+		return nil, fmt.Errorf("unmarshal dialogue state: %w", err)
+	}
+	if st.Slots == nil {
+		st.Slots = map[string]model.SlotValue{}
+	}
+	st.ConversationID = conversationID
+	return &st, nil
+}
+
+func (r *RedisDialogueStateRepository) Save(ctx context.Context, state *model.DialogueState) error {
+	if state == nil {
+		return fmt.Errorf("dialogue state is nil")
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		logx.Error().Err(err).Str("conversationID", state.ConversationID).Msg("failed to marshal dialogue state")
+		return fmt.Errorf("marshal dialogue state: %w", err)
+	}
+	key := r.stateKey(state.ConversationID)
+	// keep dialogue state alive exactly as long as the conversation history
+	if err := r.rdb.Set(ctx, key, b, r.ttl).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to save dialogue state to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (r *RedisDialogueStateRepository) Clear(ctx context.Context, conversationID string) error {
+	key := r.stateKey(conversationID)
+	if err := r.rdb.Del(ctx, key).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to delete dialogue state from redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+var _ model.DialogueStateRepository = (*RedisDialogueStateRepository)(nil)
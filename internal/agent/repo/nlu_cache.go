@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	nluCacheEntryPrefix = "nlu_cache:entry:"
+	nluCacheVersionKey  = "nlu_cache:catalog_version"
+)
+
+// RedisNLUCache shares NLU results across processes.
+type RedisNLUCache struct {
+	rdb redis.Cmdable
+}
+
+func NewRedisNLUCache(rdb redis.Cmdable) *RedisNLUCache {
+	return &RedisNLUCache{rdb: rdb}
+}
+
+func (c *RedisNLUCache) entryKey(key string) string {
+	return nluCacheEntryPrefix + key
+}
+
+func (c *RedisNLUCache) Get(ctx context.Context, key string) (*model.NLUResponse, error) {
+	rkey := c.entryKey(key)
+	raw, err := c.rdb.Get(ctx, rkey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		logx.Error().Err(err).Str("key", rkey).Msg("failed to load nlu cache entry from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	var resp model.NLUResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		// a corrupt entry is a miss; it will be overwritten by the next store
+		logx.Warn().Err(err).Str("key", rkey).Msg("failed to unmarshal nlu cache entry")
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+func (c *RedisNLUCache) Set(ctx context.Context, key string, resp *model.NLUResponse, ttl time.Duration) error {
+	if resp == nil {
+		return fmt.Errorf("nlu response is nil")
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal nlu cache entry: %w", err)
+	}
+	rkey := c.entryKey(key)
+	if err := c.rdb.Set(ctx, rkey, b, ttl).Err(); err != nil {
+		logx.Error().Err(err).Str("key", rkey).Msg("failed to save nlu cache entry to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (c *RedisNLUCache) SetCatalogVersion(ctx context.Context, version string) (bool, error) {
+	prev, err := c.rdb.SetArgs(ctx, nluCacheVersionKey, version, redis.SetArgs{Get: true}).Result()
+	if err != nil && err != redis.Nil {
+		logx.Error().Err(err).Str("key", nluCacheVersionKey).Msg("failed to swap nlu catalog version")
+		return false, errx.WrapRedis(err)
+	}
+	if prev == version {
+		return false, nil
+	}
+	// unknown or different previous version: entries may belong to another catalog
+	if err := c.Purge(ctx); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func (c *RedisNLUCache) Purge(ctx context.Context) error {
+	var cursor uint64
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, nluCacheEntryPrefix+"*", 500).Result()
+		if err != nil {
+			logx.Error().Err(err).Msg("failed to scan nlu cache entries")
+			return errx.WrapRedis(err)
+		}
+		if len(keys) > 0 {
+			if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+				logx.Error().Err(err).Int("keys", len(keys)).Msg("failed to delete nlu cache entries")
+				return errx.WrapRedis(err)
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+var _ model.NLUCache = (*RedisNLUCache)(nil)
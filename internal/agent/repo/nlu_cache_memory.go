@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// MemoryNLUCache is a process-local LRU with per-entry expiry. Entries are
+// stored as JSON so callers can never mutate a cached result.
+type MemoryNLUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+	version string
+}
+
+type memoryNLUEntry struct {
+	key       string
+	payload   []byte
+	expiresAt time.Time
+}
+
+func NewMemoryNLUCache(size int) *MemoryNLUCache {
+	if size <= 0 {
+		size = 1000
+	}
+	return &MemoryNLUCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *MemoryNLUCache) Get(ctx context.Context, key string) (*model.NLUResponse, error) {
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil, nil
+	}
+	entry := el.Value.(*memoryNLUEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return nil, nil
+	}
+	c.order.MoveToFront(el)
+	payload := entry.payload
+	c.mu.Unlock()
+
+	var resp model.NLUResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal nlu cache entry: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *MemoryNLUCache) Set(ctx context.Context, key string, resp *model.NLUResponse, ttl time.Duration) error {
+	if resp == nil {
+		return fmt.Errorf("nlu response is nil")
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal nlu cache entry: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoryNLUEntry{key: key, payload: b, expiresAt: time.Now().Add(ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryNLUEntry).key)
+	}
+	return nil
+}
+
+func (c *MemoryNLUCache) SetCatalogVersion(ctx context.Context, version string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version == version {
+		return false, nil
+	}
+	c.version = version
+	c.purgeLocked()
+	return true, nil
+}
+
+func (c *MemoryNLUCache) Purge(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purgeLocked()
+	return nil
+}
+
+func (c *MemoryNLUCache) purgeLocked() {
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+}
+
+var _ model.NLUCache = (*MemoryNLUCache)(nil)
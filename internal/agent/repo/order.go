@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisOrderStore persists carts (expiring after cartTTL of inactivity) and
+// orders (kept indefinitely) in Redis.
+type RedisOrderStore struct {
+	rdb     redis.Cmdable
+	cartTTL time.Duration
+}
+
+func NewRedisOrderStore(rdb redis.Cmdable, cartTTL time.Duration) *RedisOrderStore {
+	return &RedisOrderStore{rdb: rdb, cartTTL: cartTTL}
+}
+
+func (s *RedisOrderStore) cartKey(ownerID string) string {
+	return fmt.Sprintf("cart:%s", ownerID)
+}
+
+func (s *RedisOrderStore) orderKey(orderID string) string {
+	return fmt.Sprintf("order:%s", orderID)
+}
+
+func (s *RedisOrderStore) ownerOrdersKey(ownerID string) string {
+	return fmt.Sprintf("orders:%s", ownerID)
+}
+
+func (s *RedisOrderStore) LoadCart(ctx context.Context, ownerID string) (*model.Cart, error) {
+	key := s.cartKey(ownerID)
+	raw, err := s.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.Cart{OwnerID: ownerID}, nil
+		}
+		logx.Error().Err(err).Str("key", key).Msg("failed to load cart from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	var cart model.Cart
+	if err := json.Unmarshal([]byte(raw), &cart); err != nil {
+		logx.Error().Err(err).Str("owner_id", ownerID).Msg("failed to unmarshal cart")
+		return nil, fmt.Errorf("unmarshal cart: %w", err)
+	}
+	return &cart, nil
+}
+
+func (s *RedisOrderStore) SaveCart(ctx context.Context, cart *model.Cart) error {
+	if cart == nil {
+		return fmt.Errorf("cart is nil")
+	}
+	b, err := json.Marshal(cart)
+	if err != nil {
+		return fmt.Errorf("marshal cart: %w", err)
+	}
+	key := s.cartKey(cart.OwnerID)
+	if err := s.rdb.Set(ctx, key, b, s.cartTTL).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to save cart to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (s *RedisOrderStore) ClearCart(ctx context.Context, ownerID string) error {
+	key := s.cartKey(ownerID)
+	if err := s.rdb.Del(ctx, key).Err(); err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to clear cart in redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (s *RedisOrderStore) SaveOrder(ctx context.Context, order *model.Order) error {
+	if order == nil {
+		return fmt.Errorf("order is nil")
+	}
+	b, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("marshal order: %w", err)
+	}
+	key := s.orderKey(order.ID)
+	created, err := s.rdb.SetNX(ctx, key, b, 0).Result()
+	if err != nil {
+		logx.Error().Err(err).Str("key", key).Msg("failed to save order to redis")
+		return errx.WrapRedis(err)
+	}
+	if !created {
+		if err := s.rdb.Set(ctx, key, b, 0).Err(); err != nil {
+			logx.Error().Err(err).Str("key", key).Msg("failed to update order in redis")
+			return errx.WrapRedis(err)
+		}
+		return nil
+	}
+	// index new orders by owner, newest first
+	idx := s.ownerOrdersKey(order.OwnerID)
+	if err := s.rdb.LPush(ctx, idx, order.ID).Err(); err != nil {
+		logx.Error().Err(err).Str("key", idx).Msg("failed to index order in redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (s *RedisOrderStore) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	key := s.orderKey(orderID)
+	raw, err := s.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, model.ErrOrderNotFound
+		}
+		logx.Error().Err(err).Str("key", key).Msg("failed to load order from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	var order model.Order
+	if err := json.Unmarshal([]byte(raw), &order); err != nil {
+		logx.Error().Err(err).Str("order_id", orderID).Msg("failed to unmarshal order")
+		return nil, fmt.Errorf("unmarshal order: %w", err)
+	}
+	return &order, nil
+}
+
+func (s *RedisOrderStore) ListOrders(ctx context.Context, ownerID string) ([]*model.Order, error) {
+	idx := s.ownerOrdersKey(ownerID)
+	ids, err := s.rdb.LRange(ctx, idx, 0, -1).Result()
+	if err != nil {
+		logx.Error().Err(err).Str("key", idx).Msg("failed to list orders from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	out := make([]*model.Order, 0, len(ids))
+	for _, id := range ids {
+		o, err := s.GetOrder(ctx, id)
+		if err == model.ErrOrderNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
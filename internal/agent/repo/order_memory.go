@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// MemoryOrderStore keeps carts and orders in process memory. Values are
+// stored as JSON so callers never share mutable state with the store.
+type MemoryOrderStore struct {
+	mu     sync.RWMutex
+	carts  map[string][]byte
+	orders map[string][]byte
+	owners map[string][]string // owner -> order IDs, oldest first
+}
+
+// NewMemoryOrderStore returns a store holding the given orders.
+func NewMemoryOrderStore(orders ...*model.Order) *MemoryOrderStore {
+	s := &MemoryOrderStore{
+		carts:  map[string][]byte{},
+		orders: map[string][]byte{},
+		owners: map[string][]string{},
+	}
+	for _, o := range orders {
+		_ = s.SaveOrder(context.Background(), o)
+	}
+	return s
+}
+
+func (s *MemoryOrderStore) LoadCart(ctx context.Context, ownerID string) (*model.Cart, error) {
+	s.mu.RLock()
+	raw, ok := s.carts[ownerID]
+	s.mu.RUnlock()
+	if !ok {
+		return &model.Cart{OwnerID: ownerID}, nil
+	}
+	var cart model.Cart
+	if err := json.Unmarshal(raw, &cart); err != nil {
+		return nil, fmt.Errorf("unmarshal cart: %w", err)
+	}
+	return &cart, nil
+}
+
+func (s *MemoryOrderStore) SaveCart(ctx context.Context, cart *model.Cart) error {
+	if cart == nil {
+		return fmt.Errorf("cart is nil")
+	}
+	b, err := json.Marshal(cart)
+	if err != nil {
+		return fmt.Errorf("marshal cart: %w", err)
+	}
+	s.mu.Lock()
+	s.carts[cart.OwnerID] = b
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryOrderStore) ClearCart(ctx context.Context, ownerID string) error {
+	s.mu.Lock()
+	delete(s.carts, ownerID)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryOrderStore) SaveOrder(ctx context.Context, order *model.Order) error {
+	if order == nil {
+		return fmt.Errorf("order is nil")
+	}
+	b, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("marshal order: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.orders[order.ID]; !exists {
+		s.owners[order.OwnerID] = append(s.owners[order.OwnerID], order.ID)
+	}
+	s.orders[order.ID] = b
+	return nil
+}
+
+func (s *MemoryOrderStore) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	s.mu.RLock()
+	raw, ok := s.orders[orderID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, model.ErrOrderNotFound
+	}
+	var order model.Order
+	if err := json.Unmarshal(raw, &order); err != nil {
+		return nil, fmt.Errorf("unmarshal order: %w", err)
+	}
+	return &order, nil
+}
+
+func (s *MemoryOrderStore) ListOrders(ctx context.Context, ownerID string) ([]*model.Order, error) {
+	s.mu.RLock()
+	ids := append([]string(nil), s.owners[ownerID]...)
+	s.mu.RUnlock()
+	out := make([]*model.Order, 0, len(ids))
+	for _, id := range ids {
+		o, err := s.GetOrder(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
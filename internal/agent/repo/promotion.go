@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const promotionsKey = "promotions"
+
+// RedisPromotionStore keeps promotions in one Redis hash (ID -> JSON) so
+// they can be edited while the agent runs.
+type RedisPromotionStore struct {
+	rdb redis.Cmdable
+}
+
+func NewRedisPromotionStore(rdb redis.Cmdable) *RedisPromotionStore {
+	return &RedisPromotionStore{rdb: rdb}
+}
+
+func (s *RedisPromotionStore) List(ctx context.Context) ([]model.Promotion, error) {
+	raw, err := s.rdb.HGetAll(ctx, promotionsKey).Result()
+	if err != nil {
+		logx.Error().Err(err).Str("key", promotionsKey).Msg("failed to load promotions from redis")
+		return nil, errx.WrapRedis(err)
+	}
+	out := make([]model.Promotion, 0, len(raw))
+	for id, payload := range raw {
+		var p model.Promotion
+		if err := json.Unmarshal([]byte(payload), &p); err != nil {
+			logx.Warn().Err(err).Str("promotion_id", id).Msg("skipping unparseable promotion")
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *RedisPromotionStore) Put(ctx context.Context, p model.Promotion) error {
+	if p.ID == "" {
+		return fmt.Errorf("promotion id is required")
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal promotion: %w", err)
+	}
+	if err := s.rdb.HSet(ctx, promotionsKey, p.ID, b).Err(); err != nil {
+		logx.Error().Err(err).Str("promotion_id", p.ID).Msg("failed to save promotion to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (s *RedisPromotionStore) Delete(ctx context.Context, id string) error {
+	if err := s.rdb.HDel(ctx, promotionsKey, id).Err(); err != nil {
+		logx.Error().Err(err).Str("promotion_id", id).Msg("failed to delete promotion from redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// MemoryPromotionStore is a process-local promotion store keeping
+// insertion order.
+type MemoryPromotionStore struct {
+	mu     sync.RWMutex
+	promos []model.Promotion
+}
+
+func NewMemoryPromotionStore(promos []model.Promotion) *MemoryPromotionStore {
+	s := &MemoryPromotionStore{}
+	for _, p := range promos {
+		_ = s.Put(context.Background(), p)
+	}
+	return s
+}
+
+func (s *MemoryPromotionStore) List(ctx context.Context) ([]model.Promotion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]model.Promotion, len(s.promos))
+	copy(out, s.promos)
+	return out, nil
+}
+
+func (s *MemoryPromotionStore) Put(ctx context.Context, p model.Promotion) error {
+	if p.ID == "" {
+		return fmt.Errorf("promotion id is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.promos {
+		if s.promos[i].ID == p.ID {
+			s.promos[i] = p
+			return nil
+		}
+	}
+	s.promos = append(s.promos, p)
+	return nil
+}
+
+func (s *MemoryPromotionStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.promos {
+		if s.promos[i].ID == id {
+			s.promos = append(s.promos[:i], s.promos[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// LoadPromotionsFile reads a JSON array of promotions (or {"promotions": [...]}).
+func LoadPromotionsFile(path string) ([]model.Promotion, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read promotions file: %w", err)
+	}
+	var promos []model.Promotion
+	if err := json.Unmarshal(b, &promos); err != nil {
+		var wrapped struct {
+			Promotions []model.Promotion `json:"promotions"`
+		}
+		if err2 := json.Unmarshal(b, &wrapped); err2 != nil {
+			return nil, fmt.Errorf("parse promotions file %s: %w", path, err)
+		}
+		promos = wrapped.Promotions
+	}
+	for i, p := range promos {
+		if p.ID == "" {
+			return nil, fmt.Errorf("promotions file %s: entry %d has no id", path, i)
+		}
+		switch p.Kind {
+		case model.PromotionDiscount, model.PromotionBundle:
+		case model.PromotionCoupon:
+			if p.CouponCode == "" {
+				return nil, fmt.Errorf("promotions file %s: coupon %s has no coupon_code", path, p.ID)
+			}
+		default:
+			return nil, fmt.Errorf("promotions file %s: promotion %s has unknown kind %q", path, p.ID, p.Kind)
+		}
+	}
+	return promos, nil
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+	errx "github.com/Chative-core-poc-v1/server/internal/core/error"
+	logx "github.com/Chative-core-poc-v1/server/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const toolCacheEntryPrefix = "tool_cache:entry:"
+
+// RedisToolResultCache shares tool results across processes.
+type RedisToolResultCache struct {
+	rdb redis.Cmdable
+}
+
+func NewRedisToolResultCache(rdb redis.Cmdable) *RedisToolResultCache {
+	return &RedisToolResultCache{rdb: rdb}
+}
+
+func (c *RedisToolResultCache) entryKey(tool, key string) string {
+	return toolCacheEntryPrefix + tool + ":" + key
+}
+
+func (c *RedisToolResultCache) Get(ctx context.Context, tool, key string) (string, bool, error) {
+	rkey := c.entryKey(tool, key)
+	raw, err := c.rdb.Get(ctx, rkey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", false, nil
+		}
+		logx.Error().Err(err).Str("key", rkey).Msg("failed to load tool cache entry from redis")
+		return "", false, errx.WrapRedis(err)
+	}
+	return raw, true, nil
+}
+
+func (c *RedisToolResultCache) Set(ctx context.Context, tool, key, result string, ttl time.Duration) error {
+	rkey := c.entryKey(tool, key)
+	if err := c.rdb.Set(ctx, rkey, result, ttl).Err(); err != nil {
+		logx.Error().Err(err).Str("key", rkey).Msg("failed to save tool cache entry to redis")
+		return errx.WrapRedis(err)
+	}
+	return nil
+}
+
+func (c *RedisToolResultCache) Invalidate(ctx context.Context, tool string) error {
+	return c.deleteMatching(ctx, toolCacheEntryPrefix+tool+":*")
+}
+
+func (c *RedisToolResultCache) Purge(ctx context.Context) error {
+	return c.deleteMatching(ctx, toolCacheEntryPrefix+"*")
+}
+
+func (c *RedisToolResultCache) deleteMatching(ctx context.Context, pattern string) error {
+	var cursor uint64
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, pattern, 500).Result()
+		if err != nil {
+			logx.Error().Err(err).Str("pattern", pattern).Msg("failed to scan tool cache entries")
+			return errx.WrapRedis(err)
+		}
+		if len(keys) > 0 {
+			if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+				logx.Error().Err(err).Int("keys", len(keys)).Msg("failed to delete tool cache entries")
+				return errx.WrapRedis(err)
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+var _ model.ToolResultCache = (*RedisToolResultCache)(nil)
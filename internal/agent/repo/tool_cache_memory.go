@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Chative-core-poc-v1/server/internal/agent/model"
+)
+
+// MemoryToolResultCache is a process-local LRU of tool results with
+// per-entry expiry.
+type MemoryToolResultCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type memoryToolEntry struct {
+	id        string // tool + "\x00" + key
+	tool      string
+	result    string
+	expiresAt time.Time
+}
+
+func NewMemoryToolResultCache(size int) *MemoryToolResultCache {
+	if size <= 0 {
+		size = 2000
+	}
+	return &MemoryToolResultCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *MemoryToolResultCache) Get(ctx context.Context, tool, key string) (string, bool, error) {
+	id := tool + "\x00" + key
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[id]
+	if !ok {
+		return "", false, nil
+	}
+	entry := el.Value.(*memoryToolEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, id)
+		return "", false, nil
+	}
+	c.order.MoveToFront(el)
+	return entry.result, true, nil
+}
+
+func (c *MemoryToolResultCache) Set(ctx context.Context, tool, key, result string, ttl time.Duration) error {
+	id := tool + "\x00" + key
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoryToolEntry{id: id, tool: tool, result: result, expiresAt: time.Now().Add(ttl)}
+	if el, ok := c.entries[id]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return nil
+	}
+	c.entries[id] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryToolEntry).id)
+	}
+	return nil
+}
+
+func (c *MemoryToolResultCache) Invalidate(ctx context.Context, tool string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if entry := el.Value.(*memoryToolEntry); entry.tool == tool {
+			c.order.Remove(el)
+			delete(c.entries, entry.id)
+		}
+		el = next
+	}
+	return nil
+}
+
+func (c *MemoryToolResultCache) Purge(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+	return nil
+}
+
+var _ model.ToolResultCache = (*MemoryToolResultCache)(nil)
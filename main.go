@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/Chative-core-poc-v1/server/internal/agent/graph"
+	"github.com/Chative-core-poc-v1/server/internal/agent/graph/tools"
+	"github.com/Chative-core-poc-v1/server/internal/agent/knowledge"
+	"github.com/Chative-core-poc-v1/server/internal/agent/mcp"
 	"github.com/Chative-core-poc-v1/server/internal/agent/model"
 	"github.com/Chative-core-poc-v1/server/internal/agent/repo"
 	pkgredis "github.com/Chative-core-poc-v1/server/pkg/redis"
@@ -29,6 +32,11 @@ type AppConfig struct {
 	Response     model.ResponseModelConfig
 	Prompt       model.ResponsePromptConfig
 	Conversation model.ConversationConfig
+	Catalog      model.CatalogConfig
+	Promotions   model.PromotionsConfig
+	Orders       model.OrdersConfig
+	Knowledge    model.KnowledgeConfig
+	Tools        model.ToolsConfig
 }
 
 func main() {
@@ -67,9 +75,137 @@ func main() {
 		ResponseModel:    envCfg.Response,
 		ResponsePrompt:   envCfg.Prompt,
 		Conversation:     envCfg.Conversation,
+		Tools:            envCfg.Tools,
 		ConversationRepo: repo.NewRedisConversationRepository(rdb, ttl),
+		// Slot state shares the conversation TTL so both expire together
+		DialogueStateRepo: repo.NewRedisDialogueStateRepository(rdb, ttl),
 	}
 
+	switch envCfg.NLU.Cache.Backend {
+	case model.NLUCacheMemory:
+		cfg.NLUCache = repo.NewMemoryNLUCache(envCfg.NLU.Cache.Size)
+	case model.NLUCacheRedis:
+		cfg.NLUCache = repo.NewRedisNLUCache(rdb)
+	case model.NLUCacheOff, "":
+	default:
+		log.Fatalf("Invalid NLU_CACHE_BACKEND '%s' (expected memory|redis|off)", envCfg.NLU.Cache.Backend)
+	}
+
+	switch envCfg.Tools.CacheBackend {
+	case model.ToolCacheMemory:
+		cfg.ToolCache = repo.NewMemoryToolResultCache(envCfg.Tools.CacheSize)
+	case model.ToolCacheRedis:
+		cfg.ToolCache = repo.NewRedisToolResultCache(rdb)
+	case model.ToolCacheOff, "":
+	default:
+		log.Fatalf("Invalid TOOLS_CACHE_BACKEND '%s' (expected memory|redis|off)", envCfg.Tools.CacheBackend)
+	}
+
+	switch envCfg.Catalog.Backend {
+	case model.CatalogMemory, "":
+		cfg.Catalog = tools.NewMemoryCatalog(tools.DefaultProducts())
+	case model.CatalogFile:
+		interval, err := time.ParseDuration(envCfg.Catalog.ReloadInterval)
+		if err != nil {
+			log.Fatalf("Invalid CATALOG_RELOAD_INTERVAL '%s': %v", envCfg.Catalog.ReloadInterval, err)
+		}
+		fileCatalog, err := tools.NewFileCatalog(envCfg.Catalog.Path, interval)
+		if err != nil {
+			log.Fatalf("Failed to load product catalog: %v", err)
+		}
+		defer fileCatalog.Close()
+		cfg.Catalog = fileCatalog
+	case model.CatalogHTTP:
+		timeout, err := time.ParseDuration(envCfg.Catalog.Timeout)
+		if err != nil {
+			log.Fatalf("Invalid CATALOG_TIMEOUT '%s': %v", envCfg.Catalog.Timeout, err)
+		}
+		httpCatalog, err := tools.NewHTTPCatalog(envCfg.Catalog.URL, envCfg.Catalog.APIKey, timeout)
+		if err != nil {
+			log.Fatalf("Failed to create catalog client: %v", err)
+		}
+		cfg.Catalog = httpCatalog
+	default:
+		log.Fatalf("Invalid CATALOG_BACKEND '%s' (expected memory|file|http)", envCfg.Catalog.Backend)
+	}
+
+	switch envCfg.Promotions.Backend {
+	case model.PromotionsMemory, "":
+		cfg.Promotions = repo.NewMemoryPromotionStore(tools.DefaultPromotions(time.Now()))
+	case model.PromotionsFile:
+		promos, err := repo.LoadPromotionsFile(envCfg.Promotions.Path)
+		if err != nil {
+			log.Fatalf("Failed to load promotions: %v", err)
+		}
+		cfg.Promotions = repo.NewMemoryPromotionStore(promos)
+	case model.PromotionsRedis:
+		cfg.Promotions = repo.NewRedisPromotionStore(rdb)
+	default:
+		log.Fatalf("Invalid PROMOTIONS_BACKEND '%s' (expected memory|file|redis)", envCfg.Promotions.Backend)
+	}
+
+	reservationTTL, err := time.ParseDuration(envCfg.Orders.ReservationTTL)
+	if err != nil {
+		log.Fatalf("Invalid ORDER_RESERVATION_TTL '%s': %v", envCfg.Orders.ReservationTTL, err)
+	}
+	cfg.OrderReservationTTL = reservationTTL
+	switch envCfg.Orders.Backend {
+	case model.OrdersMemory, "":
+		cfg.Orders = repo.NewMemoryOrderStore(tools.DemoOrders(time.Now())...)
+	case model.OrdersRedis:
+		cartTTL, err := time.ParseDuration(envCfg.Orders.CartTTL)
+		if err != nil {
+			log.Fatalf("Invalid ORDER_CART_TTL '%s': %v", envCfg.Orders.CartTTL, err)
+		}
+		cfg.Orders = repo.NewRedisOrderStore(rdb, cartTTL)
+	default:
+		log.Fatalf("Invalid ORDERS_BACKEND '%s' (expected memory|redis)", envCfg.Orders.Backend)
+	}
+
+	kb, err := knowledge.Open(ctx, knowledge.Config{
+		Dir:       envCfg.Knowledge.Dir,
+		IndexPath: envCfg.Knowledge.IndexPath,
+		Chunks:    knowledge.ChunkOptions{Size: envCfg.Knowledge.ChunkSize, Overlap: envCfg.Knowledge.ChunkOverlap},
+		Embedder:  knowledge.NewHashEmbedder(envCfg.Knowledge.EmbeddingDim),
+	})
+	if err != nil {
+		log.Fatalf("Failed to open knowledge base: %v", err)
+	}
+	cfg.Knowledge = kb
+
+	// REST-backed tools join the registry before the graph selects tools
+	for _, path := range envCfg.Tools.HTTPSpecs {
+		specs, err := tools.LoadHTTPToolSpecs(path)
+		if err != nil {
+			log.Fatalf("Failed to load HTTP tools: %v", err)
+		}
+		if err := tools.RegisterHTTPTools(specs); err != nil {
+			log.Fatalf("Failed to register HTTP tools: %v", err)
+		}
+	}
+	if envCfg.Tools.MCPServers != "" {
+		servers, err := mcp.LoadServers(envCfg.Tools.MCPServers)
+		if err != nil {
+			log.Fatalf("Failed to load MCP servers: %v", err)
+		}
+		clients, err := mcp.Import(ctx, servers)
+		for _, c := range clients {
+			defer c.Close()
+		}
+		if err != nil {
+			log.Fatalf("Failed to import MCP tools: %v", err)
+		}
+	}
+
+	confirmTTL, err := time.ParseDuration(envCfg.Conversation.Tools.ConfirmTTL)
+	if err != nil {
+		log.Fatalf("Invalid CONVERSATION_TOOL_CONFIRM_TTL '%s': %v", envCfg.Conversation.Tools.ConfirmTTL, err)
+	}
+	// Paused runs live exactly as long as their confirmation question
+	cfg.ToolConfirmTTL = confirmTTL
+	cfg.PendingActions = repo.NewRedisPendingActionRepository(rdb)
+	cfg.CheckPoints = repo.NewRedisCheckPointStore(rdb, confirmTTL)
+
 	runner, err := graph.BuildResponseGraph(ctx, cfg)
 	if err != nil {
 		log.Fatalf("Failed to build graph: %v", err)
@@ -91,10 +227,10 @@ func main() {
 			description: "Follow-up with thanks",
 			query:       "ขอบคุณครับ",
 		},
-		// {
-		// 	description: "Purchase decision and support request",
-		// 	query:       "ตกลงเอาคอม Acer แล้วกัน",
-		// },
+		{
+			description: "Purchase decision",
+			query:       "ตกลงเอาคอม Acer แล้วกัน",
+		},
 	}
 
 	conversationID := "test-conversation-123451"
@@ -119,5 +255,10 @@ func main() {
 		time.Sleep(500 * time.Millisecond)
 	}
 
+	for _, st := range runner.ToolHealth() {
+		if st.State != tools.BreakerClosed {
+			fmt.Printf("Tool %s breaker %s after %d failures: %s\n", st.Tool, st.State, st.Failures, st.LastError)
+		}
+	}
 	fmt.Println("All graph tests completed successfully!")
 }